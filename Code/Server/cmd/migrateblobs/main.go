@@ -0,0 +1,81 @@
+// Command migrateblobs moves the content of images and documents still kept in the database to the configured storage.
+// It can be run again safely, only the blobs without storage key are moved.
+// Once it has run on every deployment, the data columns of images and documents can be dropped.
+package main
+
+import (
+	"log"
+
+	"github.com/joho/godotenv"
+	"keyz/backend/models"
+	"keyz/backend/services"
+	"keyz/backend/services/database"
+	"keyz/backend/services/storage"
+)
+
+const batchSize = 100
+
+func moveImages() int {
+	count := 0
+	for {
+		images := database.GetDatabaseImages(batchSize)
+		if len(images) == 0 {
+			return count
+		}
+		for _, image := range images {
+			key := storage.NewKey("images")
+			data, _ := image.Data()
+			if err := storage.Client.Put(key, data, "image/"+string(image.Type)); err != nil {
+				log.Fatalln(err)
+			}
+			database.MoveImageToStorage(image.ID, key)
+			count++
+		}
+		log.Println("Moved", count, "images")
+	}
+}
+
+func moveDocuments() int {
+	count := 0
+	for {
+		documents := database.GetDatabaseDocuments(batchSize)
+		if len(documents) == 0 {
+			return count
+		}
+		for _, document := range documents {
+			key := storage.NewKey("documents")
+			data, _ := document.Data()
+			if err := storage.Client.Put(key, data, models.DocumentMimeTypes[document.Type]); err != nil {
+				log.Fatalln(err)
+			}
+			database.MoveDocumentToStorage(document.ID, key)
+			count++
+		}
+		log.Println("Moved", count, "documents")
+	}
+}
+
+func main() {
+	err := godotenv.Load()
+	if err != nil {
+		log.Println("WARNING: failed loading .env file")
+	}
+
+	if err := storage.Connect(); err != nil {
+		log.Fatalln(err)
+	}
+	if storage.Client == nil {
+		log.Fatalln("STORAGE_BACKEND must be local or s3 to move blobs out of the database")
+	}
+
+	c, err := services.ConnectDB()
+	if err != nil {
+		log.Fatalln(err)
+	}
+	defer func() { _ = c.Client.Disconnect() }()
+
+	log.Println("Connected to database, moving blobs...")
+	images := moveImages()
+	documents := moveDocuments()
+	log.Println("Done,", images, "images and", documents, "documents moved")
+}
@@ -12,6 +12,7 @@ import (
 	"keyz/backend/prisma/db"
 	"keyz/backend/services/chatgpt"
 	"keyz/backend/services/database"
+	"keyz/backend/services/storage"
 	"keyz/backend/utils"
 )
 
@@ -20,7 +21,11 @@ const chatGPTerror = "error"
 func imagesToBase64Strings(images []db.ImageModel) []string {
 	res := make([]string, len(images))
 	for i, img := range images {
-		res[i] = "data:image/" + string(img.Type) + ";base64," + base64.StdEncoding.EncodeToString(img.Data)
+		data, err := storage.Load(img.InnerImage.Data, img.InnerImage.StorageKey)
+		if err != nil {
+			panic(err)
+		}
+		res[i] = "data:image/" + string(img.Type) + ";base64," + base64.StdEncoding.EncodeToString(data)
 	}
 	return res
 }
@@ -215,3 +220,25 @@ func handleFurnitureComparison2(c *gin.Context, req models.CompareRequest, fs db
 	}
 	c.JSON(http.StatusOK, resp)
 }
+
+// triageDamage asks ChatGPT to triage a newly created damage and stores its suggestions.
+// It runs in the background, so failures are only logged and never affect the damage creation.
+func triageDamage(damageId string, roomName string, comment string, pictures []string) {
+	defer func() {
+		if err := recover(); err != nil {
+			log.Println("Damage triage failed:", err)
+		}
+	}()
+
+	chatGPTres, err := chatgpt.TriageDamage(roomName, comment, pictures)
+	if err != nil {
+		log.Println("Damage triage failed:", err)
+		return
+	}
+	triage, err := models.ParseDamageTriage(chatGPTres)
+	if err != nil {
+		log.Println("Damage triage failed:", err, chatGPTres)
+		return
+	}
+	database.UpdateDamageTriage(damageId, triage)
+}
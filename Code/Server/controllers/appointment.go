@@ -0,0 +1,175 @@
+package controllers
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"keyz/backend/models"
+	"keyz/backend/prisma/db"
+	"keyz/backend/services/brevo"
+	"keyz/backend/services/database"
+	"keyz/backend/utils"
+)
+
+// proposeAppointments replaces the pending proposals of a damage by the given slots.
+func proposeAppointments(c *gin.Context, damage db.DamageModel, proposedBy db.AppointmentParty) {
+	var req models.AppointmentProposalRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.SendError(c, http.StatusBadRequest, utils.MissingFields, err)
+		return
+	}
+	if damage.IsClosed() {
+		utils.SendError(c, http.StatusBadRequest, utils.DamageClosed, nil)
+		return
+	}
+	if damage.IsFixed() {
+		utils.SendError(c, http.StatusBadRequest, utils.DamageAlreadyFixed, nil)
+		return
+	}
+	now := time.Now()
+	for _, slot := range req.Slots {
+		if !slot.StartAt.After(now) {
+			utils.SendError(c, http.StatusBadRequest, utils.AppointmentInPast, nil)
+			return
+		}
+	}
+
+	database.DeclineProposedAppointments(damage.ID)
+	appointments := make([]db.DamageAppointmentModel, 0, len(req.Slots))
+	for _, slot := range req.Slots {
+		appointments = append(appointments, database.CreateDamageAppointment(slot.ToDbDamageAppointment(proposedBy), damage.ID))
+	}
+	c.JSON(http.StatusCreated, utils.Map(appointments, models.DbDamageAppointmentToResponse))
+}
+
+// ProposeDamageAppointments godoc
+//
+//	@Summary		Propose appointment slots
+//	@Description	Propose up to 5 time slots to repair a damage. The slots previously proposed and not confirmed are declined.
+//	@Description	Owners propose slots to the tenant, tenants counter-propose slots to the owner.
+//	@Tags			damage
+//	@Accept			json
+//	@Produce		json
+//	@Param			property_id	path		string								true	"Property ID"
+//	@Param			lease_id	path		string								true	"Lease ID"
+//	@Param			damage_id	path		string								true	"Damage ID"
+//	@Param			slots		body		models.AppointmentProposalRequest	true	"Proposed slots"
+//	@Success		201			{array}		models.AppointmentResponse			"Proposed appointments"
+//	@Failure		400			{object}	utils.Error							"Missing fields, slot in the past, damage fixed or closed"
+//	@Failure		403			{object}	utils.Error							"Lease not yours"
+//	@Failure		404			{object}	utils.Error							"Damage not found"
+//	@Failure		500
+//	@Security		Bearer
+//	@Router			/owner/properties/{property_id}/leases/{lease_id}/damages/{damage_id}/appointments/ [post]
+//	@Router			/tenant/leases/{lease_id}/damages/{damage_id}/appointments/ [post]
+func ProposeDamageAppointments(c *gin.Context) {
+	claims := utils.GetClaims(c)
+	damage, _ := c.MustGet("damage").(db.DamageModel)
+	proposeAppointments(c, damage, db.AppointmentParty(claims["role"]))
+}
+
+// GetDamageAppointments godoc
+//
+//	@Summary		Get damage appointments
+//	@Description	Get all appointments proposed for a damage, ordered by start date
+//	@Tags			damage
+//	@Accept			json
+//	@Produce		json
+//	@Param			property_id	path		string						true	"Property ID"
+//	@Param			lease_id	path		string						true	"Lease ID"
+//	@Param			damage_id	path		string						true	"Damage ID"
+//	@Success		200			{array}		models.AppointmentResponse	"List of appointments"
+//	@Failure		403			{object}	utils.Error					"Lease not yours"
+//	@Failure		404			{object}	utils.Error					"Damage not found"
+//	@Failure		500
+//	@Security		Bearer
+//	@Router			/owner/properties/{property_id}/leases/{lease_id}/damages/{damage_id}/appointments/ [get]
+//	@Router			/tenant/leases/{lease_id}/damages/{damage_id}/appointments/ [get]
+func GetDamageAppointments(c *gin.Context) {
+	damage, _ := c.MustGet("damage").(db.DamageModel)
+	appointments := database.GetDamageAppointments(damage.ID)
+	c.JSON(http.StatusOK, utils.Map(appointments, models.DbDamageAppointmentToResponse))
+}
+
+// ConfirmDamageAppointment godoc
+//
+//	@Summary		Confirm an appointment
+//	@Description	Confirm a slot proposed by the other party. The other proposals are declined, a previously confirmed appointment is cancelled
+//	@Description	and the damage fix is planned at the start of the slot. The tenant, the owner and the contractor receive a calendar invite by email.
+//	@Tags			damage
+//	@Accept			json
+//	@Produce		json
+//	@Param			property_id		path		string						true	"Property ID"
+//	@Param			lease_id		path		string						true	"Lease ID"
+//	@Param			damage_id		path		string						true	"Damage ID"
+//	@Param			appointment_id	path		string						true	"Appointment ID"
+//	@Success		200				{object}	models.AppointmentResponse	"Confirmed appointment"
+//	@Failure		400				{object}	utils.Error					"Appointment not proposed or in the past"
+//	@Failure		403				{object}	utils.Error					"Appointment proposed by you"
+//	@Failure		404				{object}	utils.Error					"Damage or appointment not found"
+//	@Failure		500
+//	@Security		Bearer
+//	@Router			/owner/properties/{property_id}/leases/{lease_id}/damages/{damage_id}/appointments/{appointment_id}/confirm/ [put]
+//	@Router			/tenant/leases/{lease_id}/damages/{damage_id}/appointments/{appointment_id}/confirm/ [put]
+func ConfirmDamageAppointment(c *gin.Context) {
+	claims := utils.GetClaims(c)
+	damage, _ := c.MustGet("damage").(db.DamageModel)
+	appointment, _ := c.MustGet("appointment").(db.DamageAppointmentModel)
+
+	if appointment.Status != db.AppointmentStatusProposed {
+		utils.SendError(c, http.StatusBadRequest, utils.AppointmentNotProposed, nil)
+		return
+	}
+	if !appointment.StartAt.After(time.Now()) {
+		utils.SendError(c, http.StatusBadRequest, utils.AppointmentInPast, nil)
+		return
+	}
+	// the owner and the contractor are on the same side, only the tenant can confirm their slots
+	tenantProposed := appointment.ProposedBy == db.AppointmentPartyTenant
+	if tenantProposed == (db.Role(claims["role"]) == db.RoleTenant) {
+		utils.SendError(c, http.StatusForbidden, utils.CannotConfirmOwnAppointment, nil)
+		return
+	}
+
+	confirmed := database.ConfirmDamageAppointment(appointment.ID)
+	database.DeclineProposedAppointments(damage.ID)
+	database.CancelConfirmedAppointments(damage.ID, confirmed.ID)
+
+	newDamage := database.UpdateDamageOwner(damage, models.DamageOwnerUpdateRequest{FixPlannedAt: &confirmed.StartAt})
+	if newDamage != nil {
+		recordDamageEvents(c, damage.Changes(*newDamage))
+	}
+
+	res, err := brevo.SendAppointmentConfirmed(damage, confirmed)
+	if err != nil {
+		log.Println(res, err.Error())
+	}
+
+	c.JSON(http.StatusOK, models.DbDamageAppointmentToResponse(confirmed))
+}
+
+// ProposeContractorJobAppointments godoc
+//
+//	@Summary		Propose contractor appointment slots
+//	@Description	Propose up to 5 time slots to the tenant for the damage assigned to a contractor, using the link they received by email.
+//	@Description	The slots previously proposed and not confirmed are declined.
+//	@Tags			contractor
+//	@Accept			json
+//	@Produce		json
+//	@Param			token	path		string								true	"Job token"
+//	@Param			slots	body		models.AppointmentProposalRequest	true	"Proposed slots"
+//	@Success		201		{array}		models.AppointmentResponse			"Proposed appointments"
+//	@Failure		400		{object}	utils.Error							"Missing fields, slot in the past, damage fixed or closed"
+//	@Failure		404		{object}	utils.Error							"Job not found"
+//	@Failure		500
+//	@Router			/contractor/jobs/{token}/appointments/ [post]
+func ProposeContractorJobAppointments(c *gin.Context) {
+	damage := database.GetDamageByContractorToken(c.Param("token"))
+	if damage == nil {
+		utils.SendError(c, http.StatusNotFound, utils.ContractorJobNotFound, nil)
+		return
+	}
+	proposeAppointments(c, *damage, db.AppointmentPartyContractor)
+}
@@ -0,0 +1,257 @@
+package controllers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"keyz/backend/models"
+	"keyz/backend/prisma/db"
+	"keyz/backend/router"
+	"keyz/backend/services"
+	"keyz/backend/services/database"
+)
+
+func BuildTestAppointment(id string, proposedBy db.AppointmentParty) db.DamageAppointmentModel {
+	return db.DamageAppointmentModel{
+		InnerDamageAppointment: db.InnerDamageAppointment{
+			ID:              id,
+			DamageID:        "1",
+			StartAt:         time.Now().Add(48 * time.Hour).Truncate(time.Minute),
+			DurationMinutes: 60,
+			Status:          db.AppointmentStatusProposed,
+			ProposedBy:      proposedBy,
+			CreatedAt:       time.Now(),
+		},
+	}
+}
+
+func TestProposeDamageAppointments(t *testing.T) {
+	c, mock, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	property := BuildTestProperty("1")
+	lease := BuildTestLease("1")
+	damage := BuildTestDamage("1")
+	appointment := BuildTestAppointment("1", db.AppointmentPartyOwner)
+	slot := models.AppointmentSlotRequest{
+		StartAt:         appointment.StartAt,
+		DurationMinutes: appointment.DurationMinutes,
+	}
+	mock.Property.Expect(database.MockGetPropertyByID(c)).Returns(property)
+	mock.Lease.Expect(database.MockGetLeaseByID(c)).Returns(lease)
+	mock.Damage.Expect(database.MockGetDamageByID(c)).Returns(damage)
+	mock.DamageAppointment.Expect(database.MockDeclineProposedAppointments(c)).Returns(db.DamageAppointmentModel{})
+	mock.DamageAppointment.Expect(database.MockCreateDamageAppointment(c, slot.ToDbDamageAppointment(db.AppointmentPartyOwner))).Returns(appointment)
+
+	reqBody := models.AppointmentProposalRequest{
+		Slots: []models.AppointmentSlotRequest{slot},
+	}
+	b, err := json.Marshal(reqBody)
+	require.NoError(t, err)
+
+	r := router.TestRoutes()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/v1/owner/properties/1/leases/1/damages/1/appointments/", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Oauth.claims.id", "1")
+	req.Header.Set("Oauth.claims.role", string(db.RoleOwner))
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusCreated, w.Code)
+	var resp []models.AppointmentResponse
+	err = json.Unmarshal(w.Body.Bytes(), &resp)
+	require.NoError(t, err)
+	require.Len(t, resp, 1)
+	assert.Equal(t, appointment.ID, resp[0].ID)
+	assert.Equal(t, appointment.DurationMinutes, resp[0].DurationMinutes)
+}
+
+func TestProposeDamageAppointments_InPast(t *testing.T) {
+	c, mock, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	lease := BuildTestLease("1")
+	damage := BuildTestDamage("1")
+	mock.Lease.Expect(database.MockGetLeaseByID(c)).Returns(lease)
+	mock.Damage.Expect(database.MockGetDamageByID(c)).Returns(damage)
+
+	reqBody := models.AppointmentProposalRequest{
+		Slots: []models.AppointmentSlotRequest{{
+			StartAt:         time.Now().Add(-time.Hour),
+			DurationMinutes: 60,
+		}},
+	}
+	b, err := json.Marshal(reqBody)
+	require.NoError(t, err)
+
+	r := router.TestRoutes()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/v1/tenant/leases/1/damages/1/appointments/", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Oauth.claims.id", "1")
+	req.Header.Set("Oauth.claims.role", string(db.RoleTenant))
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestProposeDamageAppointments_BadDuration(t *testing.T) {
+	c, mock, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	mock.Lease.Expect(database.MockGetLeaseByID(c)).Returns(BuildTestLease("1"))
+	mock.Damage.Expect(database.MockGetDamageByID(c)).Returns(BuildTestDamage("1"))
+
+	reqBody := models.AppointmentProposalRequest{
+		Slots: []models.AppointmentSlotRequest{{
+			StartAt:         time.Now().Add(time.Hour),
+			DurationMinutes: 5,
+		}},
+	}
+	b, err := json.Marshal(reqBody)
+	require.NoError(t, err)
+
+	r := router.TestRoutes()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/v1/tenant/leases/1/damages/1/appointments/", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Oauth.claims.id", "1")
+	req.Header.Set("Oauth.claims.role", string(db.RoleTenant))
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestGetDamageAppointments(t *testing.T) {
+	c, mock, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	lease := BuildTestLease("1")
+	damage := BuildTestDamage("1")
+	appointment := BuildTestAppointment("1", db.AppointmentPartyOwner)
+	mock.Lease.Expect(database.MockGetLeaseByID(c)).Returns(lease)
+	mock.Damage.Expect(database.MockGetDamageByID(c)).Returns(damage)
+	mock.DamageAppointment.Expect(database.MockGetDamageAppointments(c)).ReturnsMany([]db.DamageAppointmentModel{appointment})
+
+	r := router.TestRoutes()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/v1/tenant/leases/1/damages/1/appointments/", nil)
+	req.Header.Set("Oauth.claims.id", "1")
+	req.Header.Set("Oauth.claims.role", string(db.RoleTenant))
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp []models.AppointmentResponse
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	require.NoError(t, err)
+	require.Len(t, resp, 1)
+	assert.Equal(t, db.AppointmentStatusProposed, resp[0].Status)
+}
+
+func TestConfirmDamageAppointment(t *testing.T) {
+	c, mock, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	lease := BuildTestLease("1")
+	damage := BuildTestDamage("1")
+	damage.RelationsDamage.Lease = &lease
+	appointment := BuildTestAppointment("1", db.AppointmentPartyOwner)
+	confirmed := appointment
+	confirmed.Status = db.AppointmentStatusConfirmed
+	mock.Lease.Expect(database.MockGetLeaseByID(c)).Returns(lease)
+	mock.Damage.Expect(database.MockGetDamageByID(c)).Returns(damage)
+	mock.DamageAppointment.Expect(database.MockGetDamageAppointmentByID(c)).Returns(appointment)
+	mock.DamageAppointment.Expect(database.MockConfirmDamageAppointment(c)).Returns(confirmed)
+	mock.DamageAppointment.Expect(database.MockDeclineProposedAppointments(c)).Returns(db.DamageAppointmentModel{})
+	mock.DamageAppointment.Expect(database.MockCancelConfirmedAppointments(c)).Returns(db.DamageAppointmentModel{})
+	mock.Damage.Expect(database.MockUpdateDamageOwner(c, models.DamageOwnerUpdateRequest{
+		FixPlannedAt: &confirmed.StartAt,
+	})).Returns(damage)
+
+	r := router.TestRoutes()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPut, "/v1/tenant/leases/1/damages/1/appointments/1/confirm/", nil)
+	req.Header.Set("Oauth.claims.id", "1")
+	req.Header.Set("Oauth.claims.role", string(db.RoleTenant))
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp models.AppointmentResponse
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	require.NoError(t, err)
+	assert.Equal(t, db.AppointmentStatusConfirmed, resp.Status)
+}
+
+func TestConfirmDamageAppointment_OwnProposal(t *testing.T) {
+	c, mock, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	property := BuildTestProperty("1")
+	lease := BuildTestLease("1")
+	damage := BuildTestDamage("1")
+	mock.Property.Expect(database.MockGetPropertyByID(c)).Returns(property)
+	mock.Lease.Expect(database.MockGetLeaseByID(c)).Returns(lease)
+	mock.Damage.Expect(database.MockGetDamageByID(c)).Returns(damage)
+	mock.DamageAppointment.Expect(database.MockGetDamageAppointmentByID(c)).Returns(BuildTestAppointment("1", db.AppointmentPartyContractor))
+
+	r := router.TestRoutes()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPut, "/v1/owner/properties/1/leases/1/damages/1/appointments/1/confirm/", nil)
+	req.Header.Set("Oauth.claims.id", "1")
+	req.Header.Set("Oauth.claims.role", string(db.RoleOwner))
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestConfirmDamageAppointment_NotProposed(t *testing.T) {
+	c, mock, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	lease := BuildTestLease("1")
+	damage := BuildTestDamage("1")
+	appointment := BuildTestAppointment("1", db.AppointmentPartyOwner)
+	appointment.Status = db.AppointmentStatusDeclined
+	mock.Lease.Expect(database.MockGetLeaseByID(c)).Returns(lease)
+	mock.Damage.Expect(database.MockGetDamageByID(c)).Returns(damage)
+	mock.DamageAppointment.Expect(database.MockGetDamageAppointmentByID(c)).Returns(appointment)
+
+	r := router.TestRoutes()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPut, "/v1/tenant/leases/1/damages/1/appointments/1/confirm/", nil)
+	req.Header.Set("Oauth.claims.id", "1")
+	req.Header.Set("Oauth.claims.role", string(db.RoleTenant))
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestProposeContractorJobAppointments_NotFound(t *testing.T) {
+	c, mock, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	mock.Damage.Expect(database.MockGetDamageByContractorToken(c, "token")).Errors(db.ErrNotFound)
+
+	reqBody := models.AppointmentProposalRequest{
+		Slots: []models.AppointmentSlotRequest{{
+			StartAt:         time.Now().Add(time.Hour),
+			DurationMinutes: 60,
+		}},
+	}
+	b, err := json.Marshal(reqBody)
+	require.NoError(t, err)
+
+	r := router.TestRoutes()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/v1/contractor/jobs/token/appointments/", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -2,6 +2,7 @@ package controllers
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/maxzerbini/oauth"
@@ -73,28 +74,37 @@ func RegisterOwner(c *gin.Context) {
 //	@Tags			auth
 //	@Accept			json
 //	@Produce		json
-//	@Param			id		path		string				true	"Pending lease ID"
-//	@Param			user	body		models.UserRequest	true	"Tenant user data"
-//	@Success		201		{object}	models.IdResponse	"Created user ID"
-//	@Failure		400		{object}	utils.Error			"Missing fields"
-//	@Failure		404		{object}	utils.Error			"Pending lease not found"
-//	@Failure		409		{object}	utils.Error			"Email already exists"
+//	@Param			token	path		string							true	"Invite token"
+//	@Param			user	body		models.TenantRegisterRequest	true	"Tenant user data and acceptance of the lease terms"
+//	@Success		201		{object}	models.IdResponse				"Created user ID"
+//	@Failure		400		{object}	utils.Error						"Missing fields or lease terms not accepted"
+//	@Failure		404		{object}	utils.Error						"Pending lease not found"
+//	@Failure		409		{object}	utils.Error						"Email already exists"
+//	@Failure		410		{object}	utils.Error						"Invite expired"
 //	@Failure		500
-//	@Router			/auth/invite/{id}/ [post]
+//	@Router			/auth/invite/{token}/ [post]
 func RegisterTenant(c *gin.Context) {
-	var userReq models.UserRequest
+	var userReq models.TenantRegisterRequest
 	err := c.ShouldBindBodyWithJSON(&userReq)
 	if err != nil {
 		utils.SendError(c, http.StatusBadRequest, utils.MissingFields, err)
 		return
 	}
+	if !userReq.AcceptTerms {
+		utils.SendError(c, http.StatusBadRequest, utils.LeaseTermsNotAccepted, nil)
+		return
+	}
 	userReq.Email = utils.SanitizeEmail(userReq.Email)
 
-	leaseInvite := database.GetLeaseInviteById(c.Param("id"))
+	leaseInvite := database.GetLeaseInviteByToken(c.Param("token"))
 	if leaseInvite == nil {
 		utils.SendError(c, http.StatusNotFound, utils.InviteNotFound, nil)
 		return
 	}
+	if leaseInvite.IsExpired(time.Now()) {
+		utils.SendError(c, http.StatusGone, utils.InviteExpired, nil)
+		return
+	}
 	if leaseInvite.TenantEmail != userReq.Email {
 		utils.SendError(c, http.StatusBadRequest, utils.UserSameEmailAsInvite, nil)
 		return
@@ -117,10 +127,36 @@ func RegisterTenant(c *gin.Context) {
 		return
 	}
 
-	_ = database.CreateLease(*leaseInvite, *user)
+	_ = database.CreateLease(*leaseInvite, *user, utils.Now())
 	c.JSON(http.StatusCreated, models.IdResponse{ID: user.ID})
 }
 
+// GetInvite godoc
+//
+//	@Summary		Get an invite
+//	@Description	Get the property preview and the lease terms proposed by an invite link
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			token	path		string						true	"Invite token"
+//	@Success		200		{object}	models.TenantInviteResponse	"Invite"
+//	@Failure		404		{object}	utils.Error					"Pending lease not found"
+//	@Failure		410		{object}	utils.Error					"Invite expired"
+//	@Failure		500
+//	@Router			/auth/invite/{token}/ [get]
+func GetInvite(c *gin.Context) {
+	leaseInvite := database.GetLeaseInviteByToken(c.Param("token"))
+	if leaseInvite == nil {
+		utils.SendError(c, http.StatusNotFound, utils.InviteNotFound, nil)
+		return
+	}
+	if leaseInvite.IsExpired(time.Now()) {
+		utils.SendError(c, http.StatusGone, utils.InviteExpired, nil)
+		return
+	}
+	c.JSON(http.StatusOK, models.DbLeaseInviteToTenantResponse(*leaseInvite))
+}
+
 // AcceptInvite godoc
 //
 //	@Summary		Accept an invite
@@ -128,14 +164,34 @@ func RegisterTenant(c *gin.Context) {
 //	@Tags			auth
 //	@Accept			json
 //	@Produce		json
-//	@Param			id	path	string	true	"Pending lease ID"
-//	@Success		204	"Accepted"
-//	@Failure		403	{object}	utils.Error	"Not a tenant"
-//	@Failure		404	{object}	utils.Error	"Pending lease not found"
-//	@Failure		409	{object}	utils.Error	"Property not available or tenant already has lease"
+//	@Param			token	path	string						true	"Invite token"
+//	@Param			terms	body	models.AcceptInviteRequest	true	"Acceptance of the lease terms"
+//	@Success		204		"Accepted"
+//	@Failure		400		{object}	utils.Error	"Lease terms not accepted"
+//	@Failure		403		{object}	utils.Error	"Not a tenant"
+//	@Failure		404		{object}	utils.Error	"Pending lease not found"
+//	@Failure		409		{object}	utils.Error	"Property not available or tenant already has lease"
+//	@Failure		410		{object}	utils.Error	"Invite expired"
 //	@Failure		500
-//	@Router			/tenant/invite/{id}/ [post]
+//	@Router			/tenant/invite/{token}/ [post]
 func AcceptInvite(c *gin.Context) {
+	acceptInvite(c, database.GetLeaseInviteByToken, c.Param("token"))
+}
+
+// acceptInvite creates the lease of an invite for the current tenant once they accepted the lease terms.
+// The invite is found by calling getInvite with key, its token or its ID.
+func acceptInvite(c *gin.Context, getInvite func(string) *db.LeaseInviteModel, key string) {
+	var req models.AcceptInviteRequest
+	err := c.ShouldBindBodyWithJSON(&req)
+	if err != nil {
+		utils.SendError(c, http.StatusBadRequest, utils.MissingFields, err)
+		return
+	}
+	if !req.AcceptTerms {
+		utils.SendError(c, http.StatusBadRequest, utils.LeaseTermsNotAccepted, nil)
+		return
+	}
+
 	claims := utils.GetClaims(c)
 	user := database.GetUserByID(claims["id"])
 	if user == nil || user.Role != db.RoleTenant {
@@ -143,11 +199,15 @@ func AcceptInvite(c *gin.Context) {
 		return
 	}
 
-	leaseInvite := database.GetLeaseInviteById(c.Param("id"))
+	leaseInvite := getInvite(key)
 	if leaseInvite == nil {
 		utils.SendError(c, http.StatusNotFound, utils.InviteNotFound, nil)
 		return
 	}
+	if leaseInvite.IsExpired(time.Now()) {
+		utils.SendError(c, http.StatusGone, utils.InviteExpired, nil)
+		return
+	}
 	if leaseInvite.TenantEmail != user.Email {
 		utils.SendError(c, http.StatusForbidden, utils.UserSameEmailAsInvite, nil)
 		return
@@ -162,6 +222,6 @@ func AcceptInvite(c *gin.Context) {
 		return
 	}
 
-	_ = database.CreateLease(*leaseInvite, *user)
+	_ = database.CreateLease(*leaseInvite, *user, utils.Now())
 	c.Status(http.StatusNoContent)
 }
@@ -13,6 +13,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"keyz/backend/controllers"
+	"keyz/backend/models"
 	"keyz/backend/prisma/db"
 	"keyz/backend/router"
 	"keyz/backend/services"
@@ -22,6 +23,25 @@ import (
 
 const TENANT_EMAIL = "test1@example.com"
 
+func BuildTestTenantRegisterRequest(user db.UserModel, acceptTerms bool) models.TenantRegisterRequest {
+	return models.TenantRegisterRequest{
+		UserRequest: models.UserRequest{
+			Email:     user.Email,
+			Firstname: user.Firstname,
+			Lastname:  user.Lastname,
+			Password:  user.Password,
+		},
+		AcceptTerms: acceptTerms,
+	}
+}
+
+func buildAcceptTermsBody(t *testing.T, acceptTerms bool) []byte {
+	t.Helper()
+	b, err := json.Marshal(models.AcceptInviteRequest{AcceptTerms: acceptTerms})
+	require.NoError(t, err)
+	return b
+}
+
 func TestTokenAuth(t *testing.T) {
 	bServer := oauth.NewOAuthBearerServer(
 		"1234567890",
@@ -111,14 +131,32 @@ func TestRegisterTenantWrongPassword(t *testing.T) {
 	assert.Equal(t, utils.MissingFields, errorResponse.Code)
 }
 
+func TestRegisterTenantTermsNotAccepted(t *testing.T) {
+	user := BuildTestUser("1")
+	b, err := json.Marshal(BuildTestTenantRegisterRequest(user, false))
+	require.NoError(t, err)
+
+	r := router.TestRoutes()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/v1/auth/invite/1/", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	var errorResponse utils.Error
+	err = json.Unmarshal(w.Body.Bytes(), &errorResponse)
+	require.NoError(t, err)
+	assert.Equal(t, utils.LeaseTermsNotAccepted, errorResponse.Code)
+}
+
 func TestRegisterTenantInviteNotFound(t *testing.T) {
 	c, m, ensure := services.ConnectDBTest()
 	defer ensure(t)
 
-	m.LeaseInvite.Expect(database.MockGetLeaseInviteByID(c)).Errors(db.ErrNotFound)
+	m.LeaseInvite.Expect(database.MockGetLeaseInviteByToken(c)).Errors(db.ErrNotFound)
 
 	user := BuildTestUser("1")
-	b, err := json.Marshal(user)
+	b, err := json.Marshal(BuildTestTenantRegisterRequest(user, true))
 	require.NoError(t, err)
 
 	r := router.TestRoutes()
@@ -134,17 +172,43 @@ func TestRegisterTenantInviteNotFound(t *testing.T) {
 	assert.Equal(t, utils.InviteNotFound, errorResponse.Code)
 }
 
+func TestRegisterTenantInviteExpired(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	leaseInvite := BuildTestLeaseInvite()
+	leaseInvite.ExpiresAt = time.Now().Add(-time.Hour)
+	m.LeaseInvite.Expect(database.MockGetLeaseInviteByToken(c)).Returns(leaseInvite)
+
+	user := BuildTestUser("1")
+	user.Email = leaseInvite.TenantEmail
+	b, err := json.Marshal(BuildTestTenantRegisterRequest(user, true))
+	require.NoError(t, err)
+
+	r := router.TestRoutes()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/v1/auth/invite/1/", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusGone, w.Code)
+	var errorResponse utils.Error
+	err = json.Unmarshal(w.Body.Bytes(), &errorResponse)
+	require.NoError(t, err)
+	assert.Equal(t, utils.InviteExpired, errorResponse.Code)
+}
+
 func TestRegisterTenantWrongEmail(t *testing.T) {
 	c, m, ensure := services.ConnectDBTest()
 	defer ensure(t)
 
 	leaseInvite := BuildTestLeaseInvite()
 	leaseInvite.TenantEmail = TENANT_EMAIL
-	m.LeaseInvite.Expect(database.MockGetLeaseInviteByID(c)).Returns(leaseInvite)
+	m.LeaseInvite.Expect(database.MockGetLeaseInviteByToken(c)).Returns(leaseInvite)
 
 	user := BuildTestUser("1")
 	user.Email = "test2@example.com"
-	b, err := json.Marshal(user)
+	b, err := json.Marshal(BuildTestTenantRegisterRequest(user, true))
 	require.NoError(t, err)
 
 	r := router.TestRoutes()
@@ -166,12 +230,12 @@ func TestRegisterTenantPropertyNotAvailable(t *testing.T) {
 
 	leaseInvite := BuildTestLeaseInvite()
 	leaseInvite.TenantEmail = TENANT_EMAIL
-	m.LeaseInvite.Expect(database.MockGetLeaseInviteByID(c)).Returns(leaseInvite)
+	m.LeaseInvite.Expect(database.MockGetLeaseInviteByToken(c)).Returns(leaseInvite)
 	m.Lease.Expect(database.MockGetCurrentActiveLeaseByProperty(c)).ReturnsMany([]db.LeaseModel{BuildTestLease("1")})
 
 	user := BuildTestUser("1")
 	user.Email = leaseInvite.TenantEmail
-	b, err := json.Marshal(user)
+	b, err := json.Marshal(BuildTestTenantRegisterRequest(user, true))
 	require.NoError(t, err)
 
 	r := router.TestRoutes()
@@ -187,24 +251,66 @@ func TestRegisterTenantPropertyNotAvailable(t *testing.T) {
 	assert.Equal(t, utils.PropertyNotAvailable, errorResponse.Code)
 }
 
+func TestGetInvite(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	leaseInvite := BuildTestLeaseInvite()
+	m.LeaseInvite.Expect(database.MockGetLeaseInviteByToken(c)).Returns(leaseInvite)
+
+	r := router.TestRoutes()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/v1/auth/invite/1/", nil)
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp models.TenantInviteResponse
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	require.NoError(t, err)
+	assert.Equal(t, leaseInvite.ID, resp.ID)
+	assert.InDelta(t, leaseInvite.RentalPricePerMonth, resp.RentalPricePerMonth, 0)
+	assert.Equal(t, leaseInvite.PaymentDay, resp.PaymentDay)
+}
+
+func TestGetInviteExpired(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	leaseInvite := BuildTestLeaseInvite()
+	leaseInvite.ExpiresAt = time.Now().Add(-time.Hour)
+	m.LeaseInvite.Expect(database.MockGetLeaseInviteByToken(c)).Returns(leaseInvite)
+
+	r := router.TestRoutes()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/v1/auth/invite/1/", nil)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusGone, w.Code)
+	var errorResponse utils.Error
+	err := json.Unmarshal(w.Body.Bytes(), &errorResponse)
+	require.NoError(t, err)
+	assert.Equal(t, utils.InviteExpired, errorResponse.Code)
+}
+
 func TestAcceptInvite(t *testing.T) {
 	c, m, ensure := services.ConnectDBTest()
 	defer ensure(t)
+	now := mockNow(t)
 
 	user := BuildTestUser("1")
 	user.Role = db.RoleTenant
 	leaseInvite := BuildTestLeaseInvite()
 	leaseInvite.TenantEmail = user.Email
 	m.User.Expect(database.MockGetUserByID(c)).Returns(user)
-	m.LeaseInvite.Expect(database.MockGetLeaseInviteByID(c)).Returns(leaseInvite)
+	m.LeaseInvite.Expect(database.MockGetLeaseInviteByToken(c)).Returns(leaseInvite)
 	m.Lease.Expect(database.MockGetCurrentActiveLeaseByProperty(c)).ReturnsMany([]db.LeaseModel{})
 	m.Lease.Expect(database.MockGetCurrentActiveLeaseByTenant(c)).ReturnsMany([]db.LeaseModel{})
-	m.Lease.Expect(database.MockCreateLease(c, leaseInvite)).Returns(BuildTestLease("1"))
+	m.Lease.Expect(database.MockCreateLease(c, leaseInvite, now)).Returns(BuildTestLease("1"))
 	m.LeaseInvite.Expect(database.MockDeleteLeaseInviteById(c)).Returns(db.LeaseInviteModel{})
 
 	r := router.TestRoutes()
 	w := httptest.NewRecorder()
-	req, _ := http.NewRequest(http.MethodPost, "/v1/tenant/invite/1/", nil)
+	req, _ := http.NewRequest(http.MethodPost, "/v1/tenant/invite/1/", bytes.NewReader(buildAcceptTermsBody(t, true)))
 	req.Header.Set("Oauth.claims.id", user.ID)
 	req.Header.Set("Oauth.claims.role", string(user.Role))
 	r.ServeHTTP(w, req)
@@ -212,6 +318,21 @@ func TestAcceptInvite(t *testing.T) {
 	assert.Equal(t, http.StatusNoContent, w.Code)
 }
 
+func TestAcceptInviteTermsNotAccepted(t *testing.T) {
+	r := router.TestRoutes()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/v1/tenant/invite/1/", bytes.NewReader(buildAcceptTermsBody(t, false)))
+	req.Header.Set("Oauth.claims.id", "1")
+	req.Header.Set("Oauth.claims.role", string(db.RoleTenant))
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	var errorResponse utils.Error
+	err := json.Unmarshal(w.Body.Bytes(), &errorResponse)
+	require.NoError(t, err)
+	assert.Equal(t, utils.LeaseTermsNotAccepted, errorResponse.Code)
+}
+
 func TestAcceptInviteNotATenant(t *testing.T) {
 	c, m, ensure := services.ConnectDBTest()
 	defer ensure(t)
@@ -221,7 +342,7 @@ func TestAcceptInviteNotATenant(t *testing.T) {
 
 	r := router.TestRoutes()
 	w := httptest.NewRecorder()
-	req, _ := http.NewRequest(http.MethodPost, "/v1/tenant/invite/1/", nil)
+	req, _ := http.NewRequest(http.MethodPost, "/v1/tenant/invite/1/", bytes.NewReader(buildAcceptTermsBody(t, true)))
 	req.Header.Set("Oauth.claims.id", user.ID)
 	req.Header.Set("Oauth.claims.role", string(db.RoleTenant))
 	r.ServeHTTP(w, req)
@@ -240,11 +361,11 @@ func TestAcceptInviteInviteNotFound(t *testing.T) {
 	user := BuildTestUser("1")
 	user.Role = db.RoleTenant
 	m.User.Expect(database.MockGetUserByID(c)).Returns(user)
-	m.LeaseInvite.Expect(database.MockGetLeaseInviteByID(c)).Errors(db.ErrNotFound)
+	m.LeaseInvite.Expect(database.MockGetLeaseInviteByToken(c)).Errors(db.ErrNotFound)
 
 	r := router.TestRoutes()
 	w := httptest.NewRecorder()
-	req, _ := http.NewRequest(http.MethodPost, "/v1/tenant/invite/1/", nil)
+	req, _ := http.NewRequest(http.MethodPost, "/v1/tenant/invite/1/", bytes.NewReader(buildAcceptTermsBody(t, true)))
 	req.Header.Set("Oauth.claims.id", user.ID)
 	req.Header.Set("Oauth.claims.role", string(user.Role))
 	r.ServeHTTP(w, req)
@@ -256,6 +377,31 @@ func TestAcceptInviteInviteNotFound(t *testing.T) {
 	assert.Equal(t, utils.InviteNotFound, errorResponse.Code)
 }
 
+func TestAcceptInviteInviteExpired(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	user := BuildTestUser("1")
+	user.Role = db.RoleTenant
+	leaseInvite := BuildTestLeaseInvite()
+	leaseInvite.ExpiresAt = time.Now().Add(-time.Hour)
+	m.User.Expect(database.MockGetUserByID(c)).Returns(user)
+	m.LeaseInvite.Expect(database.MockGetLeaseInviteByToken(c)).Returns(leaseInvite)
+
+	r := router.TestRoutes()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/v1/tenant/invite/1/", bytes.NewReader(buildAcceptTermsBody(t, true)))
+	req.Header.Set("Oauth.claims.id", user.ID)
+	req.Header.Set("Oauth.claims.role", string(user.Role))
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusGone, w.Code)
+	var errorResponse utils.Error
+	err := json.Unmarshal(w.Body.Bytes(), &errorResponse)
+	require.NoError(t, err)
+	assert.Equal(t, utils.InviteExpired, errorResponse.Code)
+}
+
 func TestAcceptInviteWrongEmail(t *testing.T) {
 	c, m, ensure := services.ConnectDBTest()
 	defer ensure(t)
@@ -266,11 +412,11 @@ func TestAcceptInviteWrongEmail(t *testing.T) {
 	leaseInvite := BuildTestLeaseInvite()
 	leaseInvite.TenantEmail = "test2@example.com"
 	m.User.Expect(database.MockGetUserByID(c)).Returns(user)
-	m.LeaseInvite.Expect(database.MockGetLeaseInviteByID(c)).Returns(leaseInvite)
+	m.LeaseInvite.Expect(database.MockGetLeaseInviteByToken(c)).Returns(leaseInvite)
 
 	r := router.TestRoutes()
 	w := httptest.NewRecorder()
-	req, _ := http.NewRequest(http.MethodPost, "/v1/tenant/invite/1/", nil)
+	req, _ := http.NewRequest(http.MethodPost, "/v1/tenant/invite/1/", bytes.NewReader(buildAcceptTermsBody(t, true)))
 	req.Header.Set("Oauth.claims.id", user.ID)
 	req.Header.Set("Oauth.claims.role", string(user.Role))
 	r.ServeHTTP(w, req)
@@ -292,12 +438,12 @@ func TestAcceptInvitePropertyNotAvailable(t *testing.T) {
 	leaseInvite.TenantEmail = user.Email
 	activeLease := BuildTestLease("1")
 	m.User.Expect(database.MockGetUserByID(c)).Returns(user)
-	m.LeaseInvite.Expect(database.MockGetLeaseInviteByID(c)).Returns(leaseInvite)
+	m.LeaseInvite.Expect(database.MockGetLeaseInviteByToken(c)).Returns(leaseInvite)
 	m.Lease.Expect(database.MockGetCurrentActiveLeaseByProperty(c)).ReturnsMany([]db.LeaseModel{activeLease})
 
 	r := router.TestRoutes()
 	w := httptest.NewRecorder()
-	req, _ := http.NewRequest(http.MethodPost, "/v1/tenant/invite/1/", nil)
+	req, _ := http.NewRequest(http.MethodPost, "/v1/tenant/invite/1/", bytes.NewReader(buildAcceptTermsBody(t, true)))
 	req.Header.Set("Oauth.claims.id", user.ID)
 	req.Header.Set("Oauth.claims.role", string(user.Role))
 	r.ServeHTTP(w, req)
@@ -319,13 +465,13 @@ func TestAcceptInviteTenantAlreadyHasLease(t *testing.T) {
 	leaseInvite.TenantEmail = user.Email
 	activeLease := BuildTestLease("1")
 	m.User.Expect(database.MockGetUserByID(c)).Returns(user)
-	m.LeaseInvite.Expect(database.MockGetLeaseInviteByID(c)).Returns(leaseInvite)
+	m.LeaseInvite.Expect(database.MockGetLeaseInviteByToken(c)).Returns(leaseInvite)
 	m.Lease.Expect(database.MockGetCurrentActiveLeaseByProperty(c)).Errors(db.ErrNotFound)
 	m.Lease.Expect(database.MockGetCurrentActiveLeaseByTenant(c)).ReturnsMany([]db.LeaseModel{activeLease})
 
 	r := router.TestRoutes()
 	w := httptest.NewRecorder()
-	req, _ := http.NewRequest(http.MethodPost, "/v1/tenant/invite/1/", nil)
+	req, _ := http.NewRequest(http.MethodPost, "/v1/tenant/invite/1/", bytes.NewReader(buildAcceptTermsBody(t, true)))
 	req.Header.Set("Oauth.claims.id", user.ID)
 	req.Header.Set("Oauth.claims.role", string(user.Role))
 	r.ServeHTTP(w, req)
@@ -0,0 +1,249 @@
+package controllers
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"keyz/backend/models"
+	"keyz/backend/prisma/db"
+	"keyz/backend/services/brevo"
+	"keyz/backend/services/database"
+	"keyz/backend/utils"
+)
+
+// CreateContractor godoc
+//
+//	@Summary		Add contractor
+//	@Description	Add a contractor to the owner's directory
+//	@Tags			contractor
+//	@Accept			json
+//	@Produce		json
+//	@Param			contractor	body		models.ContractorRequest	true	"Contractor to add"
+//	@Success		201			{object}	models.IdResponse			"Created contractor ID"
+//	@Failure		400			{object}	utils.Error					"Missing fields"
+//	@Failure		403			{object}	utils.Error					"Not an owner"
+//	@Failure		500
+//	@Security		Bearer
+//	@Router			/owner/contractors/ [post]
+func CreateContractor(c *gin.Context) {
+	var req models.ContractorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.SendError(c, http.StatusBadRequest, utils.MissingFields, err)
+		return
+	}
+
+	claims := utils.GetClaims(c)
+	contractor := database.CreateContractor(req.ToDbContractor(), claims["id"])
+	c.JSON(http.StatusCreated, models.IdResponse{ID: contractor.ID})
+}
+
+// GetContractors godoc
+//
+//	@Summary		Get contractors
+//	@Description	Get all contractors of the owner's directory
+//	@Tags			contractor
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{array}		models.ContractorResponse	"List of contractors"
+//	@Failure		403	{object}	utils.Error					"Not an owner"
+//	@Failure		500
+//	@Security		Bearer
+//	@Router			/owner/contractors/ [get]
+func GetContractors(c *gin.Context) {
+	claims := utils.GetClaims(c)
+	contractors := database.GetContractorsByOwner(claims["id"])
+	c.JSON(http.StatusOK, utils.Map(contractors, models.DbContractorToResponse))
+}
+
+// GetContractor godoc
+//
+//	@Summary		Get contractor
+//	@Description	Get a contractor of the owner's directory by its ID
+//	@Tags			contractor
+//	@Accept			json
+//	@Produce		json
+//	@Param			contractor_id	path		string						true	"Contractor ID"
+//	@Success		200				{object}	models.ContractorResponse	"Contractor"
+//	@Failure		403				{object}	utils.Error					"Not an owner"
+//	@Failure		404				{object}	utils.Error					"Contractor not found"
+//	@Failure		500
+//	@Security		Bearer
+//	@Router			/owner/contractors/{contractor_id}/ [get]
+func GetContractor(c *gin.Context) {
+	contractor, _ := c.MustGet("contractor").(db.ContractorModel)
+	c.JSON(http.StatusOK, models.DbContractorToResponse(contractor))
+}
+
+// UpdateContractor godoc
+//
+//	@Summary		Update contractor
+//	@Description	Update a contractor of the owner's directory
+//	@Tags			contractor
+//	@Accept			json
+//	@Produce		json
+//	@Param			contractor_id	path		string							true	"Contractor ID"
+//	@Param			contractor		body		models.ContractorUpdateRequest	true	"Contractor data"
+//	@Success		200				{object}	models.IdResponse				"Updated contractor ID"
+//	@Failure		400				{object}	utils.Error						"Missing fields"
+//	@Failure		403				{object}	utils.Error						"Not an owner"
+//	@Failure		404				{object}	utils.Error						"Contractor not found"
+//	@Failure		500
+//	@Security		Bearer
+//	@Router			/owner/contractors/{contractor_id}/ [put]
+func UpdateContractor(c *gin.Context) {
+	var req models.ContractorUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.SendError(c, http.StatusBadRequest, utils.MissingFields, err)
+		return
+	}
+
+	contractor, _ := c.MustGet("contractor").(db.ContractorModel)
+	newContractor := database.UpdateContractor(contractor, req)
+	c.JSON(http.StatusOK, models.IdResponse{ID: newContractor.ID})
+}
+
+// DeleteContractor godoc
+//
+//	@Summary		Delete contractor
+//	@Description	Remove a contractor from the owner's directory, the damages assigned to them are unassigned
+//	@Tags			contractor
+//	@Accept			json
+//	@Produce		json
+//	@Param			contractor_id	path	string	true	"Contractor ID"
+//	@Success		204				"Contractor deleted"
+//	@Failure		403				{object}	utils.Error	"Not an owner"
+//	@Failure		404				{object}	utils.Error	"Contractor not found"
+//	@Failure		500
+//	@Security		Bearer
+//	@Router			/owner/contractors/{contractor_id}/ [delete]
+func DeleteContractor(c *gin.Context) {
+	contractor, _ := c.MustGet("contractor").(db.ContractorModel)
+	database.DeleteContractor(contractor.ID)
+	c.Status(http.StatusNoContent)
+}
+
+// AssignDamageContractor godoc
+//
+//	@Summary		Assign damage to a contractor
+//	@Description	Assign a damage to a contractor with a planned intervention date. The contractor receives the damage details by email,
+//	@Description	with a link to mark the job as done if `send_link` is set.
+//	@Tags			damage
+//	@Accept			json
+//	@Produce		json
+//	@Param			property_id	path		string						true	"Property ID"
+//	@Param			lease_id	path		string						true	"Lease ID"
+//	@Param			damage_id	path		string						true	"Damage ID"
+//	@Param			assignment	body		models.DamageAssignRequest	true	"Contractor and intervention date"
+//	@Success		200			{object}	models.IdResponse			"Assigned damage ID"
+//	@Failure		400			{object}	utils.Error					"Missing fields, damage fixed or closed"
+//	@Failure		403			{object}	utils.Error					"Property not yours"
+//	@Failure		404			{object}	utils.Error					"Damage or contractor not found"
+//	@Failure		500
+//	@Security		Bearer
+//	@Router			/owner/properties/{property_id}/leases/{lease_id}/damages/{damage_id}/assign/ [put]
+func AssignDamageContractor(c *gin.Context) {
+	var req models.DamageAssignRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.SendError(c, http.StatusBadRequest, utils.MissingFields, err)
+		return
+	}
+
+	damage, _ := c.MustGet("damage").(db.DamageModel)
+	if damage.IsClosed() {
+		utils.SendError(c, http.StatusBadRequest, utils.DamageClosed, nil)
+		return
+	}
+	if damage.IsFixed() {
+		utils.SendError(c, http.StatusBadRequest, utils.CannotUpdateFixedDamage, nil)
+		return
+	}
+
+	claims := utils.GetClaims(c)
+	contractor := database.GetContractorByID(req.ContractorID)
+	if contractor == nil || contractor.OwnerID != claims["id"] {
+		utils.SendError(c, http.StatusNotFound, utils.ContractorNotFound, nil)
+		return
+	}
+
+	var token, tokenHash *string
+	if req.SendLink {
+		token = utils.Ptr(utils.GenerateToken())
+		tokenHash = utils.Ptr(utils.HashToken(*token))
+	}
+
+	newDamage := database.AssignDamageContractor(damage, req, tokenHash)
+	recordDamageEvents(c, damage.Changes(newDamage))
+
+	lease, _ := c.MustGet("lease").(db.LeaseModel)
+	newDamage.RelationsDamage = damage.RelationsDamage
+	// The assignment is saved, so a failed email does not fail the request
+	res, err := brevo.SendDamageAssignment(lease, newDamage, *contractor, token)
+	if err != nil {
+		log.Println(res, err.Error())
+	}
+
+	c.JSON(http.StatusOK, models.IdResponse{ID: newDamage.ID})
+}
+
+// GetContractorJob godoc
+//
+//	@Summary		Get contractor job
+//	@Description	Get the damage assigned to a contractor, using the link they received by email
+//	@Tags			contractor
+//	@Accept			json
+//	@Produce		json
+//	@Param			token	path		string							true	"Job token"
+//	@Success		200		{object}	models.ContractorJobResponse	"Assigned damage"
+//	@Failure		404		{object}	utils.Error						"Job not found"
+//	@Failure		500
+//	@Router			/contractor/jobs/{token}/ [get]
+func GetContractorJob(c *gin.Context) {
+	damage := database.GetDamageByContractorToken(c.Param("token"))
+	if damage == nil {
+		utils.SendError(c, http.StatusNotFound, utils.ContractorJobNotFound, nil)
+		return
+	}
+
+	var resp models.ContractorJobResponse
+	resp.FromDbDamage(*damage)
+	c.JSON(http.StatusOK, resp)
+}
+
+// MarkContractorJobDone godoc
+//
+//	@Summary		Mark contractor job as done
+//	@Description	Mark the damage assigned to a contractor as done, using the link they received by email.
+//	@Description	The owner is notified by email and still has to confirm the fix.
+//	@Tags			contractor
+//	@Accept			json
+//	@Produce		json
+//	@Param			token	path		string				true	"Job token"
+//	@Success		200		{object}	models.IdResponse	"Damage ID"
+//	@Failure		400		{object}	utils.Error			"Damage fixed or closed"
+//	@Failure		404		{object}	utils.Error			"Job not found"
+//	@Failure		500
+//	@Router			/contractor/jobs/{token}/done/ [post]
+func MarkContractorJobDone(c *gin.Context) {
+	damage := database.GetDamageByContractorToken(c.Param("token"))
+	if damage == nil {
+		utils.SendError(c, http.StatusNotFound, utils.ContractorJobNotFound, nil)
+		return
+	}
+	if damage.IsClosed() {
+		utils.SendError(c, http.StatusBadRequest, utils.DamageClosed, nil)
+		return
+	}
+	if damage.IsFixed() {
+		utils.SendError(c, http.StatusBadRequest, utils.DamageAlreadyFixed, nil)
+		return
+	}
+
+	newDamage := database.MarkDamageContractorDone(*damage)
+	res, err := brevo.SendContractorJobDone(*damage)
+	if err != nil {
+		log.Println(res, err.Error())
+	}
+
+	c.JSON(http.StatusOK, models.IdResponse{ID: newDamage.ID})
+}
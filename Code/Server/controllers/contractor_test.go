@@ -0,0 +1,355 @@
+package controllers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"keyz/backend/models"
+	"keyz/backend/prisma/db"
+	"keyz/backend/router"
+	"keyz/backend/services"
+	"keyz/backend/services/database"
+	"keyz/backend/utils"
+)
+
+func BuildTestContractor(id string, ownerId string) db.ContractorModel {
+	return db.ContractorModel{
+		InnerContractor: db.InnerContractor{
+			ID:        id,
+			OwnerID:   ownerId,
+			Name:      "Mario",
+			Trade:     db.TradePlumber,
+			Email:     "mario@example.com",
+			CreatedAt: time.Now(),
+		},
+	}
+}
+
+func TestCreateContractor(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	contractor := BuildTestContractor("1", "1")
+	m.Contractor.Expect(database.MockCreateContractor(c, contractor)).Returns(contractor)
+
+	reqBody := models.ContractorRequest{
+		Name:  contractor.Name,
+		Trade: contractor.Trade,
+		Email: contractor.Email,
+	}
+	b, err := json.Marshal(reqBody)
+	require.NoError(t, err)
+
+	r := router.TestRoutes()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/v1/owner/contractors/", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Oauth.claims.id", "1")
+	req.Header.Set("Oauth.claims.role", string(db.RoleOwner))
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusCreated, w.Code)
+	var resp models.IdResponse
+	err = json.Unmarshal(w.Body.Bytes(), &resp)
+	require.NoError(t, err)
+	assert.Equal(t, contractor.ID, resp.ID)
+}
+
+func TestCreateContractor_BadTrade(t *testing.T) {
+	reqBody := models.ContractorRequest{
+		Name:  "Mario",
+		Trade: "astronaut",
+		Email: "mario@example.com",
+	}
+	b, err := json.Marshal(reqBody)
+	require.NoError(t, err)
+
+	r := router.TestRoutes()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/v1/owner/contractors/", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Oauth.claims.id", "1")
+	req.Header.Set("Oauth.claims.role", string(db.RoleOwner))
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	var resp utils.Error
+	err = json.Unmarshal(w.Body.Bytes(), &resp)
+	require.NoError(t, err)
+	assert.Equal(t, utils.MissingFields, resp.Code)
+}
+
+func TestCreateContractor_NotAnOwner(t *testing.T) {
+	r := router.TestRoutes()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/v1/owner/contractors/", nil)
+	req.Header.Set("Oauth.claims.id", "1")
+	req.Header.Set("Oauth.claims.role", string(db.RoleTenant))
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestGetContractors(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	contractors := []db.ContractorModel{BuildTestContractor("1", "1"), BuildTestContractor("2", "1")}
+	m.Contractor.Expect(database.MockGetContractorsByOwner(c)).ReturnsMany(contractors)
+
+	r := router.TestRoutes()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/v1/owner/contractors/", nil)
+	req.Header.Set("Oauth.claims.id", "1")
+	req.Header.Set("Oauth.claims.role", string(db.RoleOwner))
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp []models.ContractorResponse
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	require.NoError(t, err)
+	require.Len(t, resp, 2)
+	assert.Equal(t, db.TradePlumber, resp[0].Trade)
+}
+
+func TestGetContractor_NotYours(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	m.Contractor.Expect(database.MockGetContractorByID(c)).Returns(BuildTestContractor("1", "2"))
+
+	r := router.TestRoutes()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/v1/owner/contractors/1/", nil)
+	req.Header.Set("Oauth.claims.id", "1")
+	req.Header.Set("Oauth.claims.role", string(db.RoleOwner))
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusNotFound, w.Code)
+	var resp utils.Error
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	require.NoError(t, err)
+	assert.Equal(t, utils.ContractorNotFound, resp.Code)
+}
+
+func TestUpdateContractor(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	contractor := BuildTestContractor("1", "1")
+	reqBody := models.ContractorUpdateRequest{
+		Notes: utils.Ptr("Available on weekends"),
+	}
+	updated := contractor
+	updated.Notes = reqBody.Notes
+	m.Contractor.Expect(database.MockGetContractorByID(c)).Returns(contractor)
+	m.Contractor.Expect(database.MockUpdateContractor(c, reqBody)).Returns(updated)
+
+	b, err := json.Marshal(reqBody)
+	require.NoError(t, err)
+
+	r := router.TestRoutes()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPut, "/v1/owner/contractors/1/", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Oauth.claims.id", "1")
+	req.Header.Set("Oauth.claims.role", string(db.RoleOwner))
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestDeleteContractor(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	contractor := BuildTestContractor("1", "1")
+	m.Contractor.Expect(database.MockGetContractorByID(c)).Returns(contractor)
+	m.Contractor.Expect(database.MockDeleteContractor(c)).Returns(contractor)
+
+	r := router.TestRoutes()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodDelete, "/v1/owner/contractors/1/", nil)
+	req.Header.Set("Oauth.claims.id", "1")
+	req.Header.Set("Oauth.claims.role", string(db.RoleOwner))
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusNoContent, w.Code)
+}
+
+func TestAssignDamageContractor(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+	mockInviteToken(t)
+
+	property := BuildTestProperty("1")
+	lease := BuildTestLease("1")
+	damage := BuildTestDamage("1")
+	contractor := BuildTestContractor("1", "1")
+	reqBody := models.DamageAssignRequest{
+		ContractorID:     contractor.ID,
+		InterventionDate: time.Now().Add(24 * time.Hour).Truncate(time.Minute),
+		SendLink:         true,
+	}
+	tokenHash := utils.Ptr(utils.HashToken("1"))
+	assigned := damage
+	assigned.ContractorID = &contractor.ID
+	m.Property.Expect(database.MockGetPropertyByID(c)).Returns(property)
+	m.Lease.Expect(database.MockGetLeaseByID(c)).Returns(lease)
+	m.Damage.Expect(database.MockGetDamageByID(c)).Returns(damage)
+	m.Contractor.Expect(database.MockGetContractorByID(c)).Returns(contractor)
+	m.Damage.Expect(database.MockAssignDamageContractor(c, reqBody, tokenHash)).Returns(assigned)
+	event := db.NewDamageEvent(damage.ID, db.DamageFieldContractorID, nil, &contractor.ID)
+	event.ActorRole = db.RoleOwner
+	m.DamageEvent.Expect(database.MockCreateDamageEvent(c, event)).Returns(event)
+
+	b, err := json.Marshal(reqBody)
+	require.NoError(t, err)
+
+	r := router.TestRoutes()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPut, "/v1/owner/properties/1/leases/1/damages/1/assign/", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Oauth.claims.id", "1")
+	req.Header.Set("Oauth.claims.role", string(db.RoleOwner))
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestAssignDamageContractor_ContractorNotYours(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	property := BuildTestProperty("1")
+	lease := BuildTestLease("1")
+	damage := BuildTestDamage("1")
+	m.Property.Expect(database.MockGetPropertyByID(c)).Returns(property)
+	m.Lease.Expect(database.MockGetLeaseByID(c)).Returns(lease)
+	m.Damage.Expect(database.MockGetDamageByID(c)).Returns(damage)
+	m.Contractor.Expect(database.MockGetContractorByID(c)).Returns(BuildTestContractor("1", "2"))
+
+	reqBody := models.DamageAssignRequest{
+		ContractorID:     "1",
+		InterventionDate: time.Now().Add(24 * time.Hour),
+	}
+	b, err := json.Marshal(reqBody)
+	require.NoError(t, err)
+
+	r := router.TestRoutes()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPut, "/v1/owner/properties/1/leases/1/damages/1/assign/", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Oauth.claims.id", "1")
+	req.Header.Set("Oauth.claims.role", string(db.RoleOwner))
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusNotFound, w.Code)
+	var resp utils.Error
+	err = json.Unmarshal(w.Body.Bytes(), &resp)
+	require.NoError(t, err)
+	assert.Equal(t, utils.ContractorNotFound, resp.Code)
+}
+
+func TestAssignDamageContractor_AlreadyFixed(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	property := BuildTestProperty("1")
+	lease := BuildTestLease("1")
+	damage := BuildTestDamage("1")
+	damage.FixedOwner = true
+	damage.FixedTenant = true
+	m.Property.Expect(database.MockGetPropertyByID(c)).Returns(property)
+	m.Lease.Expect(database.MockGetLeaseByID(c)).Returns(lease)
+	m.Damage.Expect(database.MockGetDamageByID(c)).Returns(damage)
+
+	reqBody := models.DamageAssignRequest{
+		ContractorID:     "1",
+		InterventionDate: time.Now().Add(24 * time.Hour),
+	}
+	b, err := json.Marshal(reqBody)
+	require.NoError(t, err)
+
+	r := router.TestRoutes()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPut, "/v1/owner/properties/1/leases/1/damages/1/assign/", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Oauth.claims.id", "1")
+	req.Header.Set("Oauth.claims.role", string(db.RoleOwner))
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	var resp utils.Error
+	err = json.Unmarshal(w.Body.Bytes(), &resp)
+	require.NoError(t, err)
+	assert.Equal(t, utils.CannotUpdateFixedDamage, resp.Code)
+}
+
+func TestGetContractorJob(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	lease := BuildTestLease("1")
+	damage := BuildTestDamage("1")
+	damage.RelationsDamage.Lease = &lease
+	damage.RelationsDamage.Contractor = utils.Ptr(BuildTestContractor("1", "1"))
+	m.Damage.Expect(database.MockGetDamageByContractorToken(c, "abc")).Returns(damage)
+
+	r := router.TestRoutes()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/v1/contractor/jobs/abc/", nil)
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp models.ContractorJobResponse
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	require.NoError(t, err)
+	assert.Equal(t, damage.ID, resp.DamageID)
+	assert.Equal(t, "Mario", resp.ContractorName)
+}
+
+func TestGetContractorJob_NotFound(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	m.Damage.Expect(database.MockGetDamageByContractorToken(c, "abc")).Errors(db.ErrNotFound)
+
+	r := router.TestRoutes()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/v1/contractor/jobs/abc/", nil)
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusNotFound, w.Code)
+	var resp utils.Error
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	require.NoError(t, err)
+	assert.Equal(t, utils.ContractorJobNotFound, resp.Code)
+}
+
+func TestMarkContractorJobDone_AlreadyFixed(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	damage := BuildTestDamage("1")
+	damage.FixedOwner = true
+	damage.FixedTenant = true
+	m.Damage.Expect(database.MockGetDamageByContractorToken(c, "abc")).Returns(damage)
+
+	r := router.TestRoutes()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/v1/contractor/jobs/abc/done/", nil)
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	var resp utils.Error
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	require.NoError(t, err)
+	assert.Equal(t, utils.DamageAlreadyFixed, resp.Code)
+}
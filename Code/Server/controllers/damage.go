@@ -5,33 +5,60 @@ import (
 	"log"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"keyz/backend/models"
 	"keyz/backend/prisma/db"
 	"keyz/backend/services/brevo"
 	"keyz/backend/services/database"
+	"keyz/backend/services/pdf"
+	"keyz/backend/services/storage"
 	"keyz/backend/utils"
 )
 
-func getPictures(pics []string) ([]string, error) {
-	picturesId := make([]string, 0, len(pics))
-
+// getPictures stores the pictures of a damage, once they are all valid and fit in the storage quota of the owner.
+func getPictures(c *gin.Context, lease db.LeaseModel, pics []string) ([]string, bool) {
+	images := make([]db.ImageModel, 0, len(pics))
+	size := 0
 	for i, pic := range pics {
 		dbImage := models.StringToDbImage(pic)
 		if dbImage == nil {
-			return nil, errors.New("invalid base64 string at index " + strconv.Itoa(i))
+			utils.SendError(c, http.StatusBadRequest, utils.BadBase64OrUnsupportedType, errors.New("invalid base64 string at index "+strconv.Itoa(i)))
+			return nil, false
+		}
+		if !checkFileSize(c, *dbImage.InnerImage.Data, storage.MaxImageSize) {
+			return nil, false
 		}
-		newImage := database.CreateImage(*dbImage)
+		images = append(images, *dbImage)
+		size += len(*dbImage.InnerImage.Data)
+	}
+	if len(images) > 0 && !checkStorageQuota(c, lease.Property().OwnerID, size) {
+		return nil, false
+	}
+
+	picturesId := make([]string, 0, len(images))
+	for _, image := range images {
+		newImage := database.CreateImage(image)
 		picturesId = append(picturesId, newImage.ID)
 	}
-	return picturesId, nil
+	return picturesId, true
+}
+
+func recordDamageEvents(c *gin.Context, events []db.DamageEventModel) {
+	claims := utils.GetClaims(c)
+	for _, event := range events {
+		event.ActorID = claims["id"]
+		event.ActorRole = db.Role(claims["role"])
+		database.CreateDamageEvent(event)
+	}
 }
 
 // CreateDamage godoc
 //
 //	@Summary		Create damage
-//	@Description	Create a damage to a lease
+//	@Description	Create a damage to a lease, optionally linked to a furniture of the damaged room.
+//	@Description	The damage is then triaged by AI in the background, the suggestions are added to the damage when ready.
 //	@Tags			damage
 //	@Accept			json
 //	@Produce		json
@@ -39,9 +66,10 @@ func getPictures(pics []string) ([]string, error) {
 //	@Param			lease_id	path		string					true	"Lease ID or `current`"
 //	@Param			damages		body		models.DamageRequest	true	"Damages to create"
 //	@Success		201			{object}	models.IdResponse		"Created damage ID"
-//	@Failure		400			{object}	utils.Error				"Missing fields or bad base64 string"
-//	@Failure		403			{object}	utils.Error				"Property not yours"
-//	@Failure		404			{object}	utils.Error				"No active lease"
+//	@Failure		400			{object}	utils.Error				"Missing fields, bad base64 string or furniture not in this room"
+//	@Failure		403			{object}	utils.Error				"Property not yours or storage quota exceeded"
+//	@Failure		404			{object}	utils.Error				"No active lease, room or furniture not found"
+//	@Failure		413			{object}	utils.Error				"File too large"
 //	@Failure		500
 //	@Security		Bearer
 //	@Router			/tenant/leases/{lease_id}/damages/ [post]
@@ -53,19 +81,32 @@ func CreateDamage(c *gin.Context) {
 	}
 
 	damageReq := req.ToDbDamage()
-	if database.GetRoomByID(damageReq.RoomID) == nil {
+	room := database.GetRoomByID(damageReq.RoomID)
+	if room == nil {
 		utils.SendError(c, http.StatusNotFound, utils.RoomNotFound, nil)
 		return
 	}
+	if req.FurnitureID != nil {
+		furniture := database.GetFurnitureByID(*req.FurnitureID)
+		if furniture == nil {
+			utils.SendError(c, http.StatusNotFound, utils.FurnitureNotFound, nil)
+			return
+		}
+		if furniture.RoomID != damageReq.RoomID {
+			utils.SendError(c, http.StatusBadRequest, utils.FurnitureNotInThisRoom, nil)
+			return
+		}
+	}
 
-	picturesIds, imgErr := getPictures(req.Pictures)
-	if imgErr != nil {
-		utils.SendError(c, http.StatusBadRequest, utils.BadBase64OrUnsupportedType, imgErr)
+	lease, _ := c.MustGet("lease").(db.LeaseModel)
+	picturesIds, ok := getPictures(c, lease, req.Pictures)
+	if !ok {
 		return
 	}
 
-	lease, _ := c.MustGet("lease").(db.LeaseModel)
 	damage := database.CreateDamage(damageReq, lease.ID, picturesIds)
+	recordDamageEvents(c, []db.DamageEventModel{db.NewDamageEvent(damage.ID, db.DamageFieldCreated, nil, nil)})
+	go triageDamage(damage.ID, room.Name, req.Comment, req.Pictures)
 
 	res, err := brevo.SendNewDamage(lease)
 	if err != nil {
@@ -78,52 +119,191 @@ func CreateDamage(c *gin.Context) {
 // GetDamagesByProperty godoc
 //
 //	@Summary		Get property damages
-//	@Description	Get all damages of a property, optionally filtered by fixed status
+//	@Description	Get the damages of a property, filtered, sorted and paginated.
+//	@Description	When `limit` is set and there are more damages, the `Link` header points to the next page.
 //	@Tags			damage
 //	@Accept			json
 //	@Produce		json
-//	@Param			property_id	path		string					true	"Property ID"
-//	@Param			fixed		query		boolean					false	"Filter by fixed status (default: false)"
-//	@Success		200			{array}		models.DamageResponse	"List of damages"
-//	@Failure		403			{object}	utils.Error				"Property not yours"
-//	@Failure		404			{object}	utils.Error				"No active lease"
+//	@Param			property_id		path		string					true	"Property ID"
+//	@Param			fixed			query		boolean					false	"Filter by fixed status (default: false unless fix_status or a fixed date is given)"
+//	@Param			closed			query		boolean					false	"Filter withdrawn or dismissed damages (default: false unless fix_status is given)"
+//	@Param			fix_status		query		[]string				false	"Filter by fix statuses"	collectionFormat(multi)
+//	@Param			priority		query		[]string				false	"Filter by priorities"		collectionFormat(multi)
+//	@Param			room_id			query		string					false	"Filter by room"
+//	@Param			read			query		boolean					false	"Filter by read status"
+//	@Param			created_from	query		string					false	"Created at or after this date (RFC 3339)"
+//	@Param			created_to		query		string					false	"Created at or before this date (RFC 3339)"
+//	@Param			fixed_from		query		string					false	"Fixed at or after this date (RFC 3339)"
+//	@Param			fixed_to		query		string					false	"Fixed at or before this date (RFC 3339)"
+//	@Param			sort			query		string					false	"Sort field (default: fixed_at then created_at)"	Enums(created_at, updated_at, priority, fixed_at)
+//	@Param			order			query		string					false	"Sort order (default: desc)"						Enums(asc, desc)
+//	@Param			limit			query		int						false	"Page size, between 1 and 100 (default: no pagination)"
+//	@Param			cursor			query		string					false	"ID of the last damage of the previous page"
+//	@Param			omit_pictures	query		boolean					false	"Omit pictures from the damages"
+//	@Success		200				{array}		models.DamageResponse	"List of damages"
+//	@Header			200				{string}	Link					"Next page link, with rel=\"next\""
+//	@Failure		400				{object}	utils.Error				"Invalid query"
+//	@Failure		403				{object}	utils.Error				"Property not yours"
+//	@Failure		404				{object}	utils.Error				"No active lease"
 //	@Failure		500
 //	@Security		Bearer
 //	@Router			/owner/properties/{property_id}/damages/ [get]
 func GetDamagesByProperty(c *gin.Context) {
-	fixed := c.DefaultQuery("fixed", "false") == utils.Strue
-	damages := database.GetDamagesByPropertyID(c.Param("property_id"), fixed)
+	query, ok := bindDamageListQuery(c)
+	if !ok {
+		return
+	}
+	damages := database.GetDamagesByPropertyID(c.Param("property_id"), query)
+	damages = utils.Paginate(c, damages, query.Limit, func(d db.DamageModel) string { return d.ID })
 	c.JSON(http.StatusOK, utils.Map(damages, models.DbDamageToResponse))
 }
 
 // GetDamagesByLease godoc
 //
 //	@Summary		Get lease damages
-//	@Description	Get all damages of a lease, optionally filtered by fixed status
+//	@Description	Get the damages of a lease, filtered, sorted and paginated.
+//	@Description	When `limit` is set and there are more damages, the `Link` header points to the next page.
 //	@Tags			damage
 //	@Accept			json
 //	@Produce		json
-//	@Param			property_id	path		string					true	"Property ID"
-//	@Param			lease_id	path		string					true	"Lease ID"
-//	@Param			fixed		query		boolean					false	"Filter by fixed status (default: false)"
-//	@Success		200			{array}		models.DamageResponse	"List of damages"
-//	@Failure		403			{object}	utils.Error				"Lease not yours"
-//	@Failure		404			{object}	utils.Error				"No active lease"
+//	@Param			property_id		path		string					true	"Property ID"
+//	@Param			lease_id		path		string					true	"Lease ID"
+//	@Param			fixed			query		boolean					false	"Filter by fixed status (default: false unless fix_status or a fixed date is given)"
+//	@Param			closed			query		boolean					false	"Filter withdrawn or dismissed damages (default: false unless fix_status is given)"
+//	@Param			fix_status		query		[]string				false	"Filter by fix statuses"	collectionFormat(multi)
+//	@Param			priority		query		[]string				false	"Filter by priorities"		collectionFormat(multi)
+//	@Param			room_id			query		string					false	"Filter by room"
+//	@Param			read			query		boolean					false	"Filter by read status"
+//	@Param			created_from	query		string					false	"Created at or after this date (RFC 3339)"
+//	@Param			created_to		query		string					false	"Created at or before this date (RFC 3339)"
+//	@Param			fixed_from		query		string					false	"Fixed at or after this date (RFC 3339)"
+//	@Param			fixed_to		query		string					false	"Fixed at or before this date (RFC 3339)"
+//	@Param			sort			query		string					false	"Sort field (default: fixed_at then created_at)"	Enums(created_at, updated_at, priority, fixed_at)
+//	@Param			order			query		string					false	"Sort order (default: desc)"						Enums(asc, desc)
+//	@Param			limit			query		int						false	"Page size, between 1 and 100 (default: no pagination)"
+//	@Param			cursor			query		string					false	"ID of the last damage of the previous page"
+//	@Param			omit_pictures	query		boolean					false	"Omit pictures from the damages"
+//	@Success		200				{array}		models.DamageResponse	"List of damages"
+//	@Header			200				{string}	Link					"Next page link, with rel=\"next\""
+//	@Failure		400				{object}	utils.Error				"Invalid query"
+//	@Failure		403				{object}	utils.Error				"Lease not yours"
+//	@Failure		404				{object}	utils.Error				"No active lease"
 //	@Failure		500
 //	@Security		Bearer
 //	@Router			/owner/properties/{property_id}/leases/{lease_id}/damages/ [get]
 //	@Router			/tenant/leases/{lease_id}/damages/ [get]
 func GetDamagesByLease(c *gin.Context) {
-	fixed := c.DefaultQuery("fixed", "false") == utils.Strue
+	query, ok := bindDamageListQuery(c)
+	if !ok {
+		return
+	}
 	lease, _ := c.MustGet("lease").(db.LeaseModel)
-	damages := database.GetDamagesByLeaseID(lease.ID, fixed)
+	damages := database.GetDamagesByLeaseID(lease.ID, query)
+	damages = utils.Paginate(c, damages, query.Limit, func(d db.DamageModel) string { return d.ID })
 	c.JSON(http.StatusOK, utils.Map(damages, models.DbDamageToResponse))
 }
 
+// bindDamageListQuery binds the filters of damage lists, which only list unfixed damages by default.
+func bindDamageListQuery(c *gin.Context) (models.DamageListQuery, bool) {
+	var query models.DamageListQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		utils.SendError(c, http.StatusBadRequest, utils.MissingFields, err)
+		return query, false
+	}
+	if query.Fixed == nil && len(query.FixStatus) == 0 && query.FixedFrom == nil && query.FixedTo == nil {
+		query.Fixed = utils.Ptr(false)
+	}
+	if query.Closed == nil && len(query.FixStatus) == 0 {
+		query.Closed = utils.Ptr(false)
+	}
+	return query, true
+}
+
+// damageExportQuery lists every damage of the export, oldest first.
+var damageExportQuery = models.DamageListQuery{Sort: models.DamageSortCreatedAt, Order: db.SortOrderAsc}
+
+// sendDamageExport sends the damages as a CSV or PDF attachment, in the format requested by the query.
+func sendDamageExport(c *gin.Context, title string, id string, getDamages func() []db.DamageModel) {
+	var query models.DamageExportQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		utils.SendError(c, http.StatusBadRequest, utils.MissingFields, err)
+		return
+	}
+
+	damages := getDamages()
+	var data []byte
+	var err error
+	contentType := "text/csv"
+	if query.Format == models.DamageExportPDF {
+		data, err = pdf.NewDamageReportPDF(title, damages)
+		contentType = "application/pdf"
+	} else {
+		data, err = models.NewDamageExportCSV(damages)
+	}
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, utils.FailedCreateExport, err)
+		return
+	}
+
+	filename := "damages_" + time.Now().Format("2006-01-02") + "_" + id + "." + string(query.Format)
+	c.Header("Content-Disposition", "attachment; filename="+filename)
+	c.Data(http.StatusOK, contentType, data)
+}
+
+// ExportDamagesByProperty godoc
+//
+//	@Summary		Export property damages
+//	@Description	Export all the damages of a property, with their room, priority, dates, fix status and costs, as a CSV or PDF file.
+//	@Description	The PDF report also contains thumbnails of the damage pictures.
+//	@Tags			damage
+//	@Accept			json
+//	@Produce		text/csv,application/pdf
+//	@Param			property_id	path		string		true	"Property ID"
+//	@Param			format		query		string		true	"Export format"	Enums(csv, pdf)
+//	@Success		200			{file}		file		"Damage export"
+//	@Failure		400			{object}	utils.Error	"Invalid format"
+//	@Failure		403			{object}	utils.Error	"Property not yours"
+//	@Failure		404			{object}	utils.Error	"Property not found"
+//	@Failure		500			{object}	utils.Error	"Failed to create export"
+//	@Security		Bearer
+//	@Router			/owner/properties/{property_id}/damages/export/ [get]
+func ExportDamagesByProperty(c *gin.Context) {
+	property, _ := c.MustGet("property").(db.PropertyModel)
+	sendDamageExport(c, "Property: "+property.Name, property.ID, func() []db.DamageModel {
+		return database.GetDamagesByPropertyID(property.ID, damageExportQuery)
+	})
+}
+
+// ExportDamagesByLease godoc
+//
+//	@Summary		Export lease damages
+//	@Description	Export all the damages of a lease, with their room, priority, dates, fix status and costs, as a CSV or PDF file.
+//	@Description	The PDF report also contains thumbnails of the damage pictures.
+//	@Tags			damage
+//	@Accept			json
+//	@Produce		text/csv,application/pdf
+//	@Param			property_id	path		string		true	"Property ID"
+//	@Param			lease_id	path		string		true	"Lease ID"
+//	@Param			format		query		string		true	"Export format"	Enums(csv, pdf)
+//	@Success		200			{file}		file		"Damage export"
+//	@Failure		400			{object}	utils.Error	"Invalid format"
+//	@Failure		403			{object}	utils.Error	"Lease not yours"
+//	@Failure		404			{object}	utils.Error	"No active lease"
+//	@Failure		500			{object}	utils.Error	"Failed to create export"
+//	@Security		Bearer
+//	@Router			/owner/properties/{property_id}/leases/{lease_id}/damages/export/ [get]
+//	@Router			/tenant/leases/{lease_id}/damages/export/ [get]
+func ExportDamagesByLease(c *gin.Context) {
+	lease, _ := c.MustGet("lease").(db.LeaseModel)
+	sendDamageExport(c, "Lease: "+lease.Tenant().Name(), lease.ID, func() []db.DamageModel {
+		return database.GetDamagesByLeaseID(lease.ID, damageExportQuery)
+	})
+}
+
 // GetDamage godoc
 //
 //	@Summary		Get damage
-//	@Description	Get a damage
+//	@Description	Get a damage with its messages and the history of its changes
 //	@Tags			damage
 //	@Accept			json
 //	@Produce		json
@@ -168,6 +348,10 @@ func UpdateDamageOwner(c *gin.Context) {
 	}
 
 	damage, _ := c.MustGet("damage").(db.DamageModel)
+	if damage.IsClosed() {
+		utils.SendError(c, http.StatusBadRequest, utils.DamageClosed, nil)
+		return
+	}
 	if damage.IsFixed() {
 		utils.SendError(c, http.StatusBadRequest, utils.CannotUpdateFixedDamage, nil)
 		return
@@ -178,9 +362,133 @@ func UpdateDamageOwner(c *gin.Context) {
 		utils.SendError(c, http.StatusConflict, utils.DamageAlreadyExists, nil)
 		return
 	}
+	recordDamageEvents(c, damage.Changes(*newDamage))
+	c.JSON(http.StatusOK, models.IdResponse{ID: newDamage.ID})
+}
+
+// UpdateDamageCosts godoc
+//
+//	@Summary		Update damage costs
+//	@Description	Set the estimated and actual repair costs of a damage and who pays for it. Costs can still be set once the damage is fixed.
+//	@Tags			damage
+//	@Accept			json
+//	@Produce		json
+//	@Param			property_id	path		string						true	"Property ID"
+//	@Param			lease_id	path		string						true	"Lease ID"
+//	@Param			damage_id	path		string						true	"Damage ID"
+//	@Param			costs		body		models.DamageCostRequest	true	"Damage costs"
+//	@Success		200			{object}	models.IdResponse			"Updated damage ID"
+//	@Failure		400			{object}	utils.Error					"Missing fields"
+//	@Failure		403			{object}	utils.Error					"Property not yours"
+//	@Failure		404			{object}	utils.Error					"Damage not found"
+//	@Failure		500
+//	@Security		Bearer
+//	@Router			/owner/properties/{property_id}/leases/{lease_id}/damages/{damage_id}/costs/ [put]
+func UpdateDamageCosts(c *gin.Context) {
+	var req models.DamageCostRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.SendError(c, http.StatusBadRequest, utils.MissingFields, err)
+		return
+	}
+
+	damage, _ := c.MustGet("damage").(db.DamageModel)
+	newDamage := database.UpdateDamageCosts(damage, req)
+	recordDamageEvents(c, damage.Changes(newDamage))
 	c.JSON(http.StatusOK, models.IdResponse{ID: newDamage.ID})
 }
 
+// UploadDamageInvoice godoc
+//
+//	@Summary		Upload damage invoice
+//	@Description	Attach an invoice to a damage, it's also added to the lease documents
+//	@Tags			damage
+//	@Accept			json
+//	@Produce		json
+//	@Param			property_id	path		string					true	"Property ID"
+//	@Param			lease_id	path		string					true	"Lease ID"
+//	@Param			damage_id	path		string					true	"Damage ID"
+//	@Param			doc			body		models.DocumentRequest	true	"Invoice to upload"
+//	@Success		201			{object}	models.IdResponse		"Created document ID"
+//	@Failure		400			{object}	utils.Error				"Missing fields"
+//	@Failure		403			{object}	utils.Error				"Property not yours or storage quota exceeded"
+//	@Failure		404			{object}	utils.Error				"Damage not found"
+//	@Failure		413			{object}	utils.Error				"File too large"
+//	@Failure		500
+//	@Security		Bearer
+//	@Router			/owner/properties/{property_id}/leases/{lease_id}/damages/{damage_id}/invoices/ [post]
+func UploadDamageInvoice(c *gin.Context) {
+	var req models.DocumentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.SendError(c, http.StatusBadRequest, utils.MissingFields, err)
+		return
+	}
+
+	doc := req.ToDbDocument()
+	if doc == nil {
+		utils.SendError(c, http.StatusBadRequest, utils.BadBase64OrUnsupportedType, nil)
+		return
+	}
+
+	lease, _ := c.MustGet("lease").(db.LeaseModel)
+	if !checkFileSize(c, *doc.InnerDocument.Data, storage.MaxDocumentSize) || !checkStorageQuota(c, lease.Property().OwnerID, len(*doc.InnerDocument.Data)) {
+		return
+	}
+	damage, _ := c.MustGet("damage").(db.DamageModel)
+	res := database.CreateDamageInvoice(*doc, lease.ID, damage.ID)
+	c.JSON(http.StatusCreated, models.IdResponse{ID: res.ID})
+}
+
+// GetDamageInvoices godoc
+//
+//	@Summary		Get damage invoices
+//	@Description	Get all invoices attached to a damage
+//	@Tags			damage
+//	@Accept			json
+//	@Produce		json
+//	@Param			property_id	path		string					true	"Property ID"
+//	@Param			lease_id	path		string					true	"Lease ID"
+//	@Param			damage_id	path		string					true	"Damage ID"
+//	@Success		200			{array}		models.DocumentResponse	"List of invoices"
+//	@Failure		403			{object}	utils.Error				"Lease not yours"
+//	@Failure		404			{object}	utils.Error				"Damage not found"
+//	@Failure		500
+//	@Security		Bearer
+//	@Router			/owner/properties/{property_id}/leases/{lease_id}/damages/{damage_id}/invoices/ [get]
+//	@Router			/tenant/leases/{lease_id}/damages/{damage_id}/invoices/ [get]
+func GetDamageInvoices(c *gin.Context) {
+	damage, _ := c.MustGet("damage").(db.DamageModel)
+	docs := database.GetDocumentsByDamage(damage.ID)
+	c.JSON(http.StatusOK, utils.Map(docs, models.DbDocumentToResponse))
+}
+
+// GetPropertyExpenses godoc
+//
+//	@Summary		Get property expenses
+//	@Description	Get the repair costs of the damages reported on a property during a year, with totals by payer
+//	@Tags			damage
+//	@Accept			json
+//	@Produce		json
+//	@Param			property_id	path		string							true	"Property ID"
+//	@Param			year		query		int								false	"Year of the report (default: current year)"
+//	@Success		200			{object}	models.ExpenseReportResponse	"Expense report"
+//	@Failure		400			{object}	utils.Error						"Invalid year"
+//	@Failure		403			{object}	utils.Error						"Property not yours"
+//	@Failure		404			{object}	utils.Error						"Property not found"
+//	@Failure		500
+//	@Security		Bearer
+//	@Router			/owner/properties/{property_id}/expenses/ [get]
+func GetPropertyExpenses(c *gin.Context) {
+	year, err := strconv.Atoi(c.DefaultQuery("year", strconv.Itoa(time.Now().Year())))
+	if err != nil {
+		utils.SendError(c, http.StatusBadRequest, utils.MissingFields, err)
+		return
+	}
+
+	property, _ := c.MustGet("property").(db.PropertyModel)
+	damages := database.GetDamageExpensesByProperty(property.ID, year)
+	c.JSON(http.StatusOK, models.NewExpenseReport(property.ID, year, damages))
+}
+
 // UpdateDamageTenant godoc
 //
 //	@Summary		Update damage for tenant
@@ -194,8 +502,9 @@ func UpdateDamageOwner(c *gin.Context) {
 //	@Param			damages		body		models.DamageTenantUpdateRequest	true	"Damage update request"
 //	@Success		200			{object}	models.IdResponse					"Updated damage ID"
 //	@Failure		400			{object}	utils.Error							"Missing fields"
-//	@Failure		403			{object}	utils.Error							"Lease not yours"
+//	@Failure		403			{object}	utils.Error							"Lease not yours or storage quota exceeded"
 //	@Failure		404			{object}	utils.Error							"Damage not found"
+//	@Failure		413			{object}	utils.Error							"File too large"
 //	@Failure		500
 //	@Security		Bearer
 //	@Router			/tenant/leases/{lease_id}/damages/{damage_id}/ [put]
@@ -207,14 +516,18 @@ func UpdateDamageTenant(c *gin.Context) {
 	}
 
 	damage, _ := c.MustGet("damage").(db.DamageModel)
+	if damage.IsClosed() {
+		utils.SendError(c, http.StatusBadRequest, utils.DamageClosed, nil)
+		return
+	}
 	if damage.IsFixed() {
 		utils.SendError(c, http.StatusBadRequest, utils.CannotUpdateFixedDamage, nil)
 		return
 	}
 
-	picturesIds, imgErr := getPictures(req.AddPictures)
-	if imgErr != nil {
-		utils.SendError(c, http.StatusBadRequest, utils.BadBase64OrUnsupportedType, imgErr)
+	lease, _ := c.MustGet("lease").(db.LeaseModel)
+	picturesIds, ok := getPictures(c, lease, req.AddPictures)
+	if !ok {
 		return
 	}
 
@@ -223,6 +536,11 @@ func UpdateDamageTenant(c *gin.Context) {
 		utils.SendError(c, http.StatusConflict, utils.DamageAlreadyExists, nil)
 		return
 	}
+	events := damage.Changes(*newDamage)
+	if len(picturesIds) > 0 {
+		events = append(events, db.NewDamageEvent(damage.ID, db.DamageFieldPictures, nil, utils.Ptr(strconv.Itoa(len(picturesIds)))))
+	}
+	recordDamageEvents(c, events)
 	c.JSON(http.StatusOK, models.IdResponse{ID: newDamage.ID})
 }
 
@@ -248,11 +566,226 @@ func FixDamage(c *gin.Context) {
 	claims := utils.GetClaims(c)
 
 	damage, _ := c.MustGet("damage").(db.DamageModel)
+	if damage.IsClosed() {
+		utils.SendError(c, http.StatusBadRequest, utils.DamageClosed, nil)
+		return
+	}
 	if damage.IsFixed() {
 		utils.SendError(c, http.StatusBadRequest, utils.DamageAlreadyFixed, nil)
 		return
 	}
 
 	newDamage := database.MarkDamageAsFixed(damage, db.Role(claims["role"]))
+	recordDamageEvents(c, damage.Changes(newDamage))
+	c.JSON(http.StatusOK, models.IdResponse{ID: newDamage.ID})
+}
+
+// RejectDamageFix godoc
+//
+//	@Summary		Reject a damage fix
+//	@Description	Reject a fix declared by the other party of the lease, or by the contractor for the owner. The damage goes back to be fixed:
+//	@Description	its planned fix date is cleared and its upcoming confirmed appointments are cancelled.
+//	@Tags			damage
+//	@Accept			json
+//	@Produce		json
+//	@Param			property_id	path		string						true	"Property ID"
+//	@Param			lease_id	path		string						true	"Lease ID"
+//	@Param			damage_id	path		string						true	"Damage ID"
+//	@Param			reason		body		models.DamageReasonRequest	true	"Reason of the rejection"
+//	@Success		200			{object}	models.IdResponse			"Damage ID"
+//	@Failure		400			{object}	utils.Error					"Missing fields or no fix to reject"
+//	@Failure		403			{object}	utils.Error					"Lease not yours"
+//	@Failure		404			{object}	utils.Error					"Damage not found"
+//	@Failure		500
+//	@Security		Bearer
+//	@Router			/owner/properties/{property_id}/leases/{lease_id}/damages/{damage_id}/fix/reject/ [put]
+//	@Router			/tenant/leases/{lease_id}/damages/{damage_id}/fix/reject/ [put]
+func RejectDamageFix(c *gin.Context) {
+	var req models.DamageReasonRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.SendError(c, http.StatusBadRequest, utils.MissingFields, err)
+		return
+	}
+
+	claims := utils.GetClaims(c)
+	role := db.Role(claims["role"])
+	damage, _ := c.MustGet("damage").(db.DamageModel)
+	if !damage.CanRejectFix(role) {
+		utils.SendError(c, http.StatusBadRequest, utils.NoFixToReject, nil)
+		return
+	}
+
+	newDamage := database.RejectDamageFix(damage, role, req.Reason)
+	database.CancelUpcomingAppointments(damage.ID)
+	events := damage.Changes(newDamage)
+	events = append(events, db.NewDamageEvent(damage.ID, db.DamageFieldFixRejected, nil, &req.Reason))
+	recordDamageEvents(c, events)
+	c.JSON(http.StatusOK, models.IdResponse{ID: newDamage.ID})
+}
+
+// ReopenDamage godoc
+//
+//	@Summary		Reopen a fixed damage
+//	@Description	Reopen a damage that was fixed, for example when the problem comes back. Both fix confirmations are reset.
+//	@Tags			damage
+//	@Accept			json
+//	@Produce		json
+//	@Param			property_id	path		string						true	"Property ID"
+//	@Param			lease_id	path		string						true	"Lease ID"
+//	@Param			damage_id	path		string						true	"Damage ID"
+//	@Param			reason		body		models.DamageReasonRequest	true	"Reason of the reopening"
+//	@Success		200			{object}	models.IdResponse			"Damage ID"
+//	@Failure		400			{object}	utils.Error					"Missing fields or damage not fixed"
+//	@Failure		403			{object}	utils.Error					"Lease not yours"
+//	@Failure		404			{object}	utils.Error					"Damage not found"
+//	@Failure		500
+//	@Security		Bearer
+//	@Router			/owner/properties/{property_id}/leases/{lease_id}/damages/{damage_id}/reopen/ [put]
+//	@Router			/tenant/leases/{lease_id}/damages/{damage_id}/reopen/ [put]
+func ReopenDamage(c *gin.Context) {
+	var req models.DamageReasonRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.SendError(c, http.StatusBadRequest, utils.MissingFields, err)
+		return
+	}
+
+	damage, _ := c.MustGet("damage").(db.DamageModel)
+	if !damage.IsFixed() {
+		utils.SendError(c, http.StatusBadRequest, utils.DamageNotFixed, nil)
+		return
+	}
+
+	newDamage := database.ReopenDamage(damage, req.Reason)
+	events := damage.Changes(newDamage)
+	events = append(events, db.NewDamageEvent(damage.ID, db.DamageFieldReopened, nil, &req.Reason))
+	recordDamageEvents(c, events)
+	c.JSON(http.StatusOK, models.IdResponse{ID: newDamage.ID})
+}
+
+// WithdrawDamage godoc
+//
+//	@Summary		Withdraw a damage
+//	@Description	Withdraw a damage reported by mistake. Only damages not read by the owner yet can be withdrawn.
+//	@Tags			damage
+//	@Accept			json
+//	@Produce		json
+//	@Param			lease_id	path		string				true	"Lease ID"
+//	@Param			damage_id	path		string				true	"Damage ID"
+//	@Success		200			{object}	models.IdResponse	"Damage ID"
+//	@Failure		400			{object}	utils.Error			"Damage already read, fixed or closed"
+//	@Failure		403			{object}	utils.Error			"Lease not yours"
+//	@Failure		404			{object}	utils.Error			"Damage not found"
+//	@Failure		500
+//	@Security		Bearer
+//	@Router			/tenant/leases/{lease_id}/damages/{damage_id}/withdraw/ [put]
+func WithdrawDamage(c *gin.Context) {
+	damage, _ := c.MustGet("damage").(db.DamageModel)
+	if damage.IsClosed() {
+		utils.SendError(c, http.StatusBadRequest, utils.DamageClosed, nil)
+		return
+	}
+	if damage.IsFixed() {
+		utils.SendError(c, http.StatusBadRequest, utils.DamageAlreadyFixed, nil)
+		return
+	}
+	if damage.Read {
+		utils.SendError(c, http.StatusBadRequest, utils.DamageAlreadyRead, nil)
+		return
+	}
+
+	newDamage := database.WithdrawDamage(damage)
+	recordDamageEvents(c, []db.DamageEventModel{db.NewDamageEvent(damage.ID, db.DamageFieldWithdrawn, nil, nil)})
 	c.JSON(http.StatusOK, models.IdResponse{ID: newDamage.ID})
 }
+
+// DismissDamage godoc
+//
+//	@Summary		Dismiss a damage
+//	@Description	Close a damage without fixing it, as not being a damage or being the tenant's responsibility.
+//	@Description	Dismissed damages are excluded from the dashboard.
+//	@Tags			damage
+//	@Accept			json
+//	@Produce		json
+//	@Param			property_id	path		string						true	"Property ID"
+//	@Param			lease_id	path		string						true	"Lease ID"
+//	@Param			damage_id	path		string						true	"Damage ID"
+//	@Param			dismissal	body		models.DamageDismissRequest	true	"Dismissal type and reason"
+//	@Success		200			{object}	models.IdResponse			"Damage ID"
+//	@Failure		400			{object}	utils.Error					"Missing fields, damage fixed or already closed"
+//	@Failure		403			{object}	utils.Error					"Property not yours"
+//	@Failure		404			{object}	utils.Error					"Damage not found"
+//	@Failure		500
+//	@Security		Bearer
+//	@Router			/owner/properties/{property_id}/leases/{lease_id}/damages/{damage_id}/dismiss/ [put]
+func DismissDamage(c *gin.Context) {
+	var req models.DamageDismissRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.SendError(c, http.StatusBadRequest, utils.MissingFields, err)
+		return
+	}
+
+	damage, _ := c.MustGet("damage").(db.DamageModel)
+	if damage.IsClosed() {
+		utils.SendError(c, http.StatusBadRequest, utils.DamageClosed, nil)
+		return
+	}
+	if damage.IsFixed() {
+		utils.SendError(c, http.StatusBadRequest, utils.DamageAlreadyFixed, nil)
+		return
+	}
+
+	newDamage := database.DismissDamage(damage, req)
+	events := damage.Changes(newDamage)
+	events = append(events, db.NewDamageEvent(damage.ID, db.DamageFieldDismissed, nil, &req.Reason))
+	recordDamageEvents(c, events)
+	c.JSON(http.StatusOK, models.IdResponse{ID: newDamage.ID})
+}
+
+// CreateDamageMessage godoc
+//
+//	@Summary		Post a message on a damage
+//	@Description	Post a message with optional pictures on a damage. The other party of the lease is notified by email.
+//	@Tags			damage
+//	@Accept			json
+//	@Produce		json
+//	@Param			property_id	path		string						true	"Property ID"
+//	@Param			lease_id	path		string						true	"Lease ID"
+//	@Param			damage_id	path		string						true	"Damage ID"
+//	@Param			message		body		models.DamageMessageRequest	true	"Message to post"
+//	@Success		201			{object}	models.IdResponse			"Created message ID"
+//	@Failure		400			{object}	utils.Error					"Missing fields or bad base64 string"
+//	@Failure		403			{object}	utils.Error					"Lease not yours or storage quota exceeded"
+//	@Failure		404			{object}	utils.Error					"Damage not found"
+//	@Failure		413			{object}	utils.Error					"File too large"
+//	@Failure		500
+//	@Security		Bearer
+//	@Router			/owner/properties/{property_id}/leases/{lease_id}/damages/{damage_id}/messages/ [post]
+//	@Router			/tenant/leases/{lease_id}/damages/{damage_id}/messages/ [post]
+func CreateDamageMessage(c *gin.Context) {
+	var req models.DamageMessageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.SendError(c, http.StatusBadRequest, utils.MissingFields, err)
+		return
+	}
+
+	lease, _ := c.MustGet("lease").(db.LeaseModel)
+	picturesIds, ok := getPictures(c, lease, req.Pictures)
+	if !ok {
+		return
+	}
+
+	claims := utils.GetClaims(c)
+	damage, _ := c.MustGet("damage").(db.DamageModel)
+	message := database.CreateDamageMessage(req.ToDbDamageMessage(), damage.ID, claims["id"], picturesIds)
+
+	author, recipient := lease.Tenant(), lease.Property().Owner()
+	if db.Role(claims["role"]) == db.RoleOwner {
+		author, recipient = recipient, author
+	}
+	res, err := brevo.SendNewDamageMessage(lease, damage, message, *author, *recipient)
+	if err != nil {
+		log.Println(res, err.Error())
+	}
+
+	c.JSON(http.StatusCreated, models.IdResponse{ID: message.ID})
+}
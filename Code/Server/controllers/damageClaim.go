@@ -0,0 +1,119 @@
+package controllers
+
+import (
+	"archive/zip"
+	"bytes"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"keyz/backend/models"
+	"keyz/backend/prisma/db"
+	"keyz/backend/services/database"
+	"keyz/backend/services/pdf"
+	"keyz/backend/services/storage"
+	"keyz/backend/utils"
+)
+
+func addZipFile(w *zip.Writer, name string, data []byte) error {
+	f, err := w.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(data)
+	return err
+}
+
+func addZipImages(w *zip.Writer, prefix string, images []db.ImageModel) error {
+	for i, image := range images {
+		data, err := storage.Load(image.InnerImage.Data, image.InnerImage.StorageKey)
+		if err != nil {
+			return err
+		}
+		if err := addZipFile(w, prefix+"_"+strconv.Itoa(i+1)+"."+string(image.Type), data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// newDamageClaimZIP bundles the cover PDF, the damage pictures, the inventory states of the damaged room and furniture
+// with their pictures, and the invoices of the damage.
+func newDamageClaimZIP(damage db.DamageModel, lease db.LeaseModel, invReport *db.InventoryReportModel, invoices []db.DocumentModel) ([]byte, error) {
+	cover, err := pdf.NewDamageClaimPDF(damage, lease, invReport)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	if err := addZipFile(w, "claim.pdf", cover); err != nil {
+		return nil, err
+	}
+	if err := addZipImages(w, "pictures/damage", damage.Pictures()); err != nil {
+		return nil, err
+	}
+	if invReport != nil {
+		if roomState := invReport.RoomState(damage.RoomID); roomState != nil {
+			if err := addZipImages(w, "inventory/room", roomState.Pictures()); err != nil {
+				return nil, err
+			}
+		}
+		if furnitureID, ok := damage.FurnitureID(); ok {
+			if furnitureState := invReport.FurnitureState(furnitureID); furnitureState != nil {
+				if err := addZipImages(w, "inventory/furniture", furnitureState.Pictures()); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+	for i, invoice := range invoices {
+		data, err := storage.Load(invoice.InnerDocument.Data, invoice.InnerDocument.StorageKey)
+		if err != nil {
+			return nil, err
+		}
+		if err := addZipFile(w, "invoices/"+strconv.Itoa(i+1)+"_"+models.DocumentFileName(invoice), data); err != nil {
+			return nil, err
+		}
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// ExportDamageClaim godoc
+//
+//	@Summary		Export insurance claim
+//	@Description	Export the dossier of a damage to send to an insurer, as a ZIP file. It contains a cover PDF with the property, the lease,
+//	@Description	the tenant, the damage and its timeline, the damage pictures, the damaged room state from the latest inventory report with its pictures,
+//	@Description	and the invoices attached to the damage.
+//	@Tags			damage
+//	@Accept			json
+//	@Produce		application/zip
+//	@Param			property_id	path		string		true	"Property ID"
+//	@Param			lease_id	path		string		true	"Lease ID"
+//	@Param			damage_id	path		string		true	"Damage ID"
+//	@Success		200			{file}		file		"Claim ZIP"
+//	@Failure		403			{object}	utils.Error	"Property not yours"
+//	@Failure		404			{object}	utils.Error	"Damage or file not found"
+//	@Failure		500			{object}	utils.Error	"Failed to create export"
+//	@Security		Bearer
+//	@Router			/owner/properties/{property_id}/leases/{lease_id}/damages/{damage_id}/claim/ [get]
+func ExportDamageClaim(c *gin.Context) {
+	lease, _ := c.MustGet("lease").(db.LeaseModel)
+	damage, _ := c.MustGet("damage").(db.DamageModel)
+
+	invReport := database.GetLatestInvReportByLease(lease.ID)
+	invoices := database.GetDocumentsByDamage(damage.ID)
+	data, err := newDamageClaimZIP(damage, lease, invReport, invoices)
+	if err != nil {
+		sendLoadError(c, utils.FailedCreateExport, err)
+		return
+	}
+
+	filename := "damage_claim_" + time.Now().Format("2006-01-02") + "_" + damage.ID + ".zip"
+	c.Header("Content-Disposition", "attachment; filename="+filename)
+	c.Data(http.StatusOK, "application/zip", data)
+}
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/steebchen/prisma-client-go/engine/protocol"
 	"github.com/stretchr/testify/assert"
@@ -58,7 +59,7 @@ func BuildTestDamage(id string) db.DamageModel {
 				{
 					InnerImage: db.InnerImage{
 						ID:   "1",
-						Data: db.Bytes("base64image1"),
+						Data: utils.Ptr(db.Bytes("base64image1")),
 						Type: db.ImageTypeJpeg,
 					},
 				},
@@ -74,17 +75,21 @@ func TestCreateDamage(t *testing.T) {
 	lease := BuildTestLease("1")
 	room := BuildTestRoom("1", "1")
 	damage := BuildTestDamage("1")
-	image := BuildTestImage("1", "data:image/jpeg;base64,b3Vp")
+	image := BuildTestImage("1", "data:image/jpeg;base64,/9j/4A==")
 	mock.Lease.Expect(database.MockGetLeaseByID(c)).Returns(lease)
 	mock.Room.Expect(database.MockGetRoomByID(c)).Returns(room)
+	ExpectStorageUsage(c, mock, 0, 0)
 	mock.Image.Expect(database.MockCreateImage(c, image)).Returns(image)
 	mock.Damage.Expect(database.MockCreateDamage(c, damage, "1", []string{"1"})).Returns(damage)
+	created := db.NewDamageEvent(damage.ID, db.DamageFieldCreated, nil, nil)
+	created.ActorRole = db.RoleTenant
+	mock.DamageEvent.Expect(database.MockCreateDamageEvent(c, created)).Returns(created)
 
 	reqBody := models.DamageRequest{
 		RoomID:   damage.RoomID,
 		Comment:  damage.Comment,
 		Priority: damage.Priority,
-		Pictures: []string{"data:image/jpeg;base64,b3Vp"},
+		Pictures: []string{"data:image/jpeg;base64,/9j/4A=="},
 	}
 	b, err := json.Marshal(reqBody)
 	require.NoError(t, err)
@@ -104,6 +109,87 @@ func TestCreateDamage(t *testing.T) {
 	assert.JSONEq(t, resp.ID, damage.ID)
 }
 
+func TestCreateDamage_WithFurniture(t *testing.T) {
+	c, mock, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	lease := BuildTestLease("1")
+	room := BuildTestRoom("1", "1")
+	furniture := BuildTestFurniture("1", "1")
+	damage := BuildTestDamage("1")
+	damage.InnerDamage.FurnitureID = &furniture.ID
+	image := BuildTestImage("1", "data:image/jpeg;base64,/9j/4A==")
+	mock.Lease.Expect(database.MockGetLeaseByID(c)).Returns(lease)
+	mock.Room.Expect(database.MockGetRoomByID(c)).Returns(room)
+	mock.Furniture.Expect(database.MockGetFurnitureByID(c)).Returns(furniture)
+	ExpectStorageUsage(c, mock, 0, 0)
+	mock.Image.Expect(database.MockCreateImage(c, image)).Returns(image)
+	mock.Damage.Expect(database.MockCreateDamage(c, damage, "1", []string{"1"})).Returns(damage)
+	created := db.NewDamageEvent(damage.ID, db.DamageFieldCreated, nil, nil)
+	created.ActorRole = db.RoleTenant
+	mock.DamageEvent.Expect(database.MockCreateDamageEvent(c, created)).Returns(created)
+
+	reqBody := models.DamageRequest{
+		RoomID:      damage.RoomID,
+		FurnitureID: &furniture.ID,
+		Comment:     damage.Comment,
+		Priority:    damage.Priority,
+		Pictures:    []string{"data:image/jpeg;base64,/9j/4A=="},
+	}
+	b, err := json.Marshal(reqBody)
+	require.NoError(t, err)
+
+	r := router.TestRoutes()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/v1/tenant/leases/1/damages/", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Oauth.claims.id", "1")
+	req.Header.Set("Oauth.claims.role", string(db.RoleTenant))
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusCreated, w.Code)
+	var resp models.IdResponse
+	err = json.Unmarshal(w.Body.Bytes(), &resp)
+	require.NoError(t, err)
+	assert.Equal(t, damage.ID, resp.ID)
+}
+
+func TestCreateDamage_FurnitureNotInThisRoom(t *testing.T) {
+	c, mock, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	lease := BuildTestLease("1")
+	room := BuildTestRoom("1", "1")
+	furniture := BuildTestFurniture("1", "2")
+	mock.Lease.Expect(database.MockGetLeaseByID(c)).Returns(lease)
+	mock.Room.Expect(database.MockGetRoomByID(c)).Returns(room)
+	mock.Furniture.Expect(database.MockGetFurnitureByID(c)).Returns(furniture)
+
+	reqBody := models.DamageRequest{
+		RoomID:      "1",
+		FurnitureID: &furniture.ID,
+		Comment:     "Test Comment",
+		Priority:    db.PriorityHigh,
+		Pictures:    []string{"data:image/jpeg;base64,/9j/4A=="},
+	}
+	b, err := json.Marshal(reqBody)
+	require.NoError(t, err)
+
+	r := router.TestRoutes()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/v1/tenant/leases/1/damages/", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Oauth.claims.id", "1")
+	req.Header.Set("Oauth.claims.role", string(db.RoleTenant))
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	var resp utils.Error
+	err = json.Unmarshal(w.Body.Bytes(), &resp)
+	require.NoError(t, err)
+	assert.Equal(t, utils.FurnitureNotInThisRoom, resp.Code)
+}
+
 func TestCreateDamage_MissingFields(t *testing.T) {
 	c, mock, ensure := services.ConnectDBTest()
 	defer ensure(t)
@@ -114,7 +200,7 @@ func TestCreateDamage_MissingFields(t *testing.T) {
 	reqBody := models.DamageRequest{
 		RoomID:   "1",
 		Comment:  "Test Comment",
-		Pictures: []string{"data:image/jpeg;base64,b3Vp"},
+		Pictures: []string{"data:image/jpeg;base64,/9j/4A=="},
 	}
 	b, err := json.Marshal(reqBody)
 	require.NoError(t, err)
@@ -146,7 +232,7 @@ func TestCreateDamage_RoomNotFound(t *testing.T) {
 		RoomID:   "1",
 		Comment:  "Test Comment",
 		Priority: db.PriorityHigh,
-		Pictures: []string{"data:image/jpeg;base64,b3Vp"},
+		Pictures: []string{"data:image/jpeg;base64,/9j/4A=="},
 	}
 	b, err := json.Marshal(reqBody)
 	require.NoError(t, err)
@@ -176,7 +262,7 @@ func TestGetDamagesByProperty(t *testing.T) {
 		BuildTestDamage("2"),
 	}
 	mock.Property.Expect(database.MockGetPropertyByID(c)).Returns(property)
-	mock.Damage.Expect(database.MockGetDamagesByPropertyID(c, false)).ReturnsMany(damages)
+	mock.Damage.Expect(database.MockGetDamagesByPropertyID(c, models.DamageListQuery{Fixed: utils.Ptr(false), Closed: utils.Ptr(false)})).ReturnsMany(damages)
 
 	r := router.TestRoutes()
 	w := httptest.NewRecorder()
@@ -195,6 +281,119 @@ func TestGetDamagesByProperty(t *testing.T) {
 	assert.Equal(t, damages[1].ID, resp[1].ID)
 }
 
+func TestGetDamagesByProperty_FilteredPage(t *testing.T) {
+	c, mock, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	property := BuildTestProperty("1")
+	damages := []db.DamageModel{
+		BuildTestDamage("1"),
+		BuildTestDamage("2"),
+	}
+	damages[0].RelationsDamage.Pictures = nil
+	damages[1].RelationsDamage.Pictures = nil
+	query := models.DamageListQuery{
+		FixStatus:    []db.FixStatus{db.FixStatusPlanned},
+		Priority:     []db.Priority{db.PriorityHigh, db.PriorityUrgent},
+		RoomID:       utils.Ptr("1"),
+		Sort:         models.DamageSortPriority,
+		Order:        db.SortOrderDesc,
+		Limit:        1,
+		OmitPictures: true,
+	}
+	mock.Property.Expect(database.MockGetPropertyByID(c)).Returns(property)
+	mock.Damage.Expect(database.MockGetDamagesByPropertyID(c, query)).ReturnsMany(damages)
+
+	r := router.TestRoutes()
+	w := httptest.NewRecorder()
+	url := "/v1/owner/properties/1/damages/?fix_status=planned&priority=high&priority=urgent&room_id=1&sort=priority&order=desc&limit=1&omit_pictures=true"
+	req, _ := http.NewRequest(http.MethodGet, url, nil)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Oauth.claims.id", "1")
+	req.Header.Set("Oauth.claims.role", string(db.RoleOwner))
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp []models.DamageResponse
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	require.NoError(t, err)
+	require.Len(t, resp, 1)
+	assert.Equal(t, damages[0].ID, resp[0].ID)
+	assert.Empty(t, resp[0].Pictures)
+	assert.Contains(t, w.Header().Get("Link"), "cursor=1")
+	assert.Contains(t, w.Header().Get("Link"), `rel="next"`)
+}
+
+func TestGetDamagesByProperty_InvalidQuery(t *testing.T) {
+	c, mock, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	property := BuildTestProperty("1")
+	mock.Property.Expect(database.MockGetPropertyByID(c)).Returns(property)
+
+	r := router.TestRoutes()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/v1/owner/properties/1/damages/?fix_status=unknown", nil)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Oauth.claims.id", "1")
+	req.Header.Set("Oauth.claims.role", string(db.RoleOwner))
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	var resp utils.Error
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	require.NoError(t, err)
+	assert.Equal(t, utils.MissingFields, resp.Code)
+}
+
+func TestExportDamagesByProperty(t *testing.T) {
+	c, mock, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	property := BuildTestProperty("1")
+	damages := []db.DamageModel{
+		BuildTestDamage("1"),
+		BuildTestDamage("2"),
+	}
+	mock.Property.Expect(database.MockGetPropertyByID(c)).Returns(property)
+	mock.Damage.Expect(database.MockGetDamagesByPropertyID(c, models.DamageListQuery{Sort: models.DamageSortCreatedAt, Order: db.SortOrderAsc})).ReturnsMany(damages)
+
+	r := router.TestRoutes()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/v1/owner/properties/1/damages/export/?format=csv", nil)
+	req.Header.Set("Oauth.claims.id", "1")
+	req.Header.Set("Oauth.claims.role", string(db.RoleOwner))
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/csv", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Header().Get("Content-Disposition"), "attachment; filename=damages_")
+	assert.Contains(t, w.Body.String(), "ID,Tenant,Room,")
+	assert.Contains(t, w.Body.String(), "1,John Doe,Living Room,,Test Comment,high,pending,")
+	assert.Contains(t, w.Body.String(), "2,John Doe,Living Room,")
+}
+
+func TestExportDamagesByProperty_BadFormat(t *testing.T) {
+	c, mock, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	property := BuildTestProperty("1")
+	mock.Property.Expect(database.MockGetPropertyByID(c)).Returns(property)
+
+	r := router.TestRoutes()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/v1/owner/properties/1/damages/export/?format=xlsx", nil)
+	req.Header.Set("Oauth.claims.id", "1")
+	req.Header.Set("Oauth.claims.role", string(db.RoleOwner))
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	var resp utils.Error
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	require.NoError(t, err)
+	assert.Equal(t, utils.MissingFields, resp.Code)
+}
+
 func TestGetDamagesByProperty_PropertyNotYours(t *testing.T) {
 	c, mock, ensure := services.ConnectDBTest()
 	defer ensure(t)
@@ -227,7 +426,7 @@ func TestGetFixedDamagesByProperty(t *testing.T) {
 		BuildTestDamage("2"),
 	}
 	mock.Property.Expect(database.MockGetPropertyByID(c)).Returns(property)
-	mock.Damage.Expect(database.MockGetDamagesByPropertyID(c, true)).ReturnsMany(damages)
+	mock.Damage.Expect(database.MockGetDamagesByPropertyID(c, models.DamageListQuery{Fixed: utils.Ptr(true), Closed: utils.Ptr(false)})).ReturnsMany(damages)
 
 	r := router.TestRoutes()
 	w := httptest.NewRecorder()
@@ -280,7 +479,7 @@ func TestGetDamagesByLease(t *testing.T) {
 	}
 	mock.Property.Expect(database.MockGetPropertyByID(c)).Returns(property)
 	mock.Lease.Expect(database.MockGetLeaseByID(c)).Returns(lease)
-	mock.Damage.Expect(database.MockGetDamagesByLeaseID(c, false)).ReturnsMany(damages)
+	mock.Damage.Expect(database.MockGetDamagesByLeaseID(c, models.DamageListQuery{Fixed: utils.Ptr(false), Closed: utils.Ptr(false)})).ReturnsMany(damages)
 
 	r := router.TestRoutes()
 	w := httptest.NewRecorder()
@@ -357,7 +556,7 @@ func TestGetFixedDamagesByLease(t *testing.T) {
 	}
 	mock.Property.Expect(database.MockGetPropertyByID(c)).Returns(property)
 	mock.Lease.Expect(database.MockGetLeaseByID(c)).Returns(lease)
-	mock.Damage.Expect(database.MockGetDamagesByLeaseID(c, true)).ReturnsMany(damages)
+	mock.Damage.Expect(database.MockGetDamagesByLeaseID(c, models.DamageListQuery{Fixed: utils.Ptr(true), Closed: utils.Ptr(false)})).ReturnsMany(damages)
 
 	r := router.TestRoutes()
 	w := httptest.NewRecorder()
@@ -625,20 +824,24 @@ func TestUpdateDamageTenant(t *testing.T) {
 
 	lease := BuildTestLease("1")
 	damage := BuildTestDamage("1")
-	image := BuildTestImage("1", "data:image/jpeg;base64,b3Vp")
+	image := BuildTestImage("1", "data:image/jpeg;base64,/9j/4A==")
 	mock.Lease.Expect(database.MockGetLeaseByID(c)).Returns(lease)
 	mock.Damage.Expect(database.MockGetDamageByID(c)).Returns(damage)
+	ExpectStorageUsage(c, mock, 0, 0)
 	mock.Image.Expect(database.MockCreateImage(c, image)).Returns(image)
 	mock.Damage.Expect(database.MockUpdateDamageTenant(c, models.DamageTenantUpdateRequest{
 		Comment:     utils.Ptr("Updated Comment"),
 		Priority:    utils.Ptr(db.PriorityLow),
 		AddPictures: []string{"1"},
 	}, []string{"1"})).Returns(damage)
+	added := db.NewDamageEvent(damage.ID, db.DamageFieldPictures, nil, utils.Ptr("1"))
+	added.ActorRole = db.RoleTenant
+	mock.DamageEvent.Expect(database.MockCreateDamageEvent(c, added)).Returns(added)
 
 	reqBody := models.DamageTenantUpdateRequest{
 		Comment:     utils.Ptr("Updated Comment"),
 		Priority:    utils.Ptr(db.PriorityLow),
-		AddPictures: []string{"data:image/jpeg;base64,b3Vp"},
+		AddPictures: []string{"data:image/jpeg;base64,/9j/4A=="},
 	}
 	b, err := json.Marshal(reqBody)
 	require.NoError(t, err)
@@ -759,9 +962,10 @@ func TestUpdateDamageTenant_AlreadyExists(t *testing.T) {
 	lease := BuildTestLease("1")
 	damage := BuildTestDamage("1")
 	damage.FixedTenant = true
-	image := BuildTestImage("1", "data:image/jpeg;base64,b3Vp")
+	image := BuildTestImage("1", "data:image/jpeg;base64,/9j/4A==")
 	mock.Lease.Expect(database.MockGetLeaseByID(c)).Returns(lease)
 	mock.Damage.Expect(database.MockGetDamageByID(c)).Returns(damage)
+	ExpectStorageUsage(c, mock, 0, 0)
 	mock.Image.Expect(database.MockCreateImage(c, image)).Returns(image)
 	mock.Damage.Expect(database.MockUpdateDamageTenant(c, models.DamageTenantUpdateRequest{
 		Comment:     utils.Ptr("Updated Comment"),
@@ -779,7 +983,7 @@ func TestUpdateDamageTenant_AlreadyExists(t *testing.T) {
 	reqBody := models.DamageTenantUpdateRequest{
 		Comment:     utils.Ptr("Updated Comment"),
 		Priority:    utils.Ptr(db.PriorityLow),
-		AddPictures: []string{"data:image/jpeg;base64,b3Vp"},
+		AddPictures: []string{"data:image/jpeg;base64,/9j/4A=="},
 	}
 	b, err := json.Marshal(reqBody)
 	require.NoError(t, err)
@@ -899,3 +1103,609 @@ func TestFixDamage_DamageNotFound(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, utils.DamageNotFound, resp.Code)
 }
+
+func BuildTestDamageMessage(id string) db.DamageMessageModel {
+	return db.DamageMessageModel{
+		InnerDamageMessage: db.InnerDamageMessage{
+			ID:       id,
+			DamageID: "1",
+			AuthorID: "1",
+			Content:  "Test message",
+		},
+		RelationsDamageMessage: db.RelationsDamageMessage{
+			Author: &db.UserModel{
+				InnerUser: db.InnerUser{
+					ID:        "1",
+					Firstname: "John",
+					Lastname:  "Doe",
+					Role:      db.RoleTenant,
+				},
+			},
+			Pictures: []db.ImageModel{},
+		},
+	}
+}
+
+func TestGetDamage_WithMessages(t *testing.T) {
+	c, mock, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	lease := BuildTestLease("1")
+	damage := BuildTestDamage("1")
+	damage.RelationsDamage.Messages = []db.DamageMessageModel{BuildTestDamageMessage("1"), BuildTestDamageMessage("2")}
+	mock.Lease.Expect(database.MockGetLeaseByID(c)).Returns(lease)
+	mock.Damage.Expect(database.MockGetDamageByID(c)).Returns(damage)
+
+	r := router.TestRoutes()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/v1/tenant/leases/1/damages/1/", nil)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Oauth.claims.id", "1")
+	req.Header.Set("Oauth.claims.role", string(db.RoleTenant))
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp models.DamageResponse
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	require.NoError(t, err)
+	require.Len(t, resp.Messages, 2)
+	assert.Equal(t, "1", resp.Messages[0].ID)
+	assert.Equal(t, "John Doe", resp.Messages[0].AuthorName)
+}
+
+func TestCreateDamageMessage(t *testing.T) {
+	c, mock, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	lease := BuildTestLease("1")
+	damage := BuildTestDamage("1")
+	message := BuildTestDamageMessage("1")
+	image := BuildTestImage("1", "data:image/jpeg;base64,/9j/4A==")
+	mock.Lease.Expect(database.MockGetLeaseByID(c)).Returns(lease)
+	mock.Damage.Expect(database.MockGetDamageByID(c)).Returns(damage)
+	ExpectStorageUsage(c, mock, 0, 0)
+	mock.Image.Expect(database.MockCreateImage(c, image)).Returns(image)
+	mock.DamageMessage.Expect(database.MockCreateDamageMessage(c, message, []string{"1"})).Returns(message)
+
+	reqBody := models.DamageMessageRequest{
+		Content:  message.Content,
+		Pictures: []string{"data:image/jpeg;base64,/9j/4A=="},
+	}
+	b, err := json.Marshal(reqBody)
+	require.NoError(t, err)
+
+	r := router.TestRoutes()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/v1/tenant/leases/1/damages/1/messages/", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Oauth.claims.id", "1")
+	req.Header.Set("Oauth.claims.role", string(db.RoleTenant))
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusCreated, w.Code)
+	var resp models.IdResponse
+	err = json.Unmarshal(w.Body.Bytes(), &resp)
+	require.NoError(t, err)
+	assert.Equal(t, message.ID, resp.ID)
+}
+
+func TestCreateDamageMessage_MissingFields(t *testing.T) {
+	c, mock, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	property := BuildTestProperty("1")
+	lease := BuildTestLease("1")
+	damage := BuildTestDamage("1")
+	mock.Property.Expect(database.MockGetPropertyByID(c)).Returns(property)
+	mock.Lease.Expect(database.MockGetLeaseByID(c)).Returns(lease)
+	mock.Damage.Expect(database.MockGetDamageByID(c)).Returns(damage)
+
+	b, err := json.Marshal(models.DamageMessageRequest{})
+	require.NoError(t, err)
+
+	r := router.TestRoutes()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/v1/owner/properties/1/leases/1/damages/1/messages/", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Oauth.claims.id", "1")
+	req.Header.Set("Oauth.claims.role", string(db.RoleOwner))
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	var resp utils.Error
+	err = json.Unmarshal(w.Body.Bytes(), &resp)
+	require.NoError(t, err)
+	assert.Equal(t, utils.MissingFields, resp.Code)
+}
+
+func TestFixDamageTenant_RecordsHistory(t *testing.T) {
+	c, mock, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	lease := BuildTestLease("1")
+	damage := BuildTestDamage("1")
+	fixed := BuildTestDamage("1")
+	fixed.FixedTenant = true
+	mock.Lease.Expect(database.MockGetLeaseByID(c)).Returns(lease)
+	mock.Damage.Expect(database.MockGetDamageByID(c)).Returns(damage)
+	mock.Damage.Expect(database.MockMarkDamageAsFixed(c, damage, db.RoleTenant)).Returns(fixed)
+	event := db.NewDamageEvent(damage.ID, db.DamageFieldFixedTenant, utils.Ptr("false"), utils.Ptr("true"))
+	event.ActorRole = db.RoleTenant
+	mock.DamageEvent.Expect(database.MockCreateDamageEvent(c, event)).Returns(event)
+
+	r := router.TestRoutes()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPut, "/v1/tenant/leases/1/damages/1/fix/", nil)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Oauth.claims.id", "1")
+	req.Header.Set("Oauth.claims.role", string(db.RoleTenant))
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestUpdateDamageCosts(t *testing.T) {
+	c, mock, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	property := BuildTestProperty("1")
+	lease := BuildTestLease("1")
+	damage := BuildTestDamage("1")
+	damage.FixedOwner = true
+	damage.FixedTenant = true
+	reqBody := models.DamageCostRequest{
+		ActualCost: utils.Ptr(120.0),
+		Payer:      utils.Ptr(db.PayerTenant),
+	}
+	updated := damage
+	updated.ActualCost = reqBody.ActualCost
+	updated.Payer = reqBody.Payer
+	mock.Property.Expect(database.MockGetPropertyByID(c)).Returns(property)
+	mock.Lease.Expect(database.MockGetLeaseByID(c)).Returns(lease)
+	mock.Damage.Expect(database.MockGetDamageByID(c)).Returns(damage)
+	mock.Damage.Expect(database.MockUpdateDamageCosts(c, reqBody)).Returns(updated)
+	for _, event := range damage.Changes(updated) {
+		event.ActorRole = db.RoleOwner
+		mock.DamageEvent.Expect(database.MockCreateDamageEvent(c, event)).Returns(event)
+	}
+
+	b, err := json.Marshal(reqBody)
+	require.NoError(t, err)
+
+	r := router.TestRoutes()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPut, "/v1/owner/properties/1/leases/1/damages/1/costs/", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Oauth.claims.id", "1")
+	req.Header.Set("Oauth.claims.role", string(db.RoleOwner))
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestUpdateDamageCosts_BadPayer(t *testing.T) {
+	c, mock, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	property := BuildTestProperty("1")
+	lease := BuildTestLease("1")
+	mock.Property.Expect(database.MockGetPropertyByID(c)).Returns(property)
+	mock.Lease.Expect(database.MockGetLeaseByID(c)).Returns(lease)
+	mock.Damage.Expect(database.MockGetDamageByID(c)).Returns(BuildTestDamage("1"))
+
+	reqBody := models.DamageCostRequest{
+		EstimatedCost: utils.Ptr(-10.0),
+		Payer:         utils.Ptr(db.Payer("neighbour")),
+	}
+	b, err := json.Marshal(reqBody)
+	require.NoError(t, err)
+
+	r := router.TestRoutes()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPut, "/v1/owner/properties/1/leases/1/damages/1/costs/", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Oauth.claims.id", "1")
+	req.Header.Set("Oauth.claims.role", string(db.RoleOwner))
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	var resp utils.Error
+	err = json.Unmarshal(w.Body.Bytes(), &resp)
+	require.NoError(t, err)
+	assert.Equal(t, utils.MissingFields, resp.Code)
+}
+
+func TestUploadDamageInvoice(t *testing.T) {
+	c, mock, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	property := BuildTestProperty("1")
+	lease := BuildTestLease("1")
+	document := BuildTestDocument()
+	mock.Property.Expect(database.MockGetPropertyByID(c)).Returns(property)
+	mock.Lease.Expect(database.MockGetLeaseByID(c)).Returns(lease)
+	mock.Damage.Expect(database.MockGetDamageByID(c)).Returns(BuildTestDamage("1"))
+	ExpectStorageUsage(c, mock, 0, 0)
+	mock.Document.Expect(database.MockCreateDamageInvoice(c, document)).Returns(document)
+
+	docRequest := models.DocumentRequest{
+		Name: "Test Document",
+		Data: "data:application/pdf;base64,JVBERi0xLjQgVGVzdCBEYXRh", // Base64 encoded "%PDF-1.4 Test Data"
+	}
+	b, err := json.Marshal(docRequest)
+	require.NoError(t, err)
+
+	r := router.TestRoutes()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/v1/owner/properties/1/leases/1/damages/1/invoices/", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Oauth.claims.id", "1")
+	req.Header.Set("Oauth.claims.role", string(db.RoleOwner))
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusCreated, w.Code)
+	var resp models.IdResponse
+	err = json.Unmarshal(w.Body.Bytes(), &resp)
+	require.NoError(t, err)
+	assert.Equal(t, document.ID, resp.ID)
+}
+
+func TestGetDamageInvoices(t *testing.T) {
+	c, mock, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	lease := BuildTestLease("1")
+	document := BuildTestDocument()
+	document.DamageID = utils.Ptr("1")
+	mock.Lease.Expect(database.MockGetLeaseByID(c)).Returns(lease)
+	mock.Damage.Expect(database.MockGetDamageByID(c)).Returns(BuildTestDamage("1"))
+	mock.Document.Expect(database.MockGetDocumentsByDamage(c)).ReturnsMany([]db.DocumentModel{document})
+
+	r := router.TestRoutes()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/v1/tenant/leases/1/damages/1/invoices/", nil)
+	req.Header.Set("Oauth.claims.id", "1")
+	req.Header.Set("Oauth.claims.role", string(db.RoleTenant))
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp []models.DocumentResponse
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	require.NoError(t, err)
+	require.Len(t, resp, 1)
+	assert.Equal(t, document.DamageID, resp[0].DamageID)
+}
+
+func TestGetPropertyExpenses(t *testing.T) {
+	c, mock, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	property := BuildTestProperty("1")
+	damage := BuildTestDamage("1")
+	damage.EstimatedCost = utils.Ptr(100.0)
+	damage.ActualCost = utils.Ptr(150.0)
+	damage.Payer = utils.Ptr(db.PayerTenant)
+	mock.Property.Expect(database.MockGetPropertyByID(c)).Returns(property)
+	mock.Damage.Expect(database.MockGetDamageExpensesByProperty(c, 2025)).ReturnsMany([]db.DamageModel{damage})
+
+	r := router.TestRoutes()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/v1/owner/properties/1/expenses/?year=2025", nil)
+	req.Header.Set("Oauth.claims.id", "1")
+	req.Header.Set("Oauth.claims.role", string(db.RoleOwner))
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp models.ExpenseReportResponse
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	require.NoError(t, err)
+	assert.Equal(t, 2025, resp.Year)
+	assert.InDelta(t, 150.0, resp.TotalActual, 0)
+	assert.InDelta(t, 150.0, resp.TotalByPayer[db.PayerTenant], 0)
+	require.Len(t, resp.Expenses, 1)
+}
+
+func TestGetPropertyExpenses_BadYear(t *testing.T) {
+	c, mock, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	mock.Property.Expect(database.MockGetPropertyByID(c)).Returns(BuildTestProperty("1"))
+
+	r := router.TestRoutes()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/v1/owner/properties/1/expenses/?year=last", nil)
+	req.Header.Set("Oauth.claims.id", "1")
+	req.Header.Set("Oauth.claims.role", string(db.RoleOwner))
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestRejectDamageFix(t *testing.T) {
+	c, mock, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	property := BuildTestProperty("1")
+	lease := BuildTestLease("1")
+	damage := BuildTestDamage("1")
+	damage.FixedTenant = true
+	rejected := damage
+	rejected.FixedTenant = false
+	rejected.FixRejectedAt = utils.Ptr(time.Now().Truncate(time.Minute))
+	reason := "Still leaking under the sink"
+	mock.Property.Expect(database.MockGetPropertyByID(c)).Returns(property)
+	mock.Lease.Expect(database.MockGetLeaseByID(c)).Returns(lease)
+	mock.Damage.Expect(database.MockGetDamageByID(c)).Returns(damage)
+	mock.Damage.Expect(database.MockRejectDamageFix(c, db.RoleOwner, reason)).Returns(rejected)
+	mock.DamageAppointment.Expect(database.MockCancelUpcomingAppointments(c)).Returns(db.DamageAppointmentModel{})
+	events := append(damage.Changes(rejected), db.NewDamageEvent(damage.ID, db.DamageFieldFixRejected, nil, &reason))
+	for _, event := range events {
+		event.ActorRole = db.RoleOwner
+		mock.DamageEvent.Expect(database.MockCreateDamageEvent(c, event)).Returns(event)
+	}
+
+	b, err := json.Marshal(models.DamageReasonRequest{Reason: reason})
+	require.NoError(t, err)
+
+	r := router.TestRoutes()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPut, "/v1/owner/properties/1/leases/1/damages/1/fix/reject/", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Oauth.claims.id", "1")
+	req.Header.Set("Oauth.claims.role", string(db.RoleOwner))
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestRejectDamageFix_NothingToReject(t *testing.T) {
+	c, mock, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	lease := BuildTestLease("1")
+	damage := BuildTestDamage("1")
+	damage.FixedTenant = true
+	mock.Lease.Expect(database.MockGetLeaseByID(c)).Returns(lease)
+	mock.Damage.Expect(database.MockGetDamageByID(c)).Returns(damage)
+
+	b, err := json.Marshal(models.DamageReasonRequest{Reason: "Not fixed"})
+	require.NoError(t, err)
+
+	r := router.TestRoutes()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPut, "/v1/tenant/leases/1/damages/1/fix/reject/", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Oauth.claims.id", "1")
+	req.Header.Set("Oauth.claims.role", string(db.RoleTenant))
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	var resp utils.Error
+	err = json.Unmarshal(w.Body.Bytes(), &resp)
+	require.NoError(t, err)
+	assert.Equal(t, utils.NoFixToReject, resp.Code)
+}
+
+func TestRejectDamageFix_MissingReason(t *testing.T) {
+	c, mock, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	lease := BuildTestLease("1")
+	mock.Lease.Expect(database.MockGetLeaseByID(c)).Returns(lease)
+	mock.Damage.Expect(database.MockGetDamageByID(c)).Returns(BuildTestDamage("1"))
+
+	r := router.TestRoutes()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPut, "/v1/tenant/leases/1/damages/1/fix/reject/", bytes.NewReader([]byte("{}")))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Oauth.claims.id", "1")
+	req.Header.Set("Oauth.claims.role", string(db.RoleTenant))
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	var resp utils.Error
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	require.NoError(t, err)
+	assert.Equal(t, utils.MissingFields, resp.Code)
+}
+
+func TestReopenDamage(t *testing.T) {
+	c, mock, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	lease := BuildTestLease("1")
+	damage := BuildTestDamage("1")
+	damage.FixedTenant = true
+	damage.FixedOwner = true
+	damage.FixedAt = utils.Ptr(time.Now().Add(-24 * time.Hour))
+	reopened := damage
+	reopened.FixedTenant = false
+	reopened.FixedOwner = false
+	reopened.FixedAt = nil
+	reopened.ReopenedAt = utils.Ptr(time.Now().Truncate(time.Minute))
+	reason := "The leak is back"
+	mock.Lease.Expect(database.MockGetLeaseByID(c)).Returns(lease)
+	mock.Damage.Expect(database.MockGetDamageByID(c)).Returns(damage)
+	mock.Damage.Expect(database.MockReopenDamage(c, reason)).Returns(reopened)
+	events := append(damage.Changes(reopened), db.NewDamageEvent(damage.ID, db.DamageFieldReopened, nil, &reason))
+	for _, event := range events {
+		event.ActorRole = db.RoleTenant
+		mock.DamageEvent.Expect(database.MockCreateDamageEvent(c, event)).Returns(event)
+	}
+
+	b, err := json.Marshal(models.DamageReasonRequest{Reason: reason})
+	require.NoError(t, err)
+
+	r := router.TestRoutes()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPut, "/v1/tenant/leases/1/damages/1/reopen/", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Oauth.claims.id", "1")
+	req.Header.Set("Oauth.claims.role", string(db.RoleTenant))
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestReopenDamage_NotFixed(t *testing.T) {
+	c, mock, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	lease := BuildTestLease("1")
+	mock.Lease.Expect(database.MockGetLeaseByID(c)).Returns(lease)
+	mock.Damage.Expect(database.MockGetDamageByID(c)).Returns(BuildTestDamage("1"))
+
+	b, err := json.Marshal(models.DamageReasonRequest{Reason: "The leak is back"})
+	require.NoError(t, err)
+
+	r := router.TestRoutes()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPut, "/v1/tenant/leases/1/damages/1/reopen/", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Oauth.claims.id", "1")
+	req.Header.Set("Oauth.claims.role", string(db.RoleTenant))
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	var resp utils.Error
+	err = json.Unmarshal(w.Body.Bytes(), &resp)
+	require.NoError(t, err)
+	assert.Equal(t, utils.DamageNotFixed, resp.Code)
+}
+
+func TestWithdrawDamage(t *testing.T) {
+	c, mock, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	lease := BuildTestLease("1")
+	damage := BuildTestDamage("1")
+	damage.Read = false
+	withdrawn := damage
+	withdrawn.WithdrawnAt = utils.Ptr(time.Now().Truncate(time.Minute))
+	mock.Lease.Expect(database.MockGetLeaseByID(c)).Returns(lease)
+	mock.Damage.Expect(database.MockGetDamageByID(c)).Returns(damage)
+	mock.Damage.Expect(database.MockWithdrawDamage(c)).Returns(withdrawn)
+	event := db.NewDamageEvent(damage.ID, db.DamageFieldWithdrawn, nil, nil)
+	event.ActorRole = db.RoleTenant
+	mock.DamageEvent.Expect(database.MockCreateDamageEvent(c, event)).Returns(event)
+
+	r := router.TestRoutes()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPut, "/v1/tenant/leases/1/damages/1/withdraw/", nil)
+	req.Header.Set("Oauth.claims.id", "1")
+	req.Header.Set("Oauth.claims.role", string(db.RoleTenant))
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestWithdrawDamage_AlreadyRead(t *testing.T) {
+	c, mock, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	lease := BuildTestLease("1")
+	mock.Lease.Expect(database.MockGetLeaseByID(c)).Returns(lease)
+	mock.Damage.Expect(database.MockGetDamageByID(c)).Returns(BuildTestDamage("1"))
+
+	r := router.TestRoutes()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPut, "/v1/tenant/leases/1/damages/1/withdraw/", nil)
+	req.Header.Set("Oauth.claims.id", "1")
+	req.Header.Set("Oauth.claims.role", string(db.RoleTenant))
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	var resp utils.Error
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	require.NoError(t, err)
+	assert.Equal(t, utils.DamageAlreadyRead, resp.Code)
+}
+
+func TestDismissDamage(t *testing.T) {
+	c, mock, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	property := BuildTestProperty("1")
+	lease := BuildTestLease("1")
+	damage := BuildTestDamage("1")
+	reqBody := models.DamageDismissRequest{
+		Dismissal: db.DismissalTenantResponsibility,
+		Reason:    "Broken by the tenant's dog",
+	}
+	dismissed := damage
+	dismissed.DismissedAt = utils.Ptr(time.Now().Truncate(time.Minute))
+	dismissed.Dismissal = &reqBody.Dismissal
+	dismissed.DismissedReason = &reqBody.Reason
+	mock.Property.Expect(database.MockGetPropertyByID(c)).Returns(property)
+	mock.Lease.Expect(database.MockGetLeaseByID(c)).Returns(lease)
+	mock.Damage.Expect(database.MockGetDamageByID(c)).Returns(damage)
+	mock.Damage.Expect(database.MockDismissDamage(c, reqBody)).Returns(dismissed)
+	event := db.NewDamageEvent(damage.ID, db.DamageFieldDismissed, nil, &reqBody.Reason)
+	event.ActorRole = db.RoleOwner
+	mock.DamageEvent.Expect(database.MockCreateDamageEvent(c, event)).Returns(event)
+
+	b, err := json.Marshal(reqBody)
+	require.NoError(t, err)
+
+	r := router.TestRoutes()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPut, "/v1/owner/properties/1/leases/1/damages/1/dismiss/", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Oauth.claims.id", "1")
+	req.Header.Set("Oauth.claims.role", string(db.RoleOwner))
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestDismissDamage_BadDismissal(t *testing.T) {
+	c, mock, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	property := BuildTestProperty("1")
+	lease := BuildTestLease("1")
+	mock.Property.Expect(database.MockGetPropertyByID(c)).Returns(property)
+	mock.Lease.Expect(database.MockGetLeaseByID(c)).Returns(lease)
+	mock.Damage.Expect(database.MockGetDamageByID(c)).Returns(BuildTestDamage("1"))
+
+	b, err := json.Marshal(models.DamageDismissRequest{Dismissal: "invalid", Reason: "Not a damage"})
+	require.NoError(t, err)
+
+	r := router.TestRoutes()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPut, "/v1/owner/properties/1/leases/1/damages/1/dismiss/", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Oauth.claims.id", "1")
+	req.Header.Set("Oauth.claims.role", string(db.RoleOwner))
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestUpdateDamageTenant_Closed(t *testing.T) {
+	c, mock, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	lease := BuildTestLease("1")
+	damage := BuildTestDamage("1")
+	damage.DismissedAt = utils.Ptr(time.Now())
+	mock.Lease.Expect(database.MockGetLeaseByID(c)).Returns(lease)
+	mock.Damage.Expect(database.MockGetDamageByID(c)).Returns(damage)
+
+	b, err := json.Marshal(models.DamageTenantUpdateRequest{Comment: utils.Ptr("Still broken")})
+	require.NoError(t, err)
+
+	r := router.TestRoutes()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPut, "/v1/tenant/leases/1/damages/1/", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Oauth.claims.id", "1")
+	req.Header.Set("Oauth.claims.role", string(db.RoleTenant))
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	var resp utils.Error
+	err = json.Unmarshal(w.Body.Bytes(), &resp)
+	require.NoError(t, err)
+	assert.Equal(t, utils.DamageClosed, resp.Code)
+}
@@ -66,7 +66,7 @@ func getReminders_Damage(lang string, now time.Time, property db.PropertyModel,
 	var res []models.Reminder
 
 	for _, damage := range damages {
-		if damage.FixedOwner {
+		if damage.FixedOwner || damage.IsClosed() {
 			continue
 		}
 
@@ -85,6 +85,33 @@ func getReminders_Damage(lang string, now time.Time, property db.PropertyModel,
 	return res
 }
 
+// getReminders_Insurance checks the home insurance certificates of the tenant. A certificate without expiry date is always valid.
+func getReminders_Insurance(lang string, now time.Time, property db.PropertyModel, documents []db.DocumentModel) []models.Reminder {
+	var expiresAt time.Time
+	for _, doc := range documents {
+		if doc.Category != db.DocCategoryInsurance {
+			continue
+		}
+		docExpiresAt, ok := doc.ExpiresAt()
+		if !ok {
+			return nil
+		}
+		if docExpiresAt.After(expiresAt) {
+			expiresAt = docExpiresAt
+		}
+	}
+
+	// reminder 15
+	if expiresAt.Before(now) {
+		return []models.Reminder{models.GetReminderInsuranceMissing(lang, property)}
+	}
+	// reminder 14
+	if expiresAt.Before(now.AddDate(0, 0, 30)) {
+		return []models.Reminder{models.GetReminderInsuranceExpiring(lang, property, int(expiresAt.Sub(now).Hours())/24)}
+	}
+	return nil
+}
+
 func getReminders_Lease(lang string, now time.Time, property db.PropertyModel, currentLease db.LeaseModel) []models.Reminder {
 	var res []models.Reminder
 
@@ -99,6 +126,7 @@ func getReminders_Lease(lang string, now time.Time, property db.PropertyModel, c
 		res = append(res, models.GetReminderNoInventoryReport(lang, property))
 	}
 
+	res = append(res, getReminders_Insurance(lang, now, property, currentLease.Documents())...)
 	res = append(res, getReminders_Damage(lang, now, property, currentLease.Damages())...)
 	return res
 }
@@ -129,7 +157,10 @@ func getReminders_Property(lang string, now time.Time, property db.PropertyModel
 	return res
 }
 
-func getReminders(lang string, properties []db.PropertyModel) []models.Reminder {
+// storageWarningPercent is the share of the storage quota from which the owner is warned.
+const storageWarningPercent = 90
+
+func getReminders(lang string, properties []db.PropertyModel, usage models.StorageUsageResponse) []models.Reminder {
 	var res []models.Reminder
 	now := time.Now()
 
@@ -137,6 +168,11 @@ func getReminders(lang string, properties []db.PropertyModel) []models.Reminder
 		res = append(res, getReminders_Property(lang, now, property)...)
 	}
 
+	// reminder 16
+	if usage.Used*100 >= usage.Quota*storageWarningPercent {
+		res = append(res, models.GetReminderStorageAlmostFull(lang, usage))
+	}
+
 	if len(res) == 0 {
 		res = append(res, models.GetReminderAllGood(lang))
 	}
@@ -165,6 +201,10 @@ func getPropertyAndDamageDashboard_Properties(pRes *models.DashboardProperties,
 }
 
 func getPropertyAndDamageDashboard_Damage(dRes *models.DashboardOpenDamages, damage db.DamageModel, lease db.LeaseModel, property db.PropertyModel, now time.Time) {
+	// withdrawn and dismissed damages are neither counted nor listed
+	if damage.IsClosed() {
+		return
+	}
 	if !damage.IsFixed() {
 		dRes.NbrTotal++
 		switch damage.Priority {
@@ -232,7 +272,7 @@ func GetOwnerDashboard(c *gin.Context) {
 	pRes, dRes := getPropertyAndDamageDashboard(properties)
 
 	c.JSON(http.StatusOK, models.DashboardResponse{
-		Reminders:   getReminders(lang, properties),
+		Reminders:   getReminders(lang, properties, getStorageUsage(claims["id"])),
 		Properties:  pRes,
 		OpenDamages: dRes,
 	})
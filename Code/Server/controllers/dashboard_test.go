@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"slices"
 	"testing"
 	"time"
 
@@ -14,6 +15,7 @@ import (
 	"keyz/backend/router"
 	"keyz/backend/services"
 	"keyz/backend/services/database"
+	"keyz/backend/services/storage"
 	"keyz/backend/utils"
 )
 
@@ -96,6 +98,7 @@ func TestGetOwnerDashboard_Success(t *testing.T) {
 	defer ensure(t)
 
 	property := BuildTestDashboard("1")
+	ExpectStorageUsage(c, m, 0, 0)
 	m.Property.Expect(database.MockGetAllDatasFromProperties(c)).ReturnsMany([]db.PropertyModel{property})
 
 	r := router.TestRoutes()
@@ -114,10 +117,91 @@ func TestGetOwnerDashboard_Success(t *testing.T) {
 	assert.NotNil(t, resp.OpenDamages)
 }
 
+func TestGetOwnerDashboard_DismissedDamage(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	property := BuildTestDashboard("1")
+	damage := &property.RelationsProperty.Leases[0].RelationsLease.Damages[0]
+	damage.DismissedAt = utils.Ptr(time.Now())
+	damage.Dismissal = utils.Ptr(db.DismissalNotADamage)
+	ExpectStorageUsage(c, m, 0, 0)
+	m.Property.Expect(database.MockGetAllDatasFromProperties(c)).ReturnsMany([]db.PropertyModel{property})
+
+	r := router.TestRoutes()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/v1/owner/dashboard/", nil)
+	req.Header.Set("Oauth.claims.id", "1")
+	req.Header.Set("Oauth.claims.role", string(db.RoleOwner))
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp models.DashboardResponse
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	require.NoError(t, err)
+	assert.Equal(t, 0, resp.OpenDamages.NbrTotal)
+	assert.Equal(t, 0, resp.OpenDamages.NbrHigh)
+	assert.Empty(t, resp.OpenDamages.ListToFix)
+}
+
+func TestGetOwnerDashboard_InsuranceMissing(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	property := BuildTestDashboard("1")
+	ExpectStorageUsage(c, m, 0, 0)
+	m.Property.Expect(database.MockGetAllDatasFromProperties(c)).ReturnsMany([]db.PropertyModel{property})
+
+	r := router.TestRoutes()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/v1/owner/dashboard/", nil)
+	req.Header.Set("Oauth.claims.id", "1")
+	req.Header.Set("Oauth.claims.role", string(db.RoleOwner))
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp models.DashboardResponse
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	require.NoError(t, err)
+	assert.True(t, slices.ContainsFunc(resp.Reminders, func(r models.Reminder) bool { return r.Id == "15" }))
+}
+
+func TestGetOwnerDashboard_InsuranceExpiring(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	property := BuildTestDashboard("1")
+	lease := &property.RelationsProperty.Leases[0]
+	lease.RelationsLease.Documents = []db.DocumentModel{{
+		InnerDocument: db.InnerDocument{
+			ID:        "1",
+			Category:  db.DocCategoryInsurance,
+			ExpiresAt: utils.Ptr(time.Now().AddDate(0, 0, 10)),
+		},
+	}}
+	ExpectStorageUsage(c, m, 0, 0)
+	m.Property.Expect(database.MockGetAllDatasFromProperties(c)).ReturnsMany([]db.PropertyModel{property})
+
+	r := router.TestRoutes()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/v1/owner/dashboard/", nil)
+	req.Header.Set("Oauth.claims.id", "1")
+	req.Header.Set("Oauth.claims.role", string(db.RoleOwner))
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp models.DashboardResponse
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	require.NoError(t, err)
+	assert.True(t, slices.ContainsFunc(resp.Reminders, func(r models.Reminder) bool { return r.Id == "14" }))
+	assert.False(t, slices.ContainsFunc(resp.Reminders, func(r models.Reminder) bool { return r.Id == "15" }))
+}
+
 func TestGetOwnerDashboard_EmptyProperties(t *testing.T) {
 	c, m, ensure := services.ConnectDBTest()
 	defer ensure(t)
 
+	ExpectStorageUsage(c, m, 0, 0)
 	m.Property.Expect(database.MockGetAllDatasFromProperties(c)).ReturnsMany([]db.PropertyModel{})
 
 	r := router.TestRoutes()
@@ -135,3 +219,25 @@ func TestGetOwnerDashboard_EmptyProperties(t *testing.T) {
 	assert.NotNil(t, resp.Properties)
 	assert.NotNil(t, resp.OpenDamages)
 }
+
+func TestGetOwnerDashboard_StorageAlmostFull(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	ExpectStorageUsage(c, m, storage.OwnerQuota-10, 0)
+	m.Property.Expect(database.MockGetAllDatasFromProperties(c)).ReturnsMany([]db.PropertyModel{})
+
+	r := router.TestRoutes()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/v1/owner/dashboard/", nil)
+	req.Header.Set("Oauth.claims.id", "1")
+	req.Header.Set("Oauth.claims.role", string(db.RoleOwner))
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp models.DashboardResponse
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	require.NoError(t, err)
+	assert.True(t, slices.ContainsFunc(resp.Reminders, func(r models.Reminder) bool { return r.Id == "16" }))
+	assert.False(t, slices.ContainsFunc(resp.Reminders, func(r models.Reminder) bool { return r.Id == "13" }))
+}
@@ -0,0 +1,176 @@
+package controllers
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"keyz/backend/models"
+	"keyz/backend/prisma/db"
+	"keyz/backend/services/brevo"
+	"keyz/backend/services/database"
+	"keyz/backend/utils"
+)
+
+// SubmitDepartureNotice godoc
+//
+//	@Summary		Submit departure notice
+//	@Description	Submit (or resubmit) a notice of departure for a lease. The end date is computed from the legal notice period.
+//	@Tags			lease
+//	@Accept			json
+//	@Produce		json
+//	@Param			lease_id	path		string							true	"Lease ID or `current`"
+//	@Param			notice		body		models.DepartureNoticeRequest	true	"Notice of departure"
+//	@Success		201			{object}	models.DepartureNoticeResponse	"Departure notice"
+//	@Failure		400			{object}	utils.Error						"Missing fields or lease already ended"
+//	@Failure		404			{object}	utils.Error						"No active lease"
+//	@Failure		409			{object}	utils.Error						"Departure notice already accepted"
+//	@Failure		500
+//	@Security		Bearer
+//	@Router			/tenant/leases/{lease_id}/notice/ [post]
+func SubmitDepartureNotice(c *gin.Context) {
+	var req models.DepartureNoticeRequest
+	err := c.ShouldBindBodyWithJSON(&req)
+	if err != nil {
+		utils.SendError(c, http.StatusBadRequest, utils.MissingFields, err)
+		return
+	}
+
+	lease, _ := c.MustGet("lease").(db.LeaseModel)
+	if lease.Status == db.LeaseStatusEnded {
+		utils.SendError(c, http.StatusBadRequest, utils.LeaseAlreadyEnded, nil)
+		return
+	}
+	existing := database.GetDepartureNoticeByLease(lease.ID)
+	if existing != nil && existing.Status == db.NoticeStatusAccepted {
+		utils.SendError(c, http.StatusConflict, utils.DepartureNoticeAccepted, nil)
+		return
+	}
+
+	notice := database.UpsertDepartureNotice(req.ToDbDepartureNotice(time.Now(), lease.Furnished), lease.ID)
+
+	res, err := brevo.SendDepartureNotice(lease, notice)
+	if err != nil {
+		log.Println(res, err.Error())
+	}
+
+	c.JSON(http.StatusCreated, models.DbDepartureNoticeToResponse(notice))
+}
+
+// GetDepartureNotice godoc
+//
+//	@Summary		Get departure notice
+//	@Description	Get the notice of departure of a lease
+//	@Tags			lease
+//	@Accept			json
+//	@Produce		json
+//	@Param			property_id	path		string							true	"Property ID"
+//	@Param			lease_id	path		string							true	"Lease ID or `current`"
+//	@Success		200			{object}	models.DepartureNoticeResponse	"Departure notice"
+//	@Failure		404			{object}	utils.Error						"No departure notice"
+//	@Failure		500
+//	@Security		Bearer
+//	@Router			/owner/properties/{property_id}/leases/{lease_id}/notice/ [get]
+//	@Router			/tenant/leases/{lease_id}/notice/ [get]
+func GetDepartureNotice(c *gin.Context) {
+	lease, _ := c.MustGet("lease").(db.LeaseModel)
+	notice := database.GetDepartureNoticeByLease(lease.ID)
+	if notice == nil {
+		utils.SendError(c, http.StatusNotFound, utils.DepartureNoticeNotFound, nil)
+		return
+	}
+	c.JSON(http.StatusOK, models.DbDepartureNoticeToResponse(*notice))
+}
+
+// AcceptDepartureNotice godoc
+//
+//	@Summary		Accept departure notice
+//	@Description	Accept the notice of departure of a lease, its end date becomes the lease end date
+//	@Tags			lease
+//	@Accept			json
+//	@Produce		json
+//	@Param			property_id	path		string							true	"Property ID"
+//	@Param			lease_id	path		string							true	"Lease ID or `current`"
+//	@Success		200			{object}	models.DepartureNoticeResponse	"Accepted departure notice"
+//	@Failure		404			{object}	utils.Error						"No departure notice"
+//	@Failure		409			{object}	utils.Error						"Departure notice already accepted"
+//	@Failure		500
+//	@Security		Bearer
+//	@Router			/owner/properties/{property_id}/leases/{lease_id}/notice/accept/ [put]
+func AcceptDepartureNotice(c *gin.Context) {
+	lease, _ := c.MustGet("lease").(db.LeaseModel)
+	notice := database.GetDepartureNoticeByLease(lease.ID)
+	if notice == nil {
+		utils.SendError(c, http.StatusNotFound, utils.DepartureNoticeNotFound, nil)
+		return
+	}
+	if notice.Status == db.NoticeStatusAccepted {
+		utils.SendError(c, http.StatusConflict, utils.DepartureNoticeAccepted, nil)
+		return
+	}
+
+	updated := database.UpdateDepartureNoticeStatus(notice.ID, db.NoticeStatusAccepted, nil)
+	if updated == nil {
+		utils.SendError(c, http.StatusNotFound, utils.DepartureNoticeNotFound, nil)
+		return
+	}
+	database.UpdateLeaseEndDate(lease.ID, updated.EndDate)
+
+	res, err := brevo.SendDepartureNoticeAnswer(lease, *updated)
+	if err != nil {
+		log.Println(res, err.Error())
+	}
+
+	c.JSON(http.StatusOK, models.DbDepartureNoticeToResponse(*updated))
+}
+
+// DiscussDepartureNotice godoc
+//
+//	@Summary		Discuss departure notice
+//	@Description	Answer the notice of departure of a lease with a comment instead of accepting it
+//	@Tags			lease
+//	@Accept			json
+//	@Produce		json
+//	@Param			property_id	path		string									true	"Property ID"
+//	@Param			lease_id	path		string									true	"Lease ID or `current`"
+//	@Param			comment		body		models.DepartureNoticeDiscussRequest	true	"Owner comment"
+//	@Success		200			{object}	models.DepartureNoticeResponse			"Departure notice"
+//	@Failure		400			{object}	utils.Error								"Missing fields"
+//	@Failure		404			{object}	utils.Error								"No departure notice"
+//	@Failure		409			{object}	utils.Error								"Departure notice already accepted"
+//	@Failure		500
+//	@Security		Bearer
+//	@Router			/owner/properties/{property_id}/leases/{lease_id}/notice/discuss/ [put]
+func DiscussDepartureNotice(c *gin.Context) {
+	var req models.DepartureNoticeDiscussRequest
+	err := c.ShouldBindBodyWithJSON(&req)
+	if err != nil {
+		utils.SendError(c, http.StatusBadRequest, utils.MissingFields, err)
+		return
+	}
+
+	lease, _ := c.MustGet("lease").(db.LeaseModel)
+	notice := database.GetDepartureNoticeByLease(lease.ID)
+	if notice == nil {
+		utils.SendError(c, http.StatusNotFound, utils.DepartureNoticeNotFound, nil)
+		return
+	}
+	if notice.Status == db.NoticeStatusAccepted {
+		utils.SendError(c, http.StatusConflict, utils.DepartureNoticeAccepted, nil)
+		return
+	}
+
+	updated := database.UpdateDepartureNoticeStatus(notice.ID, db.NoticeStatusDiscussing, &req.Comment)
+	if updated == nil {
+		utils.SendError(c, http.StatusNotFound, utils.DepartureNoticeNotFound, nil)
+		return
+	}
+
+	res, err := brevo.SendDepartureNoticeAnswer(lease, *updated)
+	if err != nil {
+		log.Println(res, err.Error())
+	}
+
+	c.JSON(http.StatusOK, models.DbDepartureNoticeToResponse(*updated))
+}
@@ -0,0 +1,208 @@
+package controllers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"keyz/backend/models"
+	"keyz/backend/prisma/db"
+	"keyz/backend/router"
+	"keyz/backend/services"
+	"keyz/backend/services/database"
+	"keyz/backend/utils"
+)
+
+func BuildTestDepartureNotice(id string, status db.NoticeStatus) db.DepartureNoticeModel {
+	return db.DepartureNoticeModel{
+		InnerDepartureNotice: db.InnerDepartureNotice{
+			ID:            id,
+			LeaseID:       "1",
+			RequestedDate: time.Now().AddDate(0, 6, 0),
+			EndDate:       time.Now().AddDate(0, 6, 0),
+			Reason:        db.DepartureReasonStandard,
+			Status:        status,
+			CreatedAt:     time.Now(),
+			UpdatedAt:     time.Now(),
+		},
+	}
+}
+
+func TestSubmitDepartureNotice(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	lease := BuildTestLease("1")
+	notice := BuildTestDepartureNotice("1", db.NoticeStatusPending)
+	m.Lease.Expect(database.MockGetLeaseByID(c)).Returns(lease)
+	m.DepartureNotice.Expect(database.MockGetDepartureNoticeByLease(c)).Errors(db.ErrNotFound)
+	m.DepartureNotice.Expect(database.MockUpsertDepartureNotice(c, notice)).Returns(notice)
+
+	reqBody := models.DepartureNoticeRequest{
+		RequestedDate: notice.RequestedDate,
+		Reason:        notice.Reason,
+	}
+	b, err := json.Marshal(reqBody)
+	require.NoError(t, err)
+
+	r := router.TestRoutes()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/v1/tenant/leases/1/notice/", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Oauth.claims.id", "1")
+	req.Header.Set("Oauth.claims.role", string(db.RoleTenant))
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusCreated, w.Code)
+	var resp models.DepartureNoticeResponse
+	err = json.Unmarshal(w.Body.Bytes(), &resp)
+	require.NoError(t, err)
+	assert.Equal(t, notice.ID, resp.ID)
+	assert.Equal(t, db.NoticeStatusPending, resp.Status)
+}
+
+func TestSubmitDepartureNotice_AlreadyAccepted(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	lease := BuildTestLease("1")
+	notice := BuildTestDepartureNotice("1", db.NoticeStatusAccepted)
+	m.Lease.Expect(database.MockGetLeaseByID(c)).Returns(lease)
+	m.DepartureNotice.Expect(database.MockGetDepartureNoticeByLease(c)).Returns(notice)
+
+	reqBody := models.DepartureNoticeRequest{
+		RequestedDate: notice.RequestedDate,
+		Reason:        notice.Reason,
+	}
+	b, err := json.Marshal(reqBody)
+	require.NoError(t, err)
+
+	r := router.TestRoutes()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/v1/tenant/leases/1/notice/", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Oauth.claims.id", "1")
+	req.Header.Set("Oauth.claims.role", string(db.RoleTenant))
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusConflict, w.Code)
+	var resp utils.Error
+	err = json.Unmarshal(w.Body.Bytes(), &resp)
+	require.NoError(t, err)
+	assert.Equal(t, utils.DepartureNoticeAccepted, resp.Code)
+}
+
+func TestSubmitDepartureNotice_MissingFields(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	lease := BuildTestLease("1")
+	m.Lease.Expect(database.MockGetLeaseByID(c)).Returns(lease)
+
+	r := router.TestRoutes()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/v1/tenant/leases/1/notice/", bytes.NewReader([]byte(`{}`)))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Oauth.claims.id", "1")
+	req.Header.Set("Oauth.claims.role", string(db.RoleTenant))
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	var resp utils.Error
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	require.NoError(t, err)
+	assert.Equal(t, utils.MissingFields, resp.Code)
+}
+
+func TestGetDepartureNotice_NotFound(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	property := BuildTestProperty("1")
+	lease := BuildTestLease("1")
+	m.Property.Expect(database.MockGetPropertyByID(c)).Returns(property)
+	m.Lease.Expect(database.MockGetLeaseByID(c)).Returns(lease)
+	m.DepartureNotice.Expect(database.MockGetDepartureNoticeByLease(c)).Errors(db.ErrNotFound)
+
+	r := router.TestRoutes()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/v1/owner/properties/1/leases/1/notice/", nil)
+	req.Header.Set("Oauth.claims.id", "1")
+	req.Header.Set("Oauth.claims.role", string(db.RoleOwner))
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusNotFound, w.Code)
+	var resp utils.Error
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	require.NoError(t, err)
+	assert.Equal(t, utils.DepartureNoticeNotFound, resp.Code)
+}
+
+func TestAcceptDepartureNotice(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	property := BuildTestProperty("1")
+	lease := BuildTestLease("1")
+	notice := BuildTestDepartureNotice("1", db.NoticeStatusPending)
+	accepted := notice
+	accepted.Status = db.NoticeStatusAccepted
+	m.Property.Expect(database.MockGetPropertyByID(c)).Returns(property)
+	m.Lease.Expect(database.MockGetLeaseByID(c)).Returns(lease)
+	m.DepartureNotice.Expect(database.MockGetDepartureNoticeByLease(c)).Returns(notice)
+	m.DepartureNotice.Expect(database.MockUpdateDepartureNoticeStatus(c, db.NoticeStatusAccepted, nil)).Returns(accepted)
+	m.Lease.Expect(database.MockUpdateLeaseEndDate(c, accepted.EndDate)).Returns(lease)
+
+	r := router.TestRoutes()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPut, "/v1/owner/properties/1/leases/1/notice/accept/", nil)
+	req.Header.Set("Oauth.claims.id", "1")
+	req.Header.Set("Oauth.claims.role", string(db.RoleOwner))
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp models.DepartureNoticeResponse
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	require.NoError(t, err)
+	assert.Equal(t, db.NoticeStatusAccepted, resp.Status)
+}
+
+func TestDiscussDepartureNotice(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	property := BuildTestProperty("1")
+	lease := BuildTestLease("1")
+	notice := BuildTestDepartureNotice("1", db.NoticeStatusPending)
+	discussing := notice
+	discussing.Status = db.NoticeStatusDiscussing
+	discussing.OwnerComment = utils.Ptr("Could you stay one more month?")
+	m.Property.Expect(database.MockGetPropertyByID(c)).Returns(property)
+	m.Lease.Expect(database.MockGetLeaseByID(c)).Returns(lease)
+	m.DepartureNotice.Expect(database.MockGetDepartureNoticeByLease(c)).Returns(notice)
+	m.DepartureNotice.Expect(database.MockUpdateDepartureNoticeStatus(c, db.NoticeStatusDiscussing, discussing.OwnerComment)).Returns(discussing)
+
+	reqBody := models.DepartureNoticeDiscussRequest{Comment: *discussing.OwnerComment}
+	b, err := json.Marshal(reqBody)
+	require.NoError(t, err)
+
+	r := router.TestRoutes()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPut, "/v1/owner/properties/1/leases/1/notice/discuss/", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Oauth.claims.id", "1")
+	req.Header.Set("Oauth.claims.role", string(db.RoleOwner))
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp models.DepartureNoticeResponse
+	err = json.Unmarshal(w.Body.Bytes(), &resp)
+	require.NoError(t, err)
+	assert.Equal(t, db.NoticeStatusDiscussing, resp.Status)
+	assert.Equal(t, discussing.OwnerComment, resp.OwnerComment)
+}
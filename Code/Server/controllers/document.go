@@ -1,43 +1,74 @@
 package controllers
 
 import (
+	"mime"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 	"keyz/backend/models"
 	"keyz/backend/prisma/db"
 	"keyz/backend/services/database"
+	"keyz/backend/services/storage"
 	"keyz/backend/utils"
 )
 
+// bindDocument binds a document sent either as a multipart form or as JSON, depending on the request Content-Type,
+// and checks that it fits in the size limit and in the storage quota of the owner of the lease.
+func bindDocument(c *gin.Context) (*db.DocumentModel, bool) {
+	var doc *db.DocumentModel
+	if c.ContentType() == gin.MIMEMultipartPOSTForm {
+		var form models.DocumentUploadForm
+		if err := c.ShouldBind(&form); err != nil {
+			utils.SendError(c, http.StatusBadRequest, utils.MissingFields, err)
+			return nil, false
+		}
+		doc = form.ToDbDocument()
+	} else {
+		var req models.DocumentRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			utils.SendError(c, http.StatusBadRequest, utils.MissingFields, err)
+			return nil, false
+		}
+		doc = req.ToDbDocument()
+	}
+	if doc == nil {
+		utils.SendError(c, http.StatusBadRequest, utils.BadBase64OrUnsupportedType, nil)
+		return nil, false
+	}
+	lease, _ := c.MustGet("lease").(db.LeaseModel)
+	if !checkFileSize(c, *doc.InnerDocument.Data, storage.MaxDocumentSize) || !checkStorageQuota(c, lease.Property().OwnerID, len(*doc.InnerDocument.Data)) {
+		return nil, false
+	}
+	return doc, true
+}
+
 // UploadDocument godoc
 //
 //	@Summary		Upload document
-//	@Description	Upload a document to a lease
+//	@Description	Upload a document to a lease, either as a base64 data URI in JSON or as a binary file in a multipart form
 //	@Tags			document
-//	@Accept			json
+//	@Accept			json,mpfd
 //	@Produce		json
 //	@Param			property_id	path		string					true	"Property ID"
 //	@Param			lease_id	path		string					true	"Lease ID or `current`"
-//	@Param			doc			body		models.DocumentRequest	true	"Document to upload"
+//	@Param			doc			body		models.DocumentRequest	false	"Document to upload (JSON)"
+//	@Param			file		formData	file					false	"Document file (multipart)"
+//	@Param			name		formData	string					false	"Document name, defaults to the file name (multipart)"
+//	@Param			category	formData	string					false	"Document category, defaults to other (multipart)"	Enums(lease, insurance, diagnostics, payslip, receipt, inventoryReport, other)
+//	@Param			tags		formData	[]string				false	"Document tags (multipart)"							collectionFormat(multi)
+//	@Param			expires_at	formData	string					false	"Expiry date of the document (RFC 3339, multipart)"
 //	@Success		201			{object}	models.IdResponse		"Created document ID"
 //	@Failure		400			{object}	utils.Error				"Missing fields"
-//	@Failure		403			{object}	utils.Error				"Property not yours"
+//	@Failure		403			{object}	utils.Error				"Property not yours or storage quota exceeded"
 //	@Failure		404			{object}	utils.Error				"No active lease"
+//	@Failure		413			{object}	utils.Error				"File too large"
 //	@Failure		500
 //	@Security		Bearer
 //	@Router			/owner/properties/{property_id}/leases/{lease_id}/docs/ [post]
 //	@Router			/tenant/leases/{lease_id}/docs/ [post]
 func UploadDocument(c *gin.Context) {
-	var req models.DocumentRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.SendError(c, http.StatusBadRequest, utils.MissingFields, err)
-		return
-	}
-
-	doc := req.ToDbDocument()
-	if doc == nil {
-		utils.SendError(c, http.StatusBadRequest, utils.BadBase64OrUnsupportedType, nil)
+	doc, ok := bindDocument(c)
+	if !ok {
 		return
 	}
 
@@ -49,13 +80,16 @@ func UploadDocument(c *gin.Context) {
 // GetAllDocumentsByLease godoc
 //
 //	@Summary		Get property documents
-//	@Description	Get all documents of a lease related to a property
+//	@Description	Get the latest version of all documents of a lease related to a property, optionally filtered by category and tag
 //	@Tags			document
 //	@Accept			json
 //	@Produce		json
 //	@Param			property_id	path		string					true	"Property ID"
 //	@Param			lease_id	path		string					true	"Lease ID or `current`"
+//	@Param			category	query		[]string				false	"Filter by categories"	collectionFormat(multi)
+//	@Param			tag			query		string					false	"Filter by tag"
 //	@Success		200			{array}		models.DocumentResponse	"List of documents"
+//	@Failure		400			{object}	utils.Error				"Invalid query"
 //	@Failure		403			{object}	utils.Error				"Property not yours"
 //	@Failure		404			{object}	utils.Error				"No active lease"
 //	@Failure		500
@@ -63,8 +97,14 @@ func UploadDocument(c *gin.Context) {
 //	@Router			/owner/properties/{property_id}/leases/{lease_id}/docs/ [get]
 //	@Router			/tenant/leases/{lease_id}/docs/ [get]
 func GetAllDocumentsByLease(c *gin.Context) {
+	var query models.DocumentListQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		utils.SendError(c, http.StatusBadRequest, utils.MissingFields, err)
+		return
+	}
+
 	lease, _ := c.MustGet("lease").(db.LeaseModel)
-	documents := database.GetDocumentsByLease(lease.ID)
+	documents := database.GetDocumentsByLease(lease.ID, query)
 	c.JSON(http.StatusOK, utils.Map(documents, models.DbDocumentToResponse))
 }
 
@@ -90,16 +130,152 @@ func GetDocument(c *gin.Context) {
 	c.JSON(http.StatusOK, models.DbDocumentToResponse(doc))
 }
 
+// UpdateDocumentInfo godoc
+//
+//	@Summary		Update document classification
+//	@Description	Update the category, the tags and the expiry date of a document
+//	@Tags			document
+//	@Accept			json
+//	@Produce		json
+//	@Param			property_id	path		string					true	"Property ID"
+//	@Param			lease_id	path		string					true	"Lease ID or `current`"
+//	@Param			doc_id		path		string					true	"Document ID"
+//	@Param			info		body		models.DocumentInfo		true	"Document classification"
+//	@Success		200			{object}	models.DocumentResponse	"Updated document"
+//	@Failure		400			{object}	utils.Error				"Missing fields"
+//	@Failure		403			{object}	utils.Error				"Property not yours"
+//	@Failure		404			{object}	utils.Error				"Document not found"
+//	@Failure		500
+//	@Security		Bearer
+//	@Router			/owner/properties/{property_id}/leases/{lease_id}/docs/{doc_id}/ [put]
+func UpdateDocumentInfo(c *gin.Context) {
+	var req models.DocumentInfo
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.SendError(c, http.StatusBadRequest, utils.MissingFields, err)
+		return
+	}
+
+	doc, _ := c.MustGet("document").(db.DocumentModel)
+	res := database.UpdateDocumentInfo(doc.ID, req.ToDbDocument())
+	c.JSON(http.StatusOK, models.DbDocumentToResponse(res))
+}
+
+// DownloadDocument godoc
+//
+//	@Summary		Download document
+//	@Description	Download the raw content of a document as a file
+//	@Tags			document
+//	@Accept			json
+//	@Produce		application/pdf,application/vnd.openxmlformats-officedocument.wordprocessingml.document,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet,application/vnd.oasis.opendocument.text,application/vnd.oasis.opendocument.spreadsheet,text/plain,text/csv,image/png,image/jpeg
+//	@Param			property_id	path		string		true	"Property ID"
+//	@Param			lease_id	path		string		true	"Lease ID or `current`"
+//	@Param			doc_id		path		string		true	"Document ID"
+//	@Success		200			{file}		file		"Document content"
+//	@Failure		403			{object}	utils.Error	"Property not yours"
+//	@Failure		404			{object}	utils.Error	"Document or file not found"
+//	@Failure		500			{object}	utils.Error	"Failed to load file"
+//	@Security		Bearer
+//	@Router			/owner/properties/{property_id}/leases/{lease_id}/docs/{doc_id}/download/ [get]
+//	@Router			/tenant/leases/{lease_id}/docs/{doc_id}/download/ [get]
+func DownloadDocument(c *gin.Context) {
+	doc, _ := c.MustGet("document").(db.DocumentModel)
+	disposition := mime.FormatMediaType("attachment", map[string]string{"filename": models.DocumentFileName(doc)})
+	if disposition == "" {
+		disposition = "attachment"
+	}
+	reader, size, err := storage.Open(doc.InnerDocument.Data, doc.InnerDocument.StorageKey)
+	if err != nil {
+		sendLoadError(c, utils.FailedLoadFile, err)
+		return
+	}
+	defer reader.Close()
+	c.DataFromReader(http.StatusOK, size, models.DocumentMimeTypes[doc.Type], reader, map[string]string{
+		"Content-Disposition": disposition,
+	})
+}
+
+// UploadDocumentVersion godoc
+//
+//	@Summary		Upload document version
+//	@Description	Upload a new version of a document, in the same way as a new document. The previous versions stay retrievable.
+//	@Description	The new version keeps the category and the tags of the document, and its expiry date unless a new one is given.
+//	@Tags			document
+//	@Accept			json,mpfd
+//	@Produce		json
+//	@Param			property_id	path		string					true	"Property ID"
+//	@Param			lease_id	path		string					true	"Lease ID or `current`"
+//	@Param			doc_id		path		string					true	"ID of the latest version of the document"
+//	@Param			doc			body		models.DocumentRequest	false	"Document to upload (JSON)"
+//	@Param			file		formData	file					false	"Document file (multipart)"
+//	@Param			name		formData	string					false	"Document name, defaults to the file name (multipart)"
+//	@Param			expires_at	formData	string					false	"Expiry date of the document (RFC 3339, multipart)"
+//	@Success		201			{object}	models.IdResponse		"Created version ID"
+//	@Failure		400			{object}	utils.Error				"Missing fields"
+//	@Failure		403			{object}	utils.Error				"Property not yours or storage quota exceeded"
+//	@Failure		404			{object}	utils.Error				"Document not found"
+//	@Failure		409			{object}	utils.Error				"Not the latest version"
+//	@Failure		413			{object}	utils.Error				"File too large"
+//	@Failure		500
+//	@Security		Bearer
+//	@Router			/owner/properties/{property_id}/leases/{lease_id}/docs/{doc_id}/versions/ [post]
+//	@Router			/tenant/leases/{lease_id}/docs/{doc_id}/versions/ [post]
+func UploadDocumentVersion(c *gin.Context) {
+	previous, _ := c.MustGet("document").(db.DocumentModel)
+	if !previous.Latest {
+		utils.SendError(c, http.StatusConflict, utils.DocumentNotLatestVersion, nil)
+		return
+	}
+	doc, ok := bindDocument(c)
+	if !ok {
+		return
+	}
+
+	doc.Category = previous.Category
+	doc.Tags = previous.Tags
+	if doc.InnerDocument.ExpiresAt == nil {
+		doc.InnerDocument.ExpiresAt = previous.InnerDocument.ExpiresAt
+	}
+	res := database.CreateDocumentVersion(*doc, previous)
+	if res == nil {
+		utils.SendError(c, http.StatusConflict, utils.DocumentNotLatestVersion, nil)
+		return
+	}
+	c.JSON(http.StatusCreated, models.IdResponse{ID: res.ID})
+}
+
+// GetDocumentVersions godoc
+//
+//	@Summary		Get document versions
+//	@Description	Get all the versions of a document, the latest first
+//	@Tags			document
+//	@Accept			json
+//	@Produce		json
+//	@Param			property_id	path		string					true	"Property ID"
+//	@Param			lease_id	path		string					true	"Lease ID or `current`"
+//	@Param			doc_id		path		string					true	"ID of any version of the document"
+//	@Success		200			{array}		models.DocumentResponse	"List of versions"
+//	@Failure		403			{object}	utils.Error				"Property not yours"
+//	@Failure		404			{object}	utils.Error				"Document not found"
+//	@Failure		500
+//	@Security		Bearer
+//	@Router			/owner/properties/{property_id}/leases/{lease_id}/docs/{doc_id}/versions/ [get]
+//	@Router			/tenant/leases/{lease_id}/docs/{doc_id}/versions/ [get]
+func GetDocumentVersions(c *gin.Context) {
+	doc, _ := c.MustGet("document").(db.DocumentModel)
+	versions := database.GetDocumentVersions(doc.FirstVersionID())
+	c.JSON(http.StatusOK, utils.Map(versions, models.DbDocumentToResponse))
+}
+
 // DeleteDocument godoc
 //
 //	@Summary		Delete document
-//	@Description	Delete a document by its ID
+//	@Description	Move a document with all its versions to the trash. It can be restored until it is permanently deleted, 30 days later.
 //	@Tags			document
 //	@Accept			json
 //	@Produce		json
 //	@Param			property_id	path	string	true	"Property ID"
 //	@Param			lease_id	path	string	true	"Lease ID or `current`"
-//	@Param			doc_id		path	string	true	"Document ID"
+//	@Param			doc_id		path	string	true	"ID of any version of the document"
 //	@Success		204			"Document deleted"
 //	@Failure		403			{object}	utils.Error	"Property not yours"
 //	@Failure		404			{object}	utils.Error	"Document not found"
@@ -108,6 +284,50 @@ func GetDocument(c *gin.Context) {
 //	@Router			/owner/properties/{property_id}/leases/{lease_id}/docs/{doc_id}/ [delete]
 func DeleteDocument(c *gin.Context) {
 	doc, _ := c.MustGet("document").(db.DocumentModel)
-	database.DeleteDocument(doc.ID)
+	database.DeleteDocument(doc.FirstVersionID(), utils.Now())
 	c.Status(http.StatusNoContent)
 }
+
+// GetDeletedDocuments godoc
+//
+//	@Summary		Get deleted documents
+//	@Description	Get the latest version of the documents of a lease in the trash, the most recently deleted first
+//	@Tags			document
+//	@Accept			json
+//	@Produce		json
+//	@Param			property_id	path		string					true	"Property ID"
+//	@Param			lease_id	path		string					true	"Lease ID or `current`"
+//	@Success		200			{array}		models.DocumentResponse	"List of deleted documents"
+//	@Failure		403			{object}	utils.Error				"Property not yours"
+//	@Failure		404			{object}	utils.Error				"No active lease"
+//	@Failure		500
+//	@Security		Bearer
+//	@Router			/owner/properties/{property_id}/leases/{lease_id}/docs/trash/ [get]
+func GetDeletedDocuments(c *gin.Context) {
+	lease, _ := c.MustGet("lease").(db.LeaseModel)
+	documents := database.GetDeletedDocumentsByLease(lease.ID)
+	c.JSON(http.StatusOK, utils.Map(documents, models.DbDocumentToResponse))
+}
+
+// RestoreDocument godoc
+//
+//	@Summary		Restore document
+//	@Description	Restore a document with all its versions from the trash
+//	@Tags			document
+//	@Accept			json
+//	@Produce		json
+//	@Param			property_id	path		string					true	"Property ID"
+//	@Param			lease_id	path		string					true	"Lease ID or `current`"
+//	@Param			doc_id		path		string					true	"ID of any version of the document"
+//	@Success		200			{object}	models.DocumentResponse	"Restored document"
+//	@Failure		403			{object}	utils.Error				"Property not yours"
+//	@Failure		404			{object}	utils.Error				"Document not found"
+//	@Failure		500
+//	@Security		Bearer
+//	@Router			/owner/properties/{property_id}/leases/{lease_id}/docs/trash/{doc_id}/restore/ [post]
+func RestoreDocument(c *gin.Context) {
+	doc, _ := c.MustGet("document").(db.DocumentModel)
+	database.RestoreDocument(doc.FirstVersionID())
+	doc.InnerDocument.DeletedAt = nil
+	c.JSON(http.StatusOK, models.DbDocumentToResponse(doc))
+}
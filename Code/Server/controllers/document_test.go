@@ -3,6 +3,7 @@ package controllers_test
 import (
 	"bytes"
 	"encoding/json"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -15,6 +16,7 @@ import (
 	"keyz/backend/router"
 	"keyz/backend/services"
 	"keyz/backend/services/database"
+	"keyz/backend/services/storage"
 	"keyz/backend/utils"
 )
 
@@ -23,8 +25,11 @@ func BuildTestDocument() db.DocumentModel {
 		InnerDocument: db.InnerDocument{
 			ID:        "1",
 			Name:      "Test Document",
-			Data:      []byte("Test Data"),
+			Data:      utils.Ptr([]byte("%PDF-1.4 Test Data")),
 			Type:      db.DocTypePdf,
+			Category:  db.DocCategoryOther,
+			Version:   1,
+			Latest:    true,
 			LeaseID:   "1",
 			CreatedAt: time.Now(),
 		},
@@ -40,7 +45,7 @@ func TestGetPropertyDocuments(t *testing.T) {
 	documents := []db.DocumentModel{BuildTestDocument()}
 	m.Property.Expect(database.MockGetPropertyByID(c)).Returns(property)
 	m.Lease.Expect(database.MockGetCurrentActiveLeaseByProperty(c)).ReturnsMany([]db.LeaseModel{activeLease})
-	m.Document.Expect(database.MockGetDocumentsByLease(c)).ReturnsMany(documents)
+	m.Document.Expect(database.MockGetDocumentsByLease(c, models.DocumentListQuery{})).ReturnsMany(documents)
 
 	r := router.TestRoutes()
 	w := httptest.NewRecorder()
@@ -56,6 +61,60 @@ func TestGetPropertyDocuments(t *testing.T) {
 	assert.Equal(t, documents[0].ID, resp[0].ID)
 }
 
+func TestGetPropertyDocuments_Filtered(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	property := BuildTestProperty("1")
+	activeLease := BuildTestLease("1")
+	document := BuildTestDocument()
+	document.Category = db.DocCategoryInsurance
+	query := models.DocumentListQuery{
+		Category: []db.DocCategory{db.DocCategoryInsurance, db.DocCategoryLease},
+		Tag:      utils.Ptr("2025"),
+	}
+	m.Property.Expect(database.MockGetPropertyByID(c)).Returns(property)
+	m.Lease.Expect(database.MockGetCurrentActiveLeaseByProperty(c)).ReturnsMany([]db.LeaseModel{activeLease})
+	m.Document.Expect(database.MockGetDocumentsByLease(c, query)).ReturnsMany([]db.DocumentModel{document})
+
+	r := router.TestRoutes()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/v1/owner/properties/1/leases/current/docs/?category=insurance&category=lease&tag=2025", nil)
+	req.Header.Set("Oauth.claims.id", "1")
+	req.Header.Set("Oauth.claims.role", string(db.RoleOwner))
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp []models.DocumentResponse
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	require.NoError(t, err)
+	require.Len(t, resp, 1)
+	assert.Equal(t, db.DocCategoryInsurance, resp[0].Category)
+}
+
+func TestGetPropertyDocuments_BadCategory(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	property := BuildTestProperty("1")
+	activeLease := BuildTestLease("1")
+	m.Property.Expect(database.MockGetPropertyByID(c)).Returns(property)
+	m.Lease.Expect(database.MockGetCurrentActiveLeaseByProperty(c)).ReturnsMany([]db.LeaseModel{activeLease})
+
+	r := router.TestRoutes()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/v1/owner/properties/1/leases/current/docs/?category=invalid", nil)
+	req.Header.Set("Oauth.claims.id", "1")
+	req.Header.Set("Oauth.claims.role", string(db.RoleOwner))
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	var errorResponse utils.Error
+	err := json.Unmarshal(w.Body.Bytes(), &errorResponse)
+	require.NoError(t, err)
+	assert.Equal(t, utils.MissingFields, errorResponse.Code)
+}
+
 func TestGetPropertyDocuments_NotYours(t *testing.T) {
 	c, m, ensure := services.ConnectDBTest()
 	defer ensure(t)
@@ -124,6 +183,63 @@ func TestGetDocumentByID(t *testing.T) {
 	assert.Equal(t, document.ID, resp.ID)
 }
 
+func TestDownloadDocument(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	property := BuildTestProperty("1")
+	activeLease := BuildTestLease("1")
+	document := BuildTestDocument()
+	m.Property.Expect(database.MockGetPropertyByID(c)).Returns(property)
+	m.Lease.Expect(database.MockGetCurrentActiveLeaseByProperty(c)).ReturnsMany([]db.LeaseModel{activeLease})
+	m.Document.Expect(database.MockGetDocumentByID(c)).Returns(document)
+
+	r := router.TestRoutes()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/v1/owner/properties/1/leases/current/docs/1/download/", nil)
+	req.Header.Set("Oauth.claims.id", "1")
+	req.Header.Set("Oauth.claims.role", string(db.RoleOwner))
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/pdf", w.Header().Get("Content-Type"))
+	assert.Equal(t, `attachment; filename="Test Document.pdf"`, w.Header().Get("Content-Disposition"))
+	assert.Equal(t, "9", w.Header().Get("Content-Length"))
+	assert.Equal(t, "%PDF-1.4 Test Data", w.Body.String())
+}
+
+func TestDownloadDocument_FileNotFound(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	local, err := storage.NewLocal(t.TempDir())
+	require.NoError(t, err)
+	storage.Client = local
+	defer func() { storage.Client = nil }()
+
+	property := BuildTestProperty("1")
+	activeLease := BuildTestLease("1")
+	document := BuildTestDocument()
+	document.InnerDocument.Data = nil
+	document.InnerDocument.StorageKey = utils.Ptr("documents/missing")
+	m.Property.Expect(database.MockGetPropertyByID(c)).Returns(property)
+	m.Lease.Expect(database.MockGetCurrentActiveLeaseByProperty(c)).ReturnsMany([]db.LeaseModel{activeLease})
+	m.Document.Expect(database.MockGetDocumentByID(c)).Returns(document)
+
+	r := router.TestRoutes()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/v1/owner/properties/1/leases/current/docs/1/download/", nil)
+	req.Header.Set("Oauth.claims.id", "1")
+	req.Header.Set("Oauth.claims.role", string(db.RoleOwner))
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusNotFound, w.Code)
+	var errorResponse utils.Error
+	err = json.Unmarshal(w.Body.Bytes(), &errorResponse)
+	require.NoError(t, err)
+	assert.Equal(t, utils.FileNotFound, errorResponse.Code)
+}
+
 func TestGetDocumentByID_NotYours(t *testing.T) {
 	c, m, ensure := services.ConnectDBTest()
 	defer ensure(t)
@@ -178,12 +294,13 @@ func TestUploadDocument(t *testing.T) {
 	document := BuildTestDocument()
 	m.Property.Expect(database.MockGetPropertyByID(c)).Returns(property)
 	m.Lease.Expect(database.MockGetCurrentActiveLeaseByProperty(c)).ReturnsMany([]db.LeaseModel{activeLease})
+	ExpectStorageUsage(c, m, 0, 0)
 	m.Document.Expect(database.MockCreateDocument(c, document)).Returns(BuildTestDocument())
 
 	r := router.TestRoutes()
 	docRequest := models.DocumentRequest{
 		Name: "Test Document",
-		Data: "data:application/pdf;base64,VGVzdCBEYXRh", // Base64 encoded "Test Data"
+		Data: "data:application/pdf;base64,JVBERi0xLjQgVGVzdCBEYXRh", // Base64 encoded "%PDF-1.4 Test Data"
 	}
 	body, err := json.Marshal(docRequest)
 	require.NoError(t, err)
@@ -202,6 +319,106 @@ func TestUploadDocument(t *testing.T) {
 	assert.Equal(t, "1", resp.ID)
 }
 
+func TestUploadDocument_Multipart(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	property := BuildTestProperty("1")
+	activeLease := BuildTestLease("1")
+	document := BuildTestDocument()
+	m.Property.Expect(database.MockGetPropertyByID(c)).Returns(property)
+	m.Lease.Expect(database.MockGetCurrentActiveLeaseByProperty(c)).ReturnsMany([]db.LeaseModel{activeLease})
+	ExpectStorageUsage(c, m, 0, 0)
+	m.Document.Expect(database.MockCreateDocument(c, document)).Returns(BuildTestDocument())
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	require.NoError(t, writer.WriteField("name", "Test Document"))
+	part, err := writer.CreateFormFile("file", "test.pdf")
+	require.NoError(t, err)
+	_, err = part.Write([]byte("%PDF-1.4 Test Data"))
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	r := router.TestRoutes()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/v1/owner/properties/1/leases/current/docs/", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	req.Header.Set("Oauth.claims.id", "1")
+	req.Header.Set("Oauth.claims.role", string(db.RoleOwner))
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusCreated, w.Code)
+	var resp models.IdResponse
+	err = json.Unmarshal(w.Body.Bytes(), &resp)
+	require.NoError(t, err)
+	assert.Equal(t, "1", resp.ID)
+}
+
+func TestUploadDocument_MultipartUnsupportedType(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	property := BuildTestProperty("1")
+	activeLease := BuildTestLease("1")
+	m.Property.Expect(database.MockGetPropertyByID(c)).Returns(property)
+	m.Lease.Expect(database.MockGetCurrentActiveLeaseByProperty(c)).ReturnsMany([]db.LeaseModel{activeLease})
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile("file", "test.gif")
+	require.NoError(t, err)
+	_, err = part.Write([]byte("GIF89a"))
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	r := router.TestRoutes()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/v1/owner/properties/1/leases/current/docs/", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	req.Header.Set("Oauth.claims.id", "1")
+	req.Header.Set("Oauth.claims.role", string(db.RoleOwner))
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	var resp utils.Error
+	err = json.Unmarshal(w.Body.Bytes(), &resp)
+	require.NoError(t, err)
+	assert.Equal(t, utils.BadBase64OrUnsupportedType, resp.Code)
+}
+
+func TestUploadDocument_MultipartTypeMismatch(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	property := BuildTestProperty("1")
+	activeLease := BuildTestLease("1")
+	m.Property.Expect(database.MockGetPropertyByID(c)).Returns(property)
+	m.Lease.Expect(database.MockGetCurrentActiveLeaseByProperty(c)).ReturnsMany([]db.LeaseModel{activeLease})
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile("file", "test.txt")
+	require.NoError(t, err)
+	_, err = part.Write([]byte("%PDF-1.4 Test Data"))
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	r := router.TestRoutes()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/v1/owner/properties/1/leases/current/docs/", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	req.Header.Set("Oauth.claims.id", "1")
+	req.Header.Set("Oauth.claims.role", string(db.RoleOwner))
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	var resp utils.Error
+	err = json.Unmarshal(w.Body.Bytes(), &resp)
+	require.NoError(t, err)
+	assert.Equal(t, utils.BadBase64OrUnsupportedType, resp.Code)
+}
+
 func TestUploadDocument_MissingFields(t *testing.T) {
 	c, m, ensure := services.ConnectDBTest()
 	defer ensure(t)
@@ -236,7 +453,7 @@ func TestUploadDocument_NoActiveLease(t *testing.T) {
 
 	docRequest := models.DocumentRequest{
 		Name: "Test Document",
-		Data: "data:application/pdf;base64,VGVzdCBEYXRh", // Base64 encoded "Test Data"
+		Data: "data:application/pdf;base64,JVBERi0xLjQgVGVzdCBEYXRh", // Base64 encoded "%PDF-1.4 Test Data"
 	}
 	body, err := json.Marshal(docRequest)
 	require.NoError(t, err)
@@ -265,7 +482,7 @@ func TestUploadDocument_NotYours(t *testing.T) {
 
 	docRequest := models.DocumentRequest{
 		Name: "Test Document",
-		Data: "data:application/pdf;base64,VGVzdCBEYXRh", // Base64 encoded "Test Data"
+		Data: "data:application/pdf;base64,JVBERi0xLjQgVGVzdCBEYXRh", // Base64 encoded "%PDF-1.4 Test Data"
 	}
 	body, err := json.Marshal(docRequest)
 	require.NoError(t, err)
@@ -285,9 +502,77 @@ func TestUploadDocument_NotYours(t *testing.T) {
 	assert.Equal(t, utils.PropertyNotYours, errorResponse.Code)
 }
 
+func TestUpdateDocumentInfo(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	property := BuildTestProperty("1")
+	activeLease := BuildTestLease("1")
+	expiresAt := time.Date(2026, time.March, 31, 0, 0, 0, 0, time.UTC)
+	document := BuildTestDocument()
+	updated := BuildTestDocument()
+	updated.Category = db.DocCategoryInsurance
+	updated.Tags = []string{"home"}
+	updated.InnerDocument.ExpiresAt = &expiresAt
+	m.Property.Expect(database.MockGetPropertyByID(c)).Returns(property)
+	m.Lease.Expect(database.MockGetCurrentActiveLeaseByProperty(c)).ReturnsMany([]db.LeaseModel{activeLease})
+	m.Document.Expect(database.MockGetDocumentByID(c)).Returns(document)
+	m.Document.Expect(database.MockUpdateDocumentInfo(c, updated)).Returns(updated)
+
+	r := router.TestRoutes()
+	body, err := json.Marshal(models.DocumentInfo{
+		Category:  db.DocCategoryInsurance,
+		Tags:      []string{"home"},
+		ExpiresAt: &expiresAt,
+	})
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPut, "/v1/owner/properties/1/leases/current/docs/1/", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Oauth.claims.id", "1")
+	req.Header.Set("Oauth.claims.role", string(db.RoleOwner))
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp models.DocumentResponse
+	err = json.Unmarshal(w.Body.Bytes(), &resp)
+	require.NoError(t, err)
+	assert.Equal(t, db.DocCategoryInsurance, resp.Category)
+	assert.Equal(t, []string{"home"}, resp.Tags)
+	require.NotNil(t, resp.ExpiresAt)
+	assert.True(t, expiresAt.Equal(*resp.ExpiresAt))
+}
+
+func TestUpdateDocumentInfo_BadCategory(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	property := BuildTestProperty("1")
+	activeLease := BuildTestLease("1")
+	m.Property.Expect(database.MockGetPropertyByID(c)).Returns(property)
+	m.Lease.Expect(database.MockGetCurrentActiveLeaseByProperty(c)).ReturnsMany([]db.LeaseModel{activeLease})
+	m.Document.Expect(database.MockGetDocumentByID(c)).Returns(BuildTestDocument())
+
+	r := router.TestRoutes()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPut, "/v1/owner/properties/1/leases/current/docs/1/", bytes.NewReader([]byte(`{"category":"invalid"}`)))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Oauth.claims.id", "1")
+	req.Header.Set("Oauth.claims.role", string(db.RoleOwner))
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	var errorResponse utils.Error
+	err := json.Unmarshal(w.Body.Bytes(), &errorResponse)
+	require.NoError(t, err)
+	assert.Equal(t, utils.MissingFields, errorResponse.Code)
+}
+
 func TestDeleteDocument(t *testing.T) {
 	c, m, ensure := services.ConnectDBTest()
 	defer ensure(t)
+	now := mockNow(t)
 
 	property := BuildTestProperty("1")
 	activeLease := BuildTestLease("1")
@@ -295,7 +580,7 @@ func TestDeleteDocument(t *testing.T) {
 	m.Property.Expect(database.MockGetPropertyByID(c)).Returns(property)
 	m.Lease.Expect(database.MockGetCurrentActiveLeaseByProperty(c)).ReturnsMany([]db.LeaseModel{activeLease})
 	m.Document.Expect(database.MockGetDocumentByID(c)).Returns(document)
-	m.Document.Expect(database.MockDeleteDocument(c)).Returns(document)
+	m.Document.Expect(database.MockDeleteDocument(c, now)).Returns(document)
 
 	r := router.TestRoutes()
 	w := httptest.NewRecorder()
@@ -352,6 +637,250 @@ func TestDeleteDocument_NotFound(t *testing.T) {
 	assert.Equal(t, utils.DocumentNotFound, errorResponse.Code)
 }
 
+func TestDeleteDocument_AlreadyDeleted(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	property := BuildTestProperty("1")
+	activeLease := BuildTestLease("1")
+	document := BuildTestDocument()
+	document.InnerDocument.DeletedAt = utils.Ptr(time.Now())
+	m.Property.Expect(database.MockGetPropertyByID(c)).Returns(property)
+	m.Lease.Expect(database.MockGetCurrentActiveLeaseByProperty(c)).ReturnsMany([]db.LeaseModel{activeLease})
+	m.Document.Expect(database.MockGetDocumentByID(c)).Returns(document)
+
+	r := router.TestRoutes()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodDelete, "/v1/owner/properties/1/leases/current/docs/1/", nil)
+	req.Header.Set("Oauth.claims.id", "1")
+	req.Header.Set("Oauth.claims.role", string(db.RoleOwner))
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusNotFound, w.Code)
+	var errorResponse utils.Error
+	err := json.Unmarshal(w.Body.Bytes(), &errorResponse)
+	require.NoError(t, err)
+	assert.Equal(t, utils.DocumentNotFound, errorResponse.Code)
+}
+
+func TestGetDeletedDocuments(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	property := BuildTestProperty("1")
+	activeLease := BuildTestLease("1")
+	document := BuildTestDocument()
+	document.InnerDocument.DeletedAt = utils.Ptr(time.Now())
+	m.Property.Expect(database.MockGetPropertyByID(c)).Returns(property)
+	m.Lease.Expect(database.MockGetCurrentActiveLeaseByProperty(c)).ReturnsMany([]db.LeaseModel{activeLease})
+	m.Document.Expect(database.MockGetDeletedDocumentsByLease(c)).ReturnsMany([]db.DocumentModel{document})
+
+	r := router.TestRoutes()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/v1/owner/properties/1/leases/current/docs/trash/", nil)
+	req.Header.Set("Oauth.claims.id", "1")
+	req.Header.Set("Oauth.claims.role", string(db.RoleOwner))
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp []models.DocumentResponse
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	require.NoError(t, err)
+	require.Len(t, resp, 1)
+	require.NotNil(t, resp[0].DeletedAt)
+	require.NotNil(t, resp[0].PurgeAt)
+	assert.WithinDuration(t, resp[0].DeletedAt.Add(db.DocumentRetentionPeriod), *resp[0].PurgeAt, time.Second)
+}
+
+func TestRestoreDocument(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	property := BuildTestProperty("1")
+	activeLease := BuildTestLease("1")
+	document := BuildTestDocument()
+	document.InnerDocument.DeletedAt = utils.Ptr(time.Now())
+	m.Property.Expect(database.MockGetPropertyByID(c)).Returns(property)
+	m.Lease.Expect(database.MockGetCurrentActiveLeaseByProperty(c)).ReturnsMany([]db.LeaseModel{activeLease})
+	m.Document.Expect(database.MockGetDocumentByID(c)).Returns(document)
+	m.Document.Expect(database.MockRestoreDocument(c)).Returns(document)
+
+	r := router.TestRoutes()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/v1/owner/properties/1/leases/current/docs/trash/1/restore/", nil)
+	req.Header.Set("Oauth.claims.id", "1")
+	req.Header.Set("Oauth.claims.role", string(db.RoleOwner))
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp models.DocumentResponse
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	require.NoError(t, err)
+	assert.Equal(t, document.ID, resp.ID)
+	assert.Nil(t, resp.DeletedAt)
+}
+
+func TestRestoreDocument_NotDeleted(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	property := BuildTestProperty("1")
+	activeLease := BuildTestLease("1")
+	m.Property.Expect(database.MockGetPropertyByID(c)).Returns(property)
+	m.Lease.Expect(database.MockGetCurrentActiveLeaseByProperty(c)).ReturnsMany([]db.LeaseModel{activeLease})
+	m.Document.Expect(database.MockGetDocumentByID(c)).Returns(BuildTestDocument())
+
+	r := router.TestRoutes()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/v1/owner/properties/1/leases/current/docs/trash/1/restore/", nil)
+	req.Header.Set("Oauth.claims.id", "1")
+	req.Header.Set("Oauth.claims.role", string(db.RoleOwner))
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusNotFound, w.Code)
+	var errorResponse utils.Error
+	err := json.Unmarshal(w.Body.Bytes(), &errorResponse)
+	require.NoError(t, err)
+	assert.Equal(t, utils.DocumentNotFound, errorResponse.Code)
+}
+
+func TestUploadDocumentVersion(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	property := BuildTestProperty("1")
+	activeLease := BuildTestLease("1")
+	previous := BuildTestDocument()
+	previous.Category = db.DocCategoryLease
+	previous.Tags = []string{"annex"}
+	version := BuildTestDocument()
+	version.ID = "2"
+	version.Name = "Annex v2"
+	version.Category = db.DocCategoryLease
+	version.Tags = []string{"annex"}
+	m.Property.Expect(database.MockGetPropertyByID(c)).Returns(property)
+	m.Lease.Expect(database.MockGetCurrentActiveLeaseByProperty(c)).ReturnsMany([]db.LeaseModel{activeLease})
+	ExpectStorageUsage(c, m, 0, 0)
+	m.Document.Expect(database.MockGetDocumentByID(c)).Returns(previous)
+	services.ExpectCount(m, database.MockOutdateDocument(c), 1)
+	m.Document.Expect(database.MockCreateDocumentVersion(c, version, previous)).Returns(version)
+
+	r := router.TestRoutes()
+	body, err := json.Marshal(models.DocumentRequest{
+		Name: "Annex v2",
+		Data: "data:application/pdf;base64,JVBERi0xLjQgVGVzdCBEYXRh", // Base64 encoded "%PDF-1.4 Test Data"
+	})
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/v1/owner/properties/1/leases/current/docs/1/versions/", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Oauth.claims.id", "1")
+	req.Header.Set("Oauth.claims.role", string(db.RoleOwner))
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusCreated, w.Code)
+	var resp models.IdResponse
+	err = json.Unmarshal(w.Body.Bytes(), &resp)
+	require.NoError(t, err)
+	assert.Equal(t, "2", resp.ID)
+}
+
+func TestUploadDocumentVersion_UploadedMeanwhile(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	property := BuildTestProperty("1")
+	activeLease := BuildTestLease("1")
+	previous := BuildTestDocument()
+	m.Property.Expect(database.MockGetPropertyByID(c)).Returns(property)
+	m.Lease.Expect(database.MockGetCurrentActiveLeaseByProperty(c)).ReturnsMany([]db.LeaseModel{activeLease})
+	ExpectStorageUsage(c, m, 0, 0)
+	m.Document.Expect(database.MockGetDocumentByID(c)).Returns(previous)
+	services.ExpectCount(m, database.MockOutdateDocument(c), 0)
+
+	r := router.TestRoutes()
+	body, err := json.Marshal(models.DocumentRequest{
+		Name: "Annex v2",
+		Data: "data:application/pdf;base64,JVBERi0xLjQgVGVzdCBEYXRh", // Base64 encoded "%PDF-1.4 Test Data"
+	})
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/v1/owner/properties/1/leases/current/docs/1/versions/", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Oauth.claims.id", "1")
+	req.Header.Set("Oauth.claims.role", string(db.RoleOwner))
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusConflict, w.Code)
+	var errorResponse utils.Error
+	err = json.Unmarshal(w.Body.Bytes(), &errorResponse)
+	require.NoError(t, err)
+	assert.Equal(t, utils.DocumentNotLatestVersion, errorResponse.Code)
+}
+
+func TestUploadDocumentVersion_NotLatest(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	property := BuildTestProperty("1")
+	activeLease := BuildTestLease("1")
+	previous := BuildTestDocument()
+	previous.Latest = false
+	m.Property.Expect(database.MockGetPropertyByID(c)).Returns(property)
+	m.Lease.Expect(database.MockGetCurrentActiveLeaseByProperty(c)).ReturnsMany([]db.LeaseModel{activeLease})
+	m.Document.Expect(database.MockGetDocumentByID(c)).Returns(previous)
+
+	r := router.TestRoutes()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/v1/owner/properties/1/leases/current/docs/1/versions/", bytes.NewReader([]byte(`{}`)))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Oauth.claims.id", "1")
+	req.Header.Set("Oauth.claims.role", string(db.RoleOwner))
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusConflict, w.Code)
+	var errorResponse utils.Error
+	err := json.Unmarshal(w.Body.Bytes(), &errorResponse)
+	require.NoError(t, err)
+	assert.Equal(t, utils.DocumentNotLatestVersion, errorResponse.Code)
+}
+
+func TestGetDocumentVersions(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	property := BuildTestProperty("1")
+	activeLease := BuildTestLease("1")
+	first := BuildTestDocument()
+	first.Latest = false
+	latest := BuildTestDocument()
+	latest.ID = "2"
+	latest.Version = 2
+	latest.InnerDocument.OriginalID = utils.Ptr("1")
+	m.Property.Expect(database.MockGetPropertyByID(c)).Returns(property)
+	m.Lease.Expect(database.MockGetCurrentActiveLeaseByProperty(c)).ReturnsMany([]db.LeaseModel{activeLease})
+	m.Document.Expect(database.MockGetDocumentByID(c)).Returns(first)
+	m.Document.Expect(database.MockGetDocumentVersions(c)).ReturnsMany([]db.DocumentModel{latest, first})
+
+	r := router.TestRoutes()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/v1/owner/properties/1/leases/current/docs/1/versions/", nil)
+	req.Header.Set("Oauth.claims.id", "1")
+	req.Header.Set("Oauth.claims.role", string(db.RoleOwner))
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp []models.DocumentResponse
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	require.NoError(t, err)
+	require.Len(t, resp, 2)
+	assert.Equal(t, 2, resp[0].Version)
+	assert.True(t, resp[0].Latest)
+	assert.False(t, resp[1].Latest)
+}
+
 func TestDeleteDocument_NoActiveLease(t *testing.T) {
 	c, m, ensure := services.ConnectDBTest()
 	defer ensure(t)
@@ -0,0 +1,158 @@
+package controllers
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"keyz/backend/models"
+	"keyz/backend/prisma/db"
+	"keyz/backend/services/brevo"
+	"keyz/backend/services/database"
+	"keyz/backend/services/storage"
+	"keyz/backend/utils"
+)
+
+// CreateGuarantor godoc
+//
+//	@Summary		Add guarantor
+//	@Description	Add a guarantor to a lease
+//	@Tags			guarantor
+//	@Accept			json
+//	@Produce		json
+//	@Param			property_id	path		string					true	"Property ID"
+//	@Param			lease_id	path		string					true	"Lease ID or `current`"
+//	@Param			guarantor	body		models.GuarantorRequest	true	"Guarantor to add"
+//	@Success		201			{object}	models.IdResponse		"Created guarantor ID"
+//	@Failure		400			{object}	utils.Error				"Missing fields"
+//	@Failure		403			{object}	utils.Error				"Property not yours"
+//	@Failure		404			{object}	utils.Error				"Lease not found"
+//	@Failure		500
+//	@Security		Bearer
+//	@Router			/owner/properties/{property_id}/leases/{lease_id}/guarantors/ [post]
+func CreateGuarantor(c *gin.Context) {
+	var req models.GuarantorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.SendError(c, http.StatusBadRequest, utils.MissingFields, err)
+		return
+	}
+
+	lease, _ := c.MustGet("lease").(db.LeaseModel)
+	guarantor := database.CreateGuarantor(req.ToDbGuarantor(), lease.ID)
+	c.JSON(http.StatusCreated, models.IdResponse{ID: guarantor.ID})
+}
+
+// GetGuarantorsByLease godoc
+//
+//	@Summary		Get guarantors
+//	@Description	Get all guarantors of a lease
+//	@Tags			guarantor
+//	@Accept			json
+//	@Produce		json
+//	@Param			property_id	path		string						true	"Property ID"
+//	@Param			lease_id	path		string						true	"Lease ID or `current`"
+//	@Success		200			{array}		models.GuarantorResponse	"List of guarantors"
+//	@Failure		403			{object}	utils.Error					"Property not yours"
+//	@Failure		404			{object}	utils.Error					"Lease not found"
+//	@Failure		500
+//	@Security		Bearer
+//	@Router			/owner/properties/{property_id}/leases/{lease_id}/guarantors/ [get]
+//	@Router			/tenant/leases/{lease_id}/guarantors/ [get]
+func GetGuarantorsByLease(c *gin.Context) {
+	lease, _ := c.MustGet("lease").(db.LeaseModel)
+	guarantors := database.GetGuarantorsByLease(lease.ID)
+	c.JSON(http.StatusOK, utils.Map(guarantors, models.DbGuarantorToResponse))
+}
+
+// DeleteGuarantor godoc
+//
+//	@Summary		Delete guarantor
+//	@Description	Remove a guarantor from a lease, their documents are kept on the lease
+//	@Tags			guarantor
+//	@Accept			json
+//	@Produce		json
+//	@Param			property_id		path	string	true	"Property ID"
+//	@Param			lease_id		path	string	true	"Lease ID or `current`"
+//	@Param			guarantor_id	path	string	true	"Guarantor ID"
+//	@Success		204				"Guarantor deleted"
+//	@Failure		403				{object}	utils.Error	"Property not yours"
+//	@Failure		404				{object}	utils.Error	"Guarantor not found"
+//	@Failure		500
+//	@Security		Bearer
+//	@Router			/owner/properties/{property_id}/leases/{lease_id}/guarantors/{guarantor_id}/ [delete]
+func DeleteGuarantor(c *gin.Context) {
+	guarantor, _ := c.MustGet("guarantor").(db.GuarantorModel)
+	database.DeleteGuarantor(guarantor.ID)
+	c.Status(http.StatusNoContent)
+}
+
+// UploadGuarantorDocument godoc
+//
+//	@Summary		Upload guarantor document
+//	@Description	Upload a supporting document of a guarantor, it's also added to the lease documents
+//	@Tags			guarantor
+//	@Accept			json
+//	@Produce		json
+//	@Param			property_id		path		string					true	"Property ID"
+//	@Param			lease_id		path		string					true	"Lease ID or `current`"
+//	@Param			guarantor_id	path		string					true	"Guarantor ID"
+//	@Param			doc				body		models.DocumentRequest	true	"Document to upload"
+//	@Success		201				{object}	models.IdResponse		"Created document ID"
+//	@Failure		400				{object}	utils.Error				"Missing fields"
+//	@Failure		403				{object}	utils.Error				"Property not yours or storage quota exceeded"
+//	@Failure		404				{object}	utils.Error				"Guarantor not found"
+//	@Failure		413				{object}	utils.Error				"File too large"
+//	@Failure		500
+//	@Security		Bearer
+//	@Router			/owner/properties/{property_id}/leases/{lease_id}/guarantors/{guarantor_id}/docs/ [post]
+func UploadGuarantorDocument(c *gin.Context) {
+	var req models.DocumentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.SendError(c, http.StatusBadRequest, utils.MissingFields, err)
+		return
+	}
+
+	doc := req.ToDbDocument()
+	if doc == nil {
+		utils.SendError(c, http.StatusBadRequest, utils.BadBase64OrUnsupportedType, nil)
+		return
+	}
+
+	lease, _ := c.MustGet("lease").(db.LeaseModel)
+	if !checkFileSize(c, *doc.InnerDocument.Data, storage.MaxDocumentSize) || !checkStorageQuota(c, lease.Property().OwnerID, len(*doc.InnerDocument.Data)) {
+		return
+	}
+	guarantor, _ := c.MustGet("guarantor").(db.GuarantorModel)
+	res := database.CreateGuarantorDocument(*doc, lease.ID, guarantor.ID)
+	c.JSON(http.StatusCreated, models.IdResponse{ID: res.ID})
+}
+
+// NotifyGuarantor godoc
+//
+//	@Summary		Notify guarantor
+//	@Description	Send the guarantor an email summarizing the lease they guarantee
+//	@Tags			guarantor
+//	@Accept			json
+//	@Produce		json
+//	@Param			property_id		path	string	true	"Property ID"
+//	@Param			lease_id		path	string	true	"Lease ID or `current`"
+//	@Param			guarantor_id	path	string	true	"Guarantor ID"
+//	@Success		204				"Email sent"
+//	@Failure		403				{object}	utils.Error	"Property not yours"
+//	@Failure		404				{object}	utils.Error	"Guarantor not found"
+//	@Failure		500				{object}	utils.Error	"Failed to send email"
+//	@Security		Bearer
+//	@Router			/owner/properties/{property_id}/leases/{lease_id}/guarantors/{guarantor_id}/notify/ [post]
+func NotifyGuarantor(c *gin.Context) {
+	lease, _ := c.MustGet("lease").(db.LeaseModel)
+	guarantor, _ := c.MustGet("guarantor").(db.GuarantorModel)
+
+	res, err := brevo.SendGuarantorNotification(lease, guarantor)
+	if err != nil {
+		log.Println(res, err.Error())
+		utils.SendError(c, http.StatusInternalServerError, utils.FailedSendEmail, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
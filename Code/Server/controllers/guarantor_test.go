@@ -0,0 +1,206 @@
+package controllers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"keyz/backend/models"
+	"keyz/backend/prisma/db"
+	"keyz/backend/router"
+	"keyz/backend/services"
+	"keyz/backend/services/database"
+	"keyz/backend/utils"
+)
+
+func BuildTestGuarantor(id string) db.GuarantorModel {
+	return db.GuarantorModel{
+		InnerGuarantor: db.InnerGuarantor{
+			ID:        id,
+			LeaseID:   "1",
+			Firstname: "Jane",
+			Lastname:  "Doe",
+			Email:     "janedoe@example.com",
+			Type:      db.GuaranteeTypeJoint,
+			CreatedAt: time.Now(),
+		},
+	}
+}
+
+func TestCreateGuarantor(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	property := BuildTestProperty("1")
+	lease := BuildTestLease("1")
+	guarantor := BuildTestGuarantor("1")
+	m.Property.Expect(database.MockGetPropertyByID(c)).Returns(property)
+	m.Lease.Expect(database.MockGetLeaseByID(c)).Returns(lease)
+	m.Guarantor.Expect(database.MockCreateGuarantor(c, guarantor)).Returns(guarantor)
+
+	reqBody := models.GuarantorRequest{
+		Firstname: guarantor.Firstname,
+		Lastname:  guarantor.Lastname,
+		Email:     guarantor.Email,
+		Type:      guarantor.Type,
+	}
+	b, err := json.Marshal(reqBody)
+	require.NoError(t, err)
+
+	r := router.TestRoutes()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/v1/owner/properties/1/leases/1/guarantors/", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Oauth.claims.id", "1")
+	req.Header.Set("Oauth.claims.role", string(db.RoleOwner))
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusCreated, w.Code)
+	var resp models.IdResponse
+	err = json.Unmarshal(w.Body.Bytes(), &resp)
+	require.NoError(t, err)
+	assert.Equal(t, guarantor.ID, resp.ID)
+}
+
+func TestCreateGuarantor_BadType(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	property := BuildTestProperty("1")
+	lease := BuildTestLease("1")
+	m.Property.Expect(database.MockGetPropertyByID(c)).Returns(property)
+	m.Lease.Expect(database.MockGetLeaseByID(c)).Returns(lease)
+
+	reqBody := models.GuarantorRequest{
+		Firstname: "Jane",
+		Lastname:  "Doe",
+		Email:     "janedoe@example.com",
+		Type:      "friend",
+	}
+	b, err := json.Marshal(reqBody)
+	require.NoError(t, err)
+
+	r := router.TestRoutes()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/v1/owner/properties/1/leases/1/guarantors/", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Oauth.claims.id", "1")
+	req.Header.Set("Oauth.claims.role", string(db.RoleOwner))
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	var resp utils.Error
+	err = json.Unmarshal(w.Body.Bytes(), &resp)
+	require.NoError(t, err)
+	assert.Equal(t, utils.MissingFields, resp.Code)
+}
+
+func TestGetGuarantorsByLease(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	lease := BuildTestLease("1")
+	m.Lease.Expect(database.MockGetLeaseByID(c)).Returns(lease)
+	m.Guarantor.Expect(database.MockGetGuarantorsByLease(c)).ReturnsMany([]db.GuarantorModel{BuildTestGuarantor("1")})
+
+	r := router.TestRoutes()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/v1/tenant/leases/1/guarantors/", nil)
+	req.Header.Set("Oauth.claims.id", "1")
+	req.Header.Set("Oauth.claims.role", string(db.RoleTenant))
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp []models.GuarantorResponse
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	require.NoError(t, err)
+	require.Len(t, resp, 1)
+	assert.Equal(t, "1", resp[0].ID)
+}
+
+func TestDeleteGuarantor(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	property := BuildTestProperty("1")
+	lease := BuildTestLease("1")
+	guarantor := BuildTestGuarantor("1")
+	m.Property.Expect(database.MockGetPropertyByID(c)).Returns(property)
+	m.Lease.Expect(database.MockGetLeaseByID(c)).Returns(lease)
+	m.Guarantor.Expect(database.MockGetGuarantorByID(c)).Returns(guarantor)
+	m.Guarantor.Expect(database.MockDeleteGuarantor(c)).Returns(guarantor)
+
+	r := router.TestRoutes()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodDelete, "/v1/owner/properties/1/leases/1/guarantors/1/", nil)
+	req.Header.Set("Oauth.claims.id", "1")
+	req.Header.Set("Oauth.claims.role", string(db.RoleOwner))
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusNoContent, w.Code)
+}
+
+func TestDeleteGuarantor_NotFound(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	property := BuildTestProperty("1")
+	lease := BuildTestLease("1")
+	m.Property.Expect(database.MockGetPropertyByID(c)).Returns(property)
+	m.Lease.Expect(database.MockGetLeaseByID(c)).Returns(lease)
+	m.Guarantor.Expect(database.MockGetGuarantorByID(c)).Errors(db.ErrNotFound)
+
+	r := router.TestRoutes()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodDelete, "/v1/owner/properties/1/leases/1/guarantors/1/", nil)
+	req.Header.Set("Oauth.claims.id", "1")
+	req.Header.Set("Oauth.claims.role", string(db.RoleOwner))
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusNotFound, w.Code)
+	var resp utils.Error
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	require.NoError(t, err)
+	assert.Equal(t, utils.GuarantorNotFound, resp.Code)
+}
+
+func TestUploadGuarantorDocument(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	property := BuildTestProperty("1")
+	lease := BuildTestLease("1")
+	guarantor := BuildTestGuarantor("1")
+	document := BuildTestDocument()
+	m.Property.Expect(database.MockGetPropertyByID(c)).Returns(property)
+	m.Lease.Expect(database.MockGetLeaseByID(c)).Returns(lease)
+	m.Guarantor.Expect(database.MockGetGuarantorByID(c)).Returns(guarantor)
+	ExpectStorageUsage(c, m, 0, 0)
+	m.Document.Expect(database.MockCreateGuarantorDocument(c, document)).Returns(document)
+
+	docRequest := models.DocumentRequest{
+		Name: "Test Document",
+		Data: "data:application/pdf;base64,JVBERi0xLjQgVGVzdCBEYXRh", // Base64 encoded "%PDF-1.4 Test Data"
+	}
+	b, err := json.Marshal(docRequest)
+	require.NoError(t, err)
+
+	r := router.TestRoutes()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/v1/owner/properties/1/leases/1/guarantors/1/docs/", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Oauth.claims.id", "1")
+	req.Header.Set("Oauth.claims.role", string(db.RoleOwner))
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusCreated, w.Code)
+	var resp models.IdResponse
+	err = json.Unmarshal(w.Body.Bytes(), &resp)
+	require.NoError(t, err)
+	assert.Equal(t, document.ID, resp.ID)
+}
@@ -10,6 +10,7 @@ import (
 	"keyz/backend/prisma/db"
 	"keyz/backend/services/database"
 	"keyz/backend/services/pdf"
+	"keyz/backend/services/storage"
 	"keyz/backend/utils"
 )
 
@@ -35,12 +36,12 @@ func checkFurniture(furnitureId string, roomId string) error {
 	return nil
 }
 
-func getFurnitureStatePictures(f models.FurnitureStateRequest) ([]string, error) {
+func getFurnitureStatePictures(f models.FurnitureStateRequest, images map[string]*db.ImageModel) ([]string, error) {
 	picturesId := make([]string, 0, len(f.Pictures))
 	var err error
 
 	for _, pic := range f.Pictures {
-		dbImage := models.StringToDbImage(pic)
+		dbImage := images[pic]
 		if dbImage == nil {
 			err = errors.New(string(utils.BadBase64OrUnsupportedType))
 			continue
@@ -51,7 +52,7 @@ func getFurnitureStatePictures(f models.FurnitureStateRequest) ([]string, error)
 	return picturesId, err
 }
 
-func createFurnitureState(invrep *db.InventoryReportModel, room models.RoomStateRequest) []string {
+func createFurnitureState(invrep *db.InventoryReportModel, room models.RoomStateRequest, images map[string]*db.ImageModel) []string {
 	var errorList []string
 
 	for _, f := range room.Furnitures {
@@ -69,7 +70,7 @@ func createFurnitureState(invrep *db.InventoryReportModel, room models.RoomState
 				Note:        f.Note,
 			},
 		}
-		picturesId, err := getFurnitureStatePictures(f)
+		picturesId, err := getFurnitureStatePictures(f, images)
 		if err != nil {
 			errorList = append(errorList, err.Error())
 		}
@@ -79,12 +80,12 @@ func createFurnitureState(invrep *db.InventoryReportModel, room models.RoomState
 	return errorList
 }
 
-func getRoomStatePictures(r models.RoomStateRequest) ([]string, error) {
+func getRoomStatePictures(r models.RoomStateRequest, images map[string]*db.ImageModel) ([]string, error) {
 	picturesId := make([]string, 0, len(r.Pictures))
 	var err error
 
 	for _, pic := range r.Pictures {
-		dbImage := models.StringToDbImage(pic)
+		dbImage := images[pic]
 		if dbImage == nil {
 			err = errors.New(string(utils.BadBase64OrUnsupportedType))
 			continue
@@ -95,7 +96,7 @@ func getRoomStatePictures(r models.RoomStateRequest) ([]string, error) {
 	return picturesId, err
 }
 
-func createRoomStates(c *gin.Context, invrep *db.InventoryReportModel, req models.InventoryReportRequest) []string {
+func createRoomStates(c *gin.Context, invrep *db.InventoryReportModel, req models.InventoryReportRequest, images map[string]*db.ImageModel) []string {
 	var errorList []string
 
 	for _, r := range req.Rooms {
@@ -113,17 +114,45 @@ func createRoomStates(c *gin.Context, invrep *db.InventoryReportModel, req model
 				Note:        r.Note,
 			},
 		}
-		picturesId, err := getRoomStatePictures(r)
+		picturesId, err := getRoomStatePictures(r, images)
 		if err != nil {
 			errorList = append(errorList, err.Error())
 		}
 		database.CreateRoomState(rModel, picturesId, invrep.ID)
-		errorList = append(errorList, createFurnitureState(invrep, r)...)
+		errorList = append(errorList, createFurnitureState(invrep, r, images)...)
 	}
 
 	return errorList
 }
 
+// decodeInventoryReportPictures decodes the pictures of a report by their base64 string and checks their size.
+// Pictures that cannot be decoded are left out, they are reported as errors when creating the room and furniture states.
+func decodeInventoryReportPictures(c *gin.Context, req models.InventoryReportRequest) (map[string]*db.ImageModel, int, bool) {
+	pictures := make([]string, 0)
+	for _, room := range req.Rooms {
+		pictures = append(pictures, room.Pictures...)
+		for _, furniture := range room.Furnitures {
+			pictures = append(pictures, furniture.Pictures...)
+		}
+	}
+
+	images := make(map[string]*db.ImageModel, len(pictures))
+	size := 0
+	for _, pic := range pictures {
+		if _, ok := images[pic]; !ok {
+			images[pic] = models.StringToDbImage(pic)
+		}
+		if images[pic] == nil {
+			continue
+		}
+		if !checkFileSize(c, *images[pic].InnerImage.Data, storage.MaxImageSize) {
+			return nil, 0, false
+		}
+		size += len(*images[pic].InnerImage.Data)
+	}
+	return images, size, true
+}
+
 func createInvReportPDF(invRepId string, lease db.LeaseModel) (*db.DocumentModel, error) {
 	invReport := database.GetInvReportByID(invRepId)
 	docBytes, err := pdf.NewInventoryReportPDF(*invReport, lease)
@@ -133,9 +162,10 @@ func createInvReportPDF(invRepId string, lease db.LeaseModel) (*db.DocumentModel
 
 	res := database.CreateDocument(db.DocumentModel{
 		InnerDocument: db.InnerDocument{
-			Name: "inventory_report_" + time.Now().Format("2006-01-02") + "_" + invRepId + ".pdf",
-			Data: docBytes,
-			Type: db.DocTypePdf,
+			Name:     "inventory_report_" + time.Now().Format("2006-01-02") + "_" + invRepId + ".pdf",
+			Data:     &docBytes,
+			Type:     db.DocTypePdf,
+			Category: db.DocCategoryInventoryReport,
 		},
 	}, lease.ID)
 	return &res, nil
@@ -153,8 +183,9 @@ func createInvReportPDF(invRepId string, lease db.LeaseModel) (*db.DocumentModel
 //	@Param			invReport	body		models.InventoryReportRequest			true	"Inventory report data"
 //	@Success		201			{object}	models.CreateInventoryReportResponse	"Created inventory report data"
 //	@Failure		400			{object}	utils.Error								"Missing fields"
-//	@Failure		403			{object}	utils.Error								"Property not yours"
+//	@Failure		403			{object}	utils.Error								"Property not yours or storage quota exceeded"
 //	@Failure		404			{object}	utils.Error								"Property or room not found"
+//	@Failure		413			{object}	utils.Error								"File too large"
 //	@Failure		500
 //	@Security		Bearer
 //	@Router			/owner/properties/{property_id}/leases/{lease_id}/inventory-reports/ [post]
@@ -170,14 +201,22 @@ func CreateInventoryReport(c *gin.Context) {
 		return
 	}
 
+	images, size, ok := decodeInventoryReportPictures(c, req)
+	if !ok {
+		return
+	}
 	lease, _ := c.MustGet("lease").(db.LeaseModel)
+	// the PDF of the report embeds the pictures, so it takes about as much space again
+	if !checkStorageQuota(c, lease.Property().OwnerID, 2*size) {
+		return
+	}
 	invrep := database.CreateInvReport(req.Type, lease.ID)
 	if invrep == nil {
 		utils.SendError(c, http.StatusConflict, utils.InventoryReportAlreadyExists, nil)
 		return
 	}
 
-	errorsList := createRoomStates(c, invrep, req)
+	errorsList := createRoomStates(c, invrep, req, images)
 
 	irPdf, err := createInvReportPDF(invrep.ID, lease)
 	if err != nil {
@@ -67,6 +67,7 @@ func BuildTestLeaseWithInfo() db.LeaseModel {
 					ID:                  "1",
 					Name:                "Test property",
 					RentalPricePerMonth: 1000,
+					OwnerID:             "1",
 				},
 				RelationsProperty: db.RelationsProperty{
 					Owner: &db.UserModel{
@@ -528,7 +529,7 @@ func BuildTestImage(id string, base64data string) db.ImageModel {
 // 		),
 // 	).Returns(room)
 
-// 	roomPicture := BuildTestImage("1", "data:image/jpeg;base64,b3Vp")
+// 	roomPicture := BuildTestImage("1", "data:image/jpeg;base64,/9j/4A==")
 // 	m.Image.Expect(
 // 		c.Client.Image.CreateOne(
 // 			db.Image.Data.Set(roomPicture.Data),
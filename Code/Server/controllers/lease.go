@@ -1,12 +1,14 @@
 package controllers
 
 import (
+	"log"
 	"net/http"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"keyz/backend/models"
 	"keyz/backend/prisma/db"
+	"keyz/backend/services/brevo"
 	"keyz/backend/services/database"
 	"keyz/backend/utils"
 )
@@ -97,3 +99,86 @@ func EndLease(c *gin.Context) {
 	database.EndLease(currentActive.ID, utils.Ternary(ok, nil, &now))
 	c.Status(http.StatusNoContent)
 }
+
+// GetTenantInvites godoc
+//
+//	@Summary		Get pending invites
+//	@Description	Get all pending lease invites sent to the email of the current tenant
+//	@Tags			lease
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{array}		models.TenantInviteResponse	"List of pending invites"
+//	@Failure		403	{object}	utils.Error					"Not a tenant"
+//	@Failure		500
+//	@Security		Bearer
+//	@Router			/tenant/invites/ [get]
+func GetTenantInvites(c *gin.Context) {
+	claims := utils.GetClaims(c)
+	user := database.GetUserByID(claims["id"])
+	if user == nil || user.Role != db.RoleTenant {
+		utils.SendError(c, http.StatusForbidden, utils.NotATenant, nil)
+		return
+	}
+
+	invites := database.GetLeaseInvitesByEmail(user.Email, utils.Now())
+	c.JSON(http.StatusOK, utils.Map(invites, models.DbLeaseInviteToTenantResponse))
+}
+
+// AcceptInviteByID godoc
+//
+//	@Summary		Accept an invite from the inbox
+//	@Description	Accept a pending lease invite sent to the email of the current tenant
+//	@Tags			lease
+//	@Accept			json
+//	@Produce		json
+//	@Param			invite_id	path	string						true	"Invite ID"
+//	@Param			terms		body	models.AcceptInviteRequest	true	"Acceptance of the lease terms"
+//	@Success		204			"Accepted"
+//	@Failure		400			{object}	utils.Error	"Lease terms not accepted"
+//	@Failure		403			{object}	utils.Error	"Not a tenant"
+//	@Failure		404			{object}	utils.Error	"Invite not found"
+//	@Failure		409			{object}	utils.Error	"Property not available or tenant already has lease"
+//	@Failure		410			{object}	utils.Error	"Invite expired"
+//	@Failure		500
+//	@Security		Bearer
+//	@Router			/tenant/invites/{invite_id}/accept/ [post]
+func AcceptInviteByID(c *gin.Context) {
+	acceptInvite(c, database.GetLeaseInviteByID, c.Param("invite_id"))
+}
+
+// DeclineInvite godoc
+//
+//	@Summary		Decline an invite
+//	@Description	Decline a lease invite, expired or not, notify the owner and make the property available for a new invite
+//	@Tags			lease
+//	@Accept			json
+//	@Produce		json
+//	@Param			invite_id	path	string	true	"Invite ID"
+//	@Success		204			"Declined"
+//	@Failure		403			{object}	utils.Error	"Not a tenant"
+//	@Failure		404			{object}	utils.Error	"Invite not found"
+//	@Failure		500
+//	@Security		Bearer
+//	@Router			/tenant/invites/{invite_id}/decline/ [post]
+func DeclineInvite(c *gin.Context) {
+	claims := utils.GetClaims(c)
+	user := database.GetUserByID(claims["id"])
+	if user == nil || user.Role != db.RoleTenant {
+		utils.SendError(c, http.StatusForbidden, utils.NotATenant, nil)
+		return
+	}
+
+	invite := database.GetLeaseInviteByID(c.Param("invite_id"))
+	if invite == nil || invite.TenantEmail != user.Email {
+		utils.SendError(c, http.StatusNotFound, utils.InviteNotFound, nil)
+		return
+	}
+
+	database.DeleteLeaseInviteById(invite.ID)
+
+	res, err := brevo.SendInviteDeclined(*invite, *user)
+	if err != nil {
+		log.Println(res, err.Error())
+	}
+	c.Status(http.StatusNoContent)
+}
@@ -1,6 +1,7 @@
 package controllers_test
 
 import (
+	"bytes"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
@@ -39,8 +40,9 @@ func BuildTestLease(id string) db.LeaseModel {
 			},
 			Property: &db.PropertyModel{
 				InnerProperty: db.InnerProperty{
-					ID:   "1",
-					Name: "Test Property",
+					ID:      "1",
+					Name:    "Test Property",
+					OwnerID: "1",
 				},
 				RelationsProperty: db.RelationsProperty{
 					Owner: &db.UserModel{
@@ -53,6 +55,7 @@ func BuildTestLease(id string) db.LeaseModel {
 					},
 				},
 			},
+			Guarantors: []db.GuarantorModel{},
 		},
 	}
 }
@@ -369,3 +372,193 @@ func TestGetAllLeasesByTenant_Unauthorized(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, utils.NotATenant, resp.Code)
 }
+
+func TestGetTenantInvites(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	now := mockNow(t)
+
+	user := BuildTestUser("1")
+	user.Role = db.RoleTenant
+	pending := BuildTestLeaseInvite()
+	pending.TenantEmail = user.Email
+	m.User.Expect(database.MockGetUserByID(c)).Returns(user)
+	m.LeaseInvite.Expect(database.MockGetLeaseInvitesByEmail(c, user.Email, now)).ReturnsMany([]db.LeaseInviteModel{pending})
+
+	r := router.TestRoutes()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/v1/tenant/invites/", nil)
+	req.Header.Set("Oauth.claims.id", user.ID)
+	req.Header.Set("Oauth.claims.role", string(user.Role))
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp []models.TenantInviteResponse
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	require.NoError(t, err)
+	require.Len(t, resp, 1)
+	assert.Equal(t, pending.ID, resp[0].ID)
+}
+
+func TestAcceptInviteByID(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+	now := mockNow(t)
+
+	user := BuildTestUser("1")
+	user.Role = db.RoleTenant
+	leaseInvite := BuildTestLeaseInvite()
+	leaseInvite.TenantEmail = user.Email
+	m.User.Expect(database.MockGetUserByID(c)).Returns(user)
+	m.LeaseInvite.Expect(database.MockGetLeaseInviteByID(c)).Returns(leaseInvite)
+	m.Lease.Expect(database.MockGetCurrentActiveLeaseByProperty(c)).ReturnsMany([]db.LeaseModel{})
+	m.Lease.Expect(database.MockGetCurrentActiveLeaseByTenant(c)).ReturnsMany([]db.LeaseModel{})
+	m.Lease.Expect(database.MockCreateLease(c, leaseInvite, now)).Returns(BuildTestLease("1"))
+	m.LeaseInvite.Expect(database.MockDeleteLeaseInviteById(c)).Returns(db.LeaseInviteModel{})
+
+	r := router.TestRoutes()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/v1/tenant/invites/1/accept/", bytes.NewReader(buildAcceptTermsBody(t, true)))
+	req.Header.Set("Oauth.claims.id", user.ID)
+	req.Header.Set("Oauth.claims.role", string(user.Role))
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+}
+
+func TestAcceptInviteByID_WrongEmail(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	user := BuildTestUser("1")
+	user.Role = db.RoleTenant
+	leaseInvite := BuildTestLeaseInvite()
+	leaseInvite.TenantEmail = "other@example.com"
+	m.User.Expect(database.MockGetUserByID(c)).Returns(user)
+	m.LeaseInvite.Expect(database.MockGetLeaseInviteByID(c)).Returns(leaseInvite)
+
+	r := router.TestRoutes()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/v1/tenant/invites/1/accept/", bytes.NewReader(buildAcceptTermsBody(t, true)))
+	req.Header.Set("Oauth.claims.id", user.ID)
+	req.Header.Set("Oauth.claims.role", string(user.Role))
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusForbidden, w.Code)
+	var resp utils.Error
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	require.NoError(t, err)
+	assert.Equal(t, utils.UserSameEmailAsInvite, resp.Code)
+}
+
+func TestAcceptInviteByID_Expired(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	user := BuildTestUser("1")
+	user.Role = db.RoleTenant
+	leaseInvite := BuildTestLeaseInvite()
+	leaseInvite.TenantEmail = user.Email
+	leaseInvite.ExpiresAt = time.Now().Add(-time.Hour)
+	m.User.Expect(database.MockGetUserByID(c)).Returns(user)
+	m.LeaseInvite.Expect(database.MockGetLeaseInviteByID(c)).Returns(leaseInvite)
+
+	r := router.TestRoutes()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/v1/tenant/invites/1/accept/", bytes.NewReader(buildAcceptTermsBody(t, true)))
+	req.Header.Set("Oauth.claims.id", user.ID)
+	req.Header.Set("Oauth.claims.role", string(user.Role))
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusGone, w.Code)
+	var resp utils.Error
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	require.NoError(t, err)
+	assert.Equal(t, utils.InviteExpired, resp.Code)
+}
+
+func TestAcceptInviteByID_TermsNotAccepted(t *testing.T) {
+	r := router.TestRoutes()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/v1/tenant/invites/1/accept/", bytes.NewReader(buildAcceptTermsBody(t, false)))
+	req.Header.Set("Oauth.claims.id", "1")
+	req.Header.Set("Oauth.claims.role", string(db.RoleTenant))
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	var resp utils.Error
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	require.NoError(t, err)
+	assert.Equal(t, utils.LeaseTermsNotAccepted, resp.Code)
+}
+
+func TestDeclineInvite(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	user := BuildTestUser("1")
+	user.Role = db.RoleTenant
+	leaseInvite := BuildTestLeaseInvite()
+	leaseInvite.TenantEmail = user.Email
+	m.User.Expect(database.MockGetUserByID(c)).Returns(user)
+	m.LeaseInvite.Expect(database.MockGetLeaseInviteByID(c)).Returns(leaseInvite)
+	m.LeaseInvite.Expect(database.MockDeleteLeaseInviteById(c)).Returns(leaseInvite)
+
+	r := router.TestRoutes()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/v1/tenant/invites/1/decline/", nil)
+	req.Header.Set("Oauth.claims.id", user.ID)
+	req.Header.Set("Oauth.claims.role", string(user.Role))
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+}
+
+func TestDeclineInvite_NotYours(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	user := BuildTestUser("1")
+	user.Role = db.RoleTenant
+	leaseInvite := BuildTestLeaseInvite()
+	leaseInvite.TenantEmail = "other@example.com"
+	m.User.Expect(database.MockGetUserByID(c)).Returns(user)
+	m.LeaseInvite.Expect(database.MockGetLeaseInviteByID(c)).Returns(leaseInvite)
+
+	r := router.TestRoutes()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/v1/tenant/invites/1/decline/", nil)
+	req.Header.Set("Oauth.claims.id", user.ID)
+	req.Header.Set("Oauth.claims.role", string(user.Role))
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusNotFound, w.Code)
+	var resp utils.Error
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	require.NoError(t, err)
+	assert.Equal(t, utils.InviteNotFound, resp.Code)
+}
+
+func TestDeclineInvite_Expired(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	user := BuildTestUser("1")
+	user.Role = db.RoleTenant
+	leaseInvite := BuildTestLeaseInvite()
+	leaseInvite.TenantEmail = user.Email
+	leaseInvite.ExpiresAt = time.Now().Add(-time.Hour)
+	m.User.Expect(database.MockGetUserByID(c)).Returns(user)
+	m.LeaseInvite.Expect(database.MockGetLeaseInviteByID(c)).Returns(leaseInvite)
+	m.LeaseInvite.Expect(database.MockDeleteLeaseInviteById(c)).Returns(leaseInvite)
+
+	r := router.TestRoutes()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/v1/tenant/invites/1/decline/", nil)
+	req.Header.Set("Oauth.claims.id", user.ID)
+	req.Header.Set("Oauth.claims.role", string(user.Role))
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+}
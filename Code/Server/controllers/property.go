@@ -10,6 +10,7 @@ import (
 	"keyz/backend/prisma/db"
 	"keyz/backend/services/brevo"
 	"keyz/backend/services/database"
+	"keyz/backend/services/storage"
 	"keyz/backend/utils"
 )
 
@@ -70,7 +71,8 @@ func GetProperty(c *gin.Context) {
 // GetPropertyInventory godoc
 //
 //	@Summary		Get property inventory by ID
-//	@Description	Get property information by its ID with inventory including rooms and furnitures
+//	@Description	Get property information by its ID with inventory including rooms and furnitures.
+//	@Description	Furnitures linked to unfixed damages list them in `unresolved_damages`.
 //	@Tags			property,inventory
 //	@Accept			json
 //	@Produce		json
@@ -211,8 +213,9 @@ func GetPropertyPicture(c *gin.Context) {
 //	@Success		201			{object}	models.IdResponse	"Updated property ID"
 //	@Failure		400			{object}	utils.Error			"Missing fields or bad base64 string"
 //	@Failure		401			{object}	utils.Error			"Unauthorized"
-//	@Failure		403			{object}	utils.Error			"Property not yours"
+//	@Failure		403			{object}	utils.Error			"Property not yours or storage quota exceeded"
 //	@Failure		404			{object}	utils.Error			"Property not found"
+//	@Failure		413			{object}	utils.Error			"File too large"
 //	@Failure		500
 //	@Security		Bearer
 //	@Router			/owner/properties/{property_id}/picture/ [put]
@@ -229,9 +232,12 @@ func UpdatePropertyPicture(c *gin.Context) {
 		utils.SendError(c, http.StatusBadRequest, utils.BadBase64OrUnsupportedType, nil)
 		return
 	}
+	property, _ := c.MustGet("property").(db.PropertyModel)
+	if !checkFileSize(c, *image.InnerImage.Data, storage.MaxImageSize) || !checkStorageQuota(c, property.OwnerID, len(*image.InnerImage.Data)) {
+		return
+	}
 	newImage := database.CreateImage(*image)
 
-	property, _ := c.MustGet("property").(db.PropertyModel)
 	newProperty := database.UpdatePropertyPicture(property, newImage)
 	if newProperty == nil {
 		utils.SendError(c, http.StatusInternalServerError, utils.FailedLinkImage, nil)
@@ -253,7 +259,7 @@ func UpdatePropertyPicture(c *gin.Context) {
 //	@Failure		400			{object}	utils.Error				"Missing fields"
 //	@Failure		403			{object}	utils.Error				"Property is not yours"
 //	@Failure		404			{object}	utils.Error				"Property not found"
-//	@Failure		409			{object}	utils.Error				"Invite already exists for this email"
+//	@Failure		409			{object}	utils.Error				"Invite already exists for this property"
 //	@Failure		500
 //	@Security		Bearer
 //	@Router			/owner/properties/{property_id}/send-invite/ [post]
@@ -276,13 +282,17 @@ func InviteTenant(c *gin.Context) {
 		return
 	}
 
-	leaseInvite := database.CreateLeaseInvite(inviteReq.ToDbLeaseInvite(), c.Param("property_id"))
+	property, _ := c.MustGet("property").(db.PropertyModel)
+	token := utils.GenerateToken()
+	invite := inviteReq.ToDbLeaseInvite(property)
+	invite.TokenHash = utils.HashToken(token)
+	leaseInvite := database.CreateLeaseInvite(invite, c.Param("property_id"), utils.Now())
 	if leaseInvite == nil {
 		utils.SendError(c, http.StatusConflict, utils.InviteAlreadyExists, nil)
 		return
 	}
 
-	res, err := brevo.SendEmailInvite(*leaseInvite, user != nil)
+	res, err := brevo.SendEmailInvite(*leaseInvite, token, user != nil)
 	if err != nil {
 		log.Println(res, err.Error())
 		utils.SendError(c, http.StatusInternalServerError, utils.FailedSendEmail, err)
@@ -325,6 +335,41 @@ func CancelInvite(c *gin.Context) {
 	c.Status(http.StatusNoContent)
 }
 
+// ResendInvite godoc
+//
+//	@Summary		Resend invite
+//	@Description	Send the pending lease invite email again with a new link and extend its expiry date
+//	@Tags			property
+//	@Accept			json
+//	@Produce		json
+//	@Param			property_id	path		string				true	"Property ID"
+//	@Success		200			{object}	models.IdResponse	"Invite ID"
+//	@Failure		403			{object}	utils.Error			"Property is not yours"
+//	@Failure		404			{object}	utils.Error			"No pending lease"
+//	@Failure		500			{object}	utils.Error			"Failed to send email"
+//	@Security		Bearer
+//	@Router			/owner/properties/{property_id}/resend-invite/ [post]
+func ResendInvite(c *gin.Context) {
+	invite, _ := c.MustGet("invite").(db.LeaseInviteModel)
+
+	token := utils.GenerateToken()
+	leaseInvite := database.RenewLeaseInvite(invite.ID, utils.HashToken(token), utils.Now())
+	if leaseInvite == nil {
+		utils.SendError(c, http.StatusNotFound, utils.NoLeaseInvite, nil)
+		return
+	}
+
+	user := database.GetUserByEmail(leaseInvite.TenantEmail)
+	res, err := brevo.SendEmailInvite(*leaseInvite, token, user != nil)
+	if err != nil {
+		log.Println(res, err.Error())
+		utils.SendError(c, http.StatusInternalServerError, utils.FailedSendEmail, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.IdResponse{ID: leaseInvite.ID})
+}
+
 // ArchiveProperty godoc
 //
 //	@Summary		Toggle archive property by ID
@@ -122,12 +122,17 @@ func BuildTestPropertyWithInventory(id string) db.PropertyModel {
 func BuildTestLeaseInvite() db.LeaseInviteModel {
 	return db.LeaseInviteModel{
 		InnerLeaseInvite: db.InnerLeaseInvite{
-			ID:          "1",
-			PropertyID:  "1",
-			TenantEmail: "test@example.com",
-			CreatedAt:   time.Now(),
-			StartDate:   time.Now(),
-			EndDate:     utils.Ptr(time.Now().Add(time.Hour)),
+			ID:                  "1",
+			PropertyID:          "1",
+			TenantEmail:         "test@example.com",
+			TokenHash:           utils.HashToken("1"),
+			CreatedAt:           time.Now(),
+			StartDate:           time.Now(),
+			EndDate:             utils.Ptr(time.Now().Add(time.Hour)),
+			ExpiresAt:           time.Now().Add(time.Hour),
+			RentalPricePerMonth: 500,
+			DepositPrice:        1000,
+			PaymentDay:          1,
 		},
 		RelationsLeaseInvite: db.RelationsLeaseInvite{
 			Property: &db.PropertyModel{
@@ -369,16 +374,74 @@ func TestCreateProperty_AlreadyExists(t *testing.T) {
 	assert.Equal(t, utils.PropertyAlreadyExists, resp.Code)
 }
 
+func mockInviteToken(t *testing.T) {
+	generate := utils.GenerateToken
+	utils.GenerateToken = func() string { return "1" }
+	t.Cleanup(func() { utils.GenerateToken = generate })
+}
+
+// mockNow pins the time seen by the controllers, so that the mocks expect the timestamps they write.
+func mockNow(t *testing.T) time.Time {
+	now := time.Now()
+	clock := utils.Now
+	utils.Now = func() time.Time { return now }
+	t.Cleanup(func() { utils.Now = clock })
+	return now
+}
+
 func TestInviteTenant(t *testing.T) {
 	c, mock, ensure := services.ConnectDBTest()
 	defer ensure(t)
+	mockInviteToken(t)
+	now := mockNow(t)
+
+	property := BuildTestProperty("1")
+	leaseInvite := BuildTestLeaseInvite()
+	mock.Property.Expect(database.MockGetPropertyByID(c)).Returns(property)
+	mock.Lease.Expect(database.MockGetCurrentActiveLeaseByProperty(c)).Errors(db.ErrNotFound)
+	mock.User.Expect(database.MockGetUserByEmail(c)).Errors(db.ErrNotFound)
+	mock.LeaseInvite.Expect(database.MockDeleteExpiredLeaseInvite(c, now)).Returns(db.LeaseInviteModel{})
+	mock.LeaseInvite.Expect(database.MockCreateLeaseInvite(c, leaseInvite, now)).Returns(leaseInvite)
+
+	reqBody := models.InviteRequest{
+		TenantEmail: leaseInvite.TenantEmail,
+		StartDate:   leaseInvite.StartDate,
+		EndDate:     leaseInvite.InnerLeaseInvite.EndDate,
+	}
+	b, err := json.Marshal(reqBody)
+	require.NoError(t, err)
+
+	r := router.TestRoutes()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/v1/owner/properties/1/send-invite/", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Oauth.claims.id", "1")
+	req.Header.Set("Oauth.claims.role", string(db.RoleOwner))
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusCreated, w.Code)
+	var resp db.InnerLeaseInvite
+	err = json.Unmarshal(w.Body.Bytes(), &resp)
+	require.NoError(t, err)
+	assert.JSONEq(t, resp.ID, leaseInvite.ID)
+}
+
+func TestInviteTenant_AfterExpiry(t *testing.T) {
+	c, mock, ensure := services.ConnectDBTest()
+	defer ensure(t)
+	mockInviteToken(t)
+	now := mockNow(t)
 
 	property := BuildTestProperty("1")
+	expired := BuildTestLeaseInvite()
+	expired.ID = "2"
+	expired.ExpiresAt = now.Add(-time.Hour)
 	leaseInvite := BuildTestLeaseInvite()
 	mock.Property.Expect(database.MockGetPropertyByID(c)).Returns(property)
 	mock.Lease.Expect(database.MockGetCurrentActiveLeaseByProperty(c)).Errors(db.ErrNotFound)
 	mock.User.Expect(database.MockGetUserByEmail(c)).Errors(db.ErrNotFound)
-	mock.LeaseInvite.Expect(database.MockCreateLeaseInvite(c, leaseInvite)).Returns(leaseInvite)
+	mock.LeaseInvite.Expect(database.MockDeleteExpiredLeaseInvite(c, now)).Returns(expired)
+	mock.LeaseInvite.Expect(database.MockCreateLeaseInvite(c, leaseInvite, now)).Returns(leaseInvite)
 
 	reqBody := models.InviteRequest{
 		TenantEmail: leaseInvite.TenantEmail,
@@ -525,17 +588,20 @@ func TestInviteTenant_PropertyNotAvailable(t *testing.T) {
 func TestInviteTenant_AlreadyExists(t *testing.T) {
 	c, mock, ensure := services.ConnectDBTest()
 	defer ensure(t)
+	mockInviteToken(t)
+	now := mockNow(t)
 
 	property := BuildTestProperty("1")
 	leaseInvite := BuildTestLeaseInvite()
 	mock.Property.Expect(database.MockGetPropertyByID(c)).Returns(property)
 	mock.Lease.Expect(database.MockGetCurrentActiveLeaseByProperty(c)).Errors(db.ErrNotFound)
 	mock.User.Expect(database.MockGetUserByEmail(c)).Errors(db.ErrNotFound)
-	mock.LeaseInvite.Expect(database.MockCreateLeaseInvite(c, leaseInvite)).Errors(&protocol.UserFacingError{
+	mock.LeaseInvite.Expect(database.MockDeleteExpiredLeaseInvite(c, now)).Returns(db.LeaseInviteModel{})
+	mock.LeaseInvite.Expect(database.MockCreateLeaseInvite(c, leaseInvite, now)).Errors(&protocol.UserFacingError{
 		IsPanic:   false,
 		ErrorCode: "P2002", // https://www.prisma.io/docs/orm/reference/error-reference
 		Meta: protocol.Meta{
-			Target: []any{"tenant_email"},
+			Target: []any{"property_id"},
 		},
 		Message: "Unique constraint failed",
 	})
@@ -639,7 +705,7 @@ func TestGetPropertyPicture(t *testing.T) {
 	defer ensure(t)
 
 	property := BuildTestProperty("1")
-	image := BuildTestImage("1", "data:image/jpeg;base64,b3Vp")
+	image := BuildTestImage("1", "data:image/jpeg;base64,/9j/4A==")
 	mock.Property.Expect(database.MockGetPropertyByID(c)).Returns(property)
 	mock.Image.Expect(database.MockGetImageByID(c)).Returns(image)
 
@@ -722,13 +788,14 @@ func TestUpdatePropertyPicture(t *testing.T) {
 	defer ensure(t)
 
 	property := BuildTestProperty("1")
-	image := BuildTestImage("1", "data:image/jpeg;base64,b3Vp")
+	image := BuildTestImage("1", "data:image/jpeg;base64,/9j/4A==")
 	mock.Property.Expect(database.MockGetPropertyByID(c)).Returns(property)
+	ExpectStorageUsage(c, mock, 0, 0)
 	mock.Image.Expect(database.MockCreateImage(c, image)).Returns(image)
 	mock.Property.Expect(database.MockUpdatePropertyPicture(c)).Returns(property)
 
 	reqBody := models.ImageRequest{
-		Data: "data:image/jpeg;base64,b3Vp",
+		Data: "data:image/jpeg;base64,/9j/4A==",
 	}
 	b, err := json.Marshal(reqBody)
 	require.NoError(t, err)
@@ -809,7 +876,7 @@ func TestUpdatePropertyPicture_PropertyNotFound(t *testing.T) {
 	mock.Property.Expect(database.MockGetPropertyByID(c)).Errors(db.ErrNotFound)
 
 	reqBody := models.ImageRequest{
-		Data: "data:image/jpeg;base64,b3Vp",
+		Data: "data:image/jpeg;base64,/9j/4A==",
 	}
 	b, err := json.Marshal(reqBody)
 	require.NoError(t, err)
@@ -834,13 +901,14 @@ func TestUpdatePropertyPicture_FailedLinkImage(t *testing.T) {
 	defer ensure(t)
 
 	property := BuildTestProperty("1")
-	image := BuildTestImage("1", "data:image/jpeg;base64,b3Vp")
+	image := BuildTestImage("1", "data:image/jpeg;base64,/9j/4A==")
 	mock.Property.Expect(database.MockGetPropertyByID(c)).Returns(property)
+	ExpectStorageUsage(c, mock, 0, 0)
 	mock.Image.Expect(database.MockCreateImage(c, image)).Returns(image)
 	mock.Property.Expect(database.MockUpdatePropertyPicture(c)).Errors(db.ErrNotFound)
 
 	reqBody := models.ImageRequest{
-		Data: "data:image/jpeg;base64,b3Vp",
+		Data: "data:image/jpeg;base64,/9j/4A==",
 	}
 	b, err := json.Marshal(reqBody)
 	require.NoError(t, err)
@@ -0,0 +1,69 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"keyz/backend/models"
+	"keyz/backend/services/database"
+	"keyz/backend/services/storage"
+	"keyz/backend/utils"
+)
+
+// checkFileSize sends a file-too-large error when a file is bigger than the size limit.
+func checkFileSize(c *gin.Context, data []byte, limit int) bool {
+	if len(data) > limit {
+		utils.SendError(c, http.StatusRequestEntityTooLarge, utils.FileTooLarge, errors.New("max size is "+strconv.Itoa(limit)+" bytes"))
+		return false
+	}
+	return true
+}
+
+// sendLoadError sends a file-not-found error when a blob is missing from the storage, or else the given error code.
+func sendLoadError(c *gin.Context, code utils.ErrorCode, err error) {
+	if errors.Is(err, storage.ErrNotFound) {
+		utils.SendError(c, http.StatusNotFound, utils.FileNotFound, err)
+		return
+	}
+	utils.SendError(c, http.StatusInternalServerError, code, err)
+}
+
+// getStorageUsage returns the storage used by the images and the documents of the properties of an owner.
+func getStorageUsage(ownerID string) models.StorageUsageResponse {
+	return models.NewStorageUsageResponse(
+		database.GetOwnerImagesSize(ownerID),
+		database.GetOwnerDocumentsSize(ownerID),
+		storage.OwnerQuota,
+		storage.MaxImageSize,
+		storage.MaxDocumentSize,
+	)
+}
+
+// checkStorageQuota sends a storage-quota-exceeded error when storing size more bytes would exceed the quota of the owner.
+func checkStorageQuota(c *gin.Context, ownerID string, size int) bool {
+	if size > getStorageUsage(ownerID).Available {
+		utils.SendError(c, http.StatusForbidden, utils.StorageQuotaExceeded, nil)
+		return false
+	}
+	return true
+}
+
+// GetOwnerStorage godoc
+//
+//	@Summary		Get storage usage
+//	@Description	Get the storage used by the images and the documents of the properties of the owner, with their storage quota
+//	@Description	and the maximum size of each image and document, in bytes. Previous versions and deleted documents count until they are purged.
+//	@Tags			owner
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	models.StorageUsageResponse	"Storage usage"
+//	@Failure		403	{object}	utils.Error					"Not an owner"
+//	@Failure		500
+//	@Security		Bearer
+//	@Router			/owner/storage/ [get]
+func GetOwnerStorage(c *gin.Context) {
+	claims := utils.GetClaims(c)
+	c.JSON(http.StatusOK, getStorageUsage(claims["id"]))
+}
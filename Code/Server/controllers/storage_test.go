@@ -0,0 +1,296 @@
+package controllers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"keyz/backend/models"
+	"keyz/backend/prisma/db"
+	"keyz/backend/router"
+	"keyz/backend/services"
+	"keyz/backend/services/database"
+	"keyz/backend/services/storage"
+	"keyz/backend/utils"
+)
+
+// ExpectStorageUsage mocks the storage used by the images and the documents of the owner "1".
+func ExpectStorageUsage(c *services.PrismaDB, m *db.Mock, images int, documents int) {
+	m.Image.Expect(database.MockGetOwnerImagesSize(c)).ReturnsMany([]db.ImageModel{
+		{InnerImage: db.InnerImage{Size: images}},
+	})
+	m.Document.Expect(database.MockGetOwnerDocumentsSize(c)).ReturnsMany([]db.DocumentModel{
+		{InnerDocument: db.InnerDocument{Size: documents}},
+	})
+}
+
+func TestGetOwnerStorage(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	ExpectStorageUsage(c, m, 3000, 2000)
+
+	r := router.TestRoutes()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/v1/owner/storage/", nil)
+	req.Header.Set("Oauth.claims.id", "1")
+	req.Header.Set("Oauth.claims.role", string(db.RoleOwner))
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp models.StorageUsageResponse
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	require.NoError(t, err)
+	assert.Equal(t, 3000, resp.Images)
+	assert.Equal(t, 2000, resp.Documents)
+	assert.Equal(t, 5000, resp.Used)
+	assert.Equal(t, storage.OwnerQuota, resp.Quota)
+	assert.Equal(t, storage.OwnerQuota-5000, resp.Available)
+	assert.Equal(t, storage.MaxImageSize, resp.MaxImageSize)
+	assert.Equal(t, storage.MaxDocumentSize, resp.MaxDocumentSize)
+}
+
+func TestGetOwnerStorage_NotAnOwner(t *testing.T) {
+	r := router.TestRoutes()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/v1/owner/storage/", nil)
+	req.Header.Set("Oauth.claims.id", "1")
+	req.Header.Set("Oauth.claims.role", string(db.RoleTenant))
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusForbidden, w.Code)
+	var resp utils.Error
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	require.NoError(t, err)
+	assert.Equal(t, utils.NotAnOwner, resp.Code)
+}
+
+func TestUploadDocument_FileTooLarge(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	maxDocumentSize := storage.MaxDocumentSize
+	defer func() { storage.MaxDocumentSize = maxDocumentSize }()
+	storage.MaxDocumentSize = 10
+
+	m.Property.Expect(database.MockGetPropertyByID(c)).Returns(BuildTestProperty("1"))
+	m.Lease.Expect(database.MockGetCurrentActiveLeaseByProperty(c)).ReturnsMany([]db.LeaseModel{BuildTestLease("1")})
+
+	b, err := json.Marshal(models.DocumentRequest{
+		Name: "Test Document",
+		Data: "data:application/pdf;base64,JVBERi0xLjQgVGVzdCBEYXRh", // Base64 encoded "%PDF-1.4 Test Data"
+	})
+	require.NoError(t, err)
+
+	r := router.TestRoutes()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/v1/owner/properties/1/leases/current/docs/", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Oauth.claims.id", "1")
+	req.Header.Set("Oauth.claims.role", string(db.RoleOwner))
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	var resp utils.Error
+	err = json.Unmarshal(w.Body.Bytes(), &resp)
+	require.NoError(t, err)
+	assert.Equal(t, utils.FileTooLarge, resp.Code)
+}
+
+func TestUploadDocument_QuotaExceeded(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	m.Property.Expect(database.MockGetPropertyByID(c)).Returns(BuildTestProperty("1"))
+	m.Lease.Expect(database.MockGetCurrentActiveLeaseByProperty(c)).ReturnsMany([]db.LeaseModel{BuildTestLease("1")})
+	ExpectStorageUsage(c, m, storage.OwnerQuota-10, 0)
+
+	b, err := json.Marshal(models.DocumentRequest{
+		Name: "Test Document",
+		Data: "data:application/pdf;base64,JVBERi0xLjQgVGVzdCBEYXRh", // Base64 encoded "%PDF-1.4 Test Data"
+	})
+	require.NoError(t, err)
+
+	r := router.TestRoutes()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/v1/owner/properties/1/leases/current/docs/", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Oauth.claims.id", "1")
+	req.Header.Set("Oauth.claims.role", string(db.RoleOwner))
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusForbidden, w.Code)
+	var resp utils.Error
+	err = json.Unmarshal(w.Body.Bytes(), &resp)
+	require.NoError(t, err)
+	assert.Equal(t, utils.StorageQuotaExceeded, resp.Code)
+}
+
+func TestUpdatePropertyPicture_FileTooLarge(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	maxImageSize := storage.MaxImageSize
+	defer func() { storage.MaxImageSize = maxImageSize }()
+	storage.MaxImageSize = 2
+
+	m.Property.Expect(database.MockGetPropertyByID(c)).Returns(BuildTestProperty("1"))
+
+	b, err := json.Marshal(models.ImageRequest{Data: "data:image/jpeg;base64,/9j/4A=="})
+	require.NoError(t, err)
+
+	r := router.TestRoutes()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPut, "/v1/owner/properties/1/picture/", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Oauth.claims.id", "1")
+	req.Header.Set("Oauth.claims.role", string(db.RoleOwner))
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	var resp utils.Error
+	err = json.Unmarshal(w.Body.Bytes(), &resp)
+	require.NoError(t, err)
+	assert.Equal(t, utils.FileTooLarge, resp.Code)
+}
+
+func TestCreateDamage_PictureTooLarge(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	maxImageSize := storage.MaxImageSize
+	defer func() { storage.MaxImageSize = maxImageSize }()
+	storage.MaxImageSize = 2
+
+	m.Lease.Expect(database.MockGetLeaseByID(c)).Returns(BuildTestLease("1"))
+	m.Room.Expect(database.MockGetRoomByID(c)).Returns(BuildTestRoom("1", "1"))
+
+	b, err := json.Marshal(models.DamageRequest{
+		RoomID:   "1",
+		Comment:  "Broken window",
+		Priority: db.PriorityHigh,
+		Pictures: []string{"data:image/jpeg;base64,/9j/4A=="},
+	})
+	require.NoError(t, err)
+
+	r := router.TestRoutes()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/v1/tenant/leases/1/damages/", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Oauth.claims.id", "1")
+	req.Header.Set("Oauth.claims.role", string(db.RoleTenant))
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	var resp utils.Error
+	err = json.Unmarshal(w.Body.Bytes(), &resp)
+	require.NoError(t, err)
+	assert.Equal(t, utils.FileTooLarge, resp.Code)
+}
+
+func TestCreateDamage_QuotaExceeded(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	m.Lease.Expect(database.MockGetLeaseByID(c)).Returns(BuildTestLease("1"))
+	m.Room.Expect(database.MockGetRoomByID(c)).Returns(BuildTestRoom("1", "1"))
+	ExpectStorageUsage(c, m, storage.OwnerQuota-4, 0)
+
+	b, err := json.Marshal(models.DamageRequest{
+		RoomID:   "1",
+		Comment:  "Broken window",
+		Priority: db.PriorityHigh,
+		Pictures: []string{"data:image/jpeg;base64,/9j/4A==", "data:image/jpeg;base64,/9j/4A=="},
+	})
+	require.NoError(t, err)
+
+	r := router.TestRoutes()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/v1/tenant/leases/1/damages/", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Oauth.claims.id", "1")
+	req.Header.Set("Oauth.claims.role", string(db.RoleTenant))
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusForbidden, w.Code)
+	var resp utils.Error
+	err = json.Unmarshal(w.Body.Bytes(), &resp)
+	require.NoError(t, err)
+	assert.Equal(t, utils.StorageQuotaExceeded, resp.Code)
+}
+
+func buildInventoryReportRequestBody(t *testing.T) []byte {
+	b, err := json.Marshal(models.InventoryReportRequest{
+		Type: db.ReportTypeStart,
+		Rooms: []models.RoomStateRequest{{
+			ID:          "1",
+			State:       db.StateGood,
+			Cleanliness: db.CleanlinessClean,
+			Note:        "Test note",
+			Pictures:    []string{"data:image/jpeg;base64,/9j/4A=="},
+			Furnitures: []models.FurnitureStateRequest{{
+				ID:          "1",
+				State:       db.StateGood,
+				Cleanliness: db.CleanlinessClean,
+				Note:        "Test note",
+				Pictures:    []string{"data:image/jpeg;base64,/9j/4A=="},
+			}},
+		}},
+	})
+	require.NoError(t, err)
+	return b
+}
+
+func TestCreateInventoryReport_PictureTooLarge(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	maxImageSize := storage.MaxImageSize
+	defer func() { storage.MaxImageSize = maxImageSize }()
+	storage.MaxImageSize = 2
+
+	m.Property.Expect(database.MockGetPropertyByID(c)).Returns(BuildTestProperty("1"))
+	m.Lease.Expect(database.MockGetCurrentActiveLeaseByProperty(c)).ReturnsMany([]db.LeaseModel{BuildTestLease("1")})
+
+	r := router.TestRoutes()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/v1/owner/properties/1/leases/current/inventory-reports/", bytes.NewReader(buildInventoryReportRequestBody(t)))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Oauth.claims.id", "1")
+	req.Header.Set("Oauth.claims.role", string(db.RoleOwner))
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	var resp utils.Error
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	require.NoError(t, err)
+	assert.Equal(t, utils.FileTooLarge, resp.Code)
+}
+
+func TestCreateInventoryReport_QuotaExceeded(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	m.Property.Expect(database.MockGetPropertyByID(c)).Returns(BuildTestProperty("1"))
+	m.Lease.Expect(database.MockGetCurrentActiveLeaseByProperty(c)).ReturnsMany([]db.LeaseModel{BuildTestLease("1")})
+	// the 2 pictures and the PDF embedding them need 16 bytes
+	ExpectStorageUsage(c, m, storage.OwnerQuota-15, 0)
+
+	r := router.TestRoutes()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/v1/owner/properties/1/leases/current/inventory-reports/", bytes.NewReader(buildInventoryReportRequestBody(t)))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Oauth.claims.id", "1")
+	req.Header.Set("Oauth.claims.role", string(db.RoleOwner))
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusForbidden, w.Code)
+	var resp utils.Error
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	require.NoError(t, err)
+	assert.Equal(t, utils.StorageQuotaExceeded, resp.Code)
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"keyz/backend/models"
 	"keyz/backend/services/database"
+	"keyz/backend/services/storage"
 	"keyz/backend/utils"
 )
 
@@ -195,6 +196,7 @@ func GetCurrentUserProfilePicture(c *gin.Context) {
 //	@Failure		400		{object}	utils.Error			"Missing fields or bad base64 string"
 //	@Failure		401		{object}	utils.Error			"Unauthorized"
 //	@Failure		404		{object}	utils.Error			"User not found"
+//	@Failure		413		{object}	utils.Error			"File too large"
 //	@Failure		500
 //	@Security		Bearer
 //	@Router			/profile/picture/ [put]
@@ -218,6 +220,9 @@ func UpdateCurrentUserProfilePicture(c *gin.Context) {
 		utils.SendError(c, http.StatusBadRequest, utils.BadBase64OrUnsupportedType, nil)
 		return
 	}
+	if !checkFileSize(c, *image.InnerImage.Data, storage.MaxImageSize) {
+		return
+	}
 	newImage := database.CreateImage(*image)
 
 	newUser := database.UpdateUserPicture(*user, newImage)
@@ -5,6 +5,7 @@ go 1.22.3
 toolchain go1.23.5
 
 require (
+	github.com/gabriel-vasile/mimetype v1.4.5
 	github.com/getbrevo/brevo-go v1.1.2
 	github.com/gin-contrib/cors v1.7.2
 	github.com/gin-gonic/gin v1.10.0
@@ -20,6 +21,7 @@ require (
 	github.com/swaggo/swag v1.16.3
 	github.com/ulule/limiter/v3 v3.11.2
 	golang.org/x/crypto v0.27.0
+	golang.org/x/image v0.12.0
 )
 
 require (
@@ -32,7 +34,6 @@ require (
 	github.com/cloudwego/base64x v0.1.4 // indirect
 	github.com/cloudwego/iasm v0.2.0 // indirect
 	github.com/davecgh/go-spew v1.1.1 // indirect
-	github.com/gabriel-vasile/mimetype v1.4.5 // indirect
 	github.com/gin-contrib/sse v0.1.0 // indirect
 	github.com/go-openapi/jsonpointer v0.19.5 // indirect
 	github.com/go-openapi/jsonreference v0.19.6 // indirect
@@ -3,11 +3,14 @@ package main
 import (
 	"log"
 	"os"
+	"time"
 
 	"github.com/joho/godotenv"
 	"keyz/backend/docs"
 	"keyz/backend/router"
 	"keyz/backend/services"
+	"keyz/backend/services/scheduler"
+	"keyz/backend/services/storage"
 )
 
 //	@title			Keyz API
@@ -59,6 +62,15 @@ func mainFunc() int {
 
 	docs.SwaggerInfo.Host = os.Getenv("PUBLIC_URL")
 
+	if err := storage.Connect(); err != nil {
+		log.Println(err)
+		return 1
+	}
+	if err := storage.LoadLimits(); err != nil {
+		log.Println(err)
+		return 1
+	}
+
 	db, err := services.ConnectDB()
 	if err != nil {
 		log.Println(err)
@@ -67,6 +79,8 @@ func mainFunc() int {
 	log.Println("Connected to database, starting server...")
 	defer func() { _ = db.Client.Disconnect() }()
 
+	scheduler.Start(time.Hour)
+
 	err = router.Routes().Run(":" + os.Getenv("PORT"))
 	if err != nil {
 		log.Println(err)
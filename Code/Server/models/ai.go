@@ -1,5 +1,13 @@
 package models
 
+import (
+	"errors"
+	"slices"
+	"strings"
+
+	"keyz/backend/prisma/db"
+)
+
 type SummarizeRequest struct {
 	Type     string   `binding:"required,oneof=room furniture"               json:"type"`
 	Id       string   `binding:"required"                                    json:"id"`
@@ -23,3 +31,44 @@ type CompareResponse struct {
 	Cleanliness string `json:"cleanliness"`
 	Note        string `json:"note"`
 }
+
+type DamageTriage struct {
+	Priority    db.Priority       `json:"priority"`
+	Category    db.DamageCategory `json:"category"`
+	Responsible db.Payer          `json:"responsible"`
+	Advice      string            `json:"advice"`
+	TriagedAt   db.DateTime       `json:"triaged_at"`
+}
+
+var damageCategories = []db.DamageCategory{
+	db.DamageCategoryPlumbing, db.DamageCategoryElectrical, db.DamageCategoryHeating, db.DamageCategoryAppliance,
+	db.DamageCategoryStructural, db.DamageCategoryOpenings, db.DamageCategoryFurniture, db.DamageCategoryPests,
+	db.DamageCategoryCosmetic, db.DamageCategoryOther,
+}
+
+// ParseDamageTriage parses a ChatGPT triage response of the form <priority>|<category>|<responsible>|<advice>.
+func ParseDamageTriage(res string) (DamageTriage, error) {
+	splitted := strings.SplitN(strings.TrimSpace(res), "|", 4)
+	if len(splitted) == 2 && splitted[0] == "error" {
+		return DamageTriage{}, errors.New(splitted[1])
+	} else if len(splitted) != 4 {
+		return DamageTriage{}, errors.New("unexpected response format from ChatGPT")
+	}
+
+	triage := DamageTriage{
+		Priority:    db.Priority(splitted[0]),
+		Category:    db.DamageCategory(splitted[1]),
+		Responsible: db.Payer(splitted[2]),
+		Advice:      strings.TrimSpace(splitted[3]),
+	}
+	if !slices.Contains([]db.Priority{db.PriorityLow, db.PriorityMedium, db.PriorityHigh, db.PriorityUrgent}, triage.Priority) {
+		return DamageTriage{}, errors.New("invalid priority from ChatGPT: " + splitted[0])
+	}
+	if !slices.Contains(damageCategories, triage.Category) {
+		return DamageTriage{}, errors.New("invalid category from ChatGPT: " + splitted[1])
+	}
+	if !slices.Contains([]db.Payer{db.PayerOwner, db.PayerTenant, db.PayerInsurance}, triage.Responsible) {
+		return DamageTriage{}, errors.New("invalid responsible party from ChatGPT: " + splitted[2])
+	}
+	return triage, nil
+}
@@ -0,0 +1,43 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"keyz/backend/models"
+	"keyz/backend/prisma/db"
+)
+
+func TestParseDamageTriage(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		triage, err := models.ParseDamageTriage("urgent|plumbing|owner|Close the water valve | and call a plumber.\n")
+
+		require.NoError(t, err)
+		assert.Equal(t, db.PriorityUrgent, triage.Priority)
+		assert.Equal(t, db.DamageCategoryPlumbing, triage.Category)
+		assert.Equal(t, db.PayerOwner, triage.Responsible)
+		assert.Equal(t, "Close the water valve | and call a plumber.", triage.Advice)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		_, err := models.ParseDamageTriage("error|The images do not show any damage.")
+
+		require.Error(t, err)
+		assert.Equal(t, "The images do not show any damage.", err.Error())
+	})
+
+	t.Run("BadFormat", func(t *testing.T) {
+		_, err := models.ParseDamageTriage("This looks like a water leak.")
+		require.Error(t, err)
+	})
+
+	t.Run("InvalidValues", func(t *testing.T) {
+		_, err := models.ParseDamageTriage("critical|plumbing|owner|Advice")
+		require.Error(t, err)
+		_, err = models.ParseDamageTriage("high|roof|owner|Advice")
+		require.Error(t, err)
+		_, err = models.ParseDamageTriage("high|plumbing|neighbour|Advice")
+		require.Error(t, err)
+	})
+}
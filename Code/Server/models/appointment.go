@@ -0,0 +1,54 @@
+package models
+
+import (
+	"keyz/backend/prisma/db"
+)
+
+type AppointmentSlotRequest struct {
+	StartAt         db.DateTime `binding:"required"                json:"start_at"`
+	DurationMinutes int         `binding:"required,min=15,max=480" json:"duration_minutes"`
+}
+
+func (r *AppointmentSlotRequest) ToDbDamageAppointment(proposedBy db.AppointmentParty) db.DamageAppointmentModel {
+	return db.DamageAppointmentModel{
+		InnerDamageAppointment: db.InnerDamageAppointment{
+			StartAt:         r.StartAt,
+			DurationMinutes: r.DurationMinutes,
+			ProposedBy:      proposedBy,
+		},
+	}
+}
+
+type AppointmentProposalRequest struct {
+	Slots []AppointmentSlotRequest `binding:"required,min=1,max=5,dive" json:"slots"`
+}
+
+type AppointmentResponse struct {
+	ID              string               `json:"id"`
+	DamageID        string               `json:"damage_id"`
+	StartAt         db.DateTime          `json:"start_at"`
+	EndAt           db.DateTime          `json:"end_at"`
+	DurationMinutes int                  `json:"duration_minutes"`
+	Status          db.AppointmentStatus `json:"status"`
+	ProposedBy      db.AppointmentParty  `json:"proposed_by"`
+	CreatedAt       db.DateTime          `json:"created_at"`
+	ConfirmedAt     *db.DateTime         `json:"confirmed_at,omitempty"`
+}
+
+func (r *AppointmentResponse) FromDbDamageAppointment(model db.DamageAppointmentModel) {
+	r.ID = model.ID
+	r.DamageID = model.DamageID
+	r.StartAt = model.StartAt
+	r.EndAt = model.EndAt()
+	r.DurationMinutes = model.DurationMinutes
+	r.Status = model.Status
+	r.ProposedBy = model.ProposedBy
+	r.CreatedAt = model.CreatedAt
+	r.ConfirmedAt = model.InnerDamageAppointment.ConfirmedAt
+}
+
+func DbDamageAppointmentToResponse(model db.DamageAppointmentModel) AppointmentResponse {
+	var resp AppointmentResponse
+	resp.FromDbDamageAppointment(model)
+	return resp
+}
@@ -0,0 +1,47 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"keyz/backend/models"
+	"keyz/backend/prisma/db"
+)
+
+func TestAppointmentSlotRequest(t *testing.T) {
+	req := models.AppointmentSlotRequest{
+		StartAt:         time.Now(),
+		DurationMinutes: 90,
+	}
+
+	appointment := req.ToDbDamageAppointment(db.AppointmentPartyContractor)
+	assert.Equal(t, req.StartAt, appointment.StartAt)
+	assert.Equal(t, req.DurationMinutes, appointment.DurationMinutes)
+	assert.Equal(t, db.AppointmentPartyContractor, appointment.ProposedBy)
+}
+
+func TestAppointmentResponse(t *testing.T) {
+	start := time.Now().Truncate(time.Minute)
+	model := db.DamageAppointmentModel{
+		InnerDamageAppointment: db.InnerDamageAppointment{
+			ID:              "1",
+			DamageID:        "1",
+			StartAt:         start,
+			DurationMinutes: 90,
+			Status:          db.AppointmentStatusConfirmed,
+			ProposedBy:      db.AppointmentPartyTenant,
+			CreatedAt:       time.Now(),
+			ConfirmedAt:     &start,
+		},
+	}
+
+	resp := models.DbDamageAppointmentToResponse(model)
+	assert.Equal(t, model.ID, resp.ID)
+	assert.Equal(t, model.DamageID, resp.DamageID)
+	assert.Equal(t, start, resp.StartAt)
+	assert.Equal(t, start.Add(90*time.Minute), resp.EndAt)
+	assert.Equal(t, model.Status, resp.Status)
+	assert.Equal(t, model.ProposedBy, resp.ProposedBy)
+	assert.Equal(t, model.InnerDamageAppointment.ConfirmedAt, resp.ConfirmedAt)
+}
@@ -0,0 +1,119 @@
+package models
+
+import (
+	"keyz/backend/prisma/db"
+	"keyz/backend/utils"
+)
+
+type ContractorRequest struct {
+	Name    string   `binding:"required"       json:"name"`
+	Company *string  `json:"company,omitempty"`
+	Trade   db.Trade `binding:"required,trade" json:"trade"`
+	Email   string   `binding:"required,email" json:"email"`
+	Phone   *string  `json:"phone,omitempty"`
+	Notes   *string  `json:"notes,omitempty"`
+}
+
+func (r *ContractorRequest) ToDbContractor() db.ContractorModel {
+	return db.ContractorModel{
+		InnerContractor: db.InnerContractor{
+			Name:    r.Name,
+			Company: r.Company,
+			Trade:   r.Trade,
+			Email:   r.Email,
+			Phone:   r.Phone,
+			Notes:   r.Notes,
+		},
+	}
+}
+
+type ContractorUpdateRequest struct {
+	Name    *string   `json:"name,omitempty"`
+	Company *string   `json:"company,omitempty"`
+	Trade   *db.Trade `binding:"omitempty,trade" json:"trade,omitempty"`
+	Email   *string   `binding:"omitempty,email" json:"email,omitempty"`
+	Phone   *string   `json:"phone,omitempty"`
+	Notes   *string   `json:"notes,omitempty"`
+}
+
+type ContractorResponse struct {
+	ID        string      `json:"id"`
+	OwnerID   string      `json:"owner_id"`
+	Name      string      `json:"name"`
+	Company   *string     `json:"company"`
+	Trade     db.Trade    `json:"trade"`
+	Email     string      `json:"email"`
+	Phone     *string     `json:"phone"`
+	Notes     *string     `json:"notes"`
+	CreatedAt db.DateTime `json:"created_at"`
+}
+
+func (r *ContractorResponse) FromDbContractor(model db.ContractorModel) {
+	r.ID = model.ID
+	r.OwnerID = model.OwnerID
+	r.Name = model.Name
+	r.Company = model.InnerContractor.Company
+	r.Trade = model.Trade
+	r.Email = model.Email
+	r.Phone = model.InnerContractor.Phone
+	r.Notes = model.InnerContractor.Notes
+	r.CreatedAt = model.CreatedAt
+}
+
+func DbContractorToResponse(model db.ContractorModel) ContractorResponse {
+	var resp ContractorResponse
+	resp.FromDbContractor(model)
+	return resp
+}
+
+type DamageAssignRequest struct {
+	ContractorID     string      `binding:"required" json:"contractor_id"`
+	InterventionDate db.DateTime `binding:"required" json:"intervention_date"`
+	SendLink         bool        `json:"send_link"`
+}
+
+// ContractorJobResponse is what a contractor sees through the link sent by email.
+type ContractorJobResponse struct {
+	DamageID         string       `json:"damage_id"`
+	ContractorName   string       `json:"contractor_name"`
+	OwnerName        string       `json:"owner_name"`
+	PropertyName     string       `json:"property_name"`
+	Address          string       `json:"address"`
+	ApartmentNumber  *string      `json:"apartment_number,omitempty"`
+	City             string       `json:"city"`
+	PostalCode       string       `json:"postal_code"`
+	RoomName         string       `json:"room_name"`
+	Comment          string       `json:"comment"`
+	Priority         db.Priority  `json:"priority"`
+	InterventionDate *db.DateTime `json:"intervention_date"`
+	DoneAt           *db.DateTime `json:"done_at"`
+
+	Pictures     []string              `json:"pictures"`
+	Appointments []AppointmentResponse `json:"appointments"`
+}
+
+func (r *ContractorJobResponse) FromDbDamage(model db.DamageModel) {
+	property := model.Lease().Property()
+	r.DamageID = model.ID
+	if contractor, ok := model.Contractor(); ok {
+		r.ContractorName = contractor.Name
+	}
+	r.OwnerName = property.Owner().Name()
+	r.PropertyName = property.Name
+	r.Address = property.Address
+	r.ApartmentNumber = property.InnerProperty.ApartmentNumber
+	r.City = property.City
+	r.PostalCode = property.PostalCode
+	r.RoomName = model.Room().Name
+	r.Comment = model.Comment
+	r.Priority = model.Priority
+	r.InterventionDate = model.InnerDamage.InterventionDate
+	r.DoneAt = model.InnerDamage.ContractorDoneAt
+
+	for _, picture := range model.Pictures() {
+		r.Pictures = append(r.Pictures, DbImageToResponse(picture).Data)
+	}
+	if model.RelationsDamage.Appointments != nil {
+		r.Appointments = utils.Map(model.Appointments(), DbDamageAppointmentToResponse)
+	}
+}
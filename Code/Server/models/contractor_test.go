@@ -0,0 +1,68 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"keyz/backend/models"
+	"keyz/backend/prisma/db"
+)
+
+func TestContractorRequest(t *testing.T) {
+	company := "Plumbing Bros"
+	req := models.ContractorRequest{
+		Name:    "Mario",
+		Company: &company,
+		Trade:   db.TradePlumber,
+		Email:   "mario@example.com",
+	}
+
+	contractor := req.ToDbContractor()
+	assert.Equal(t, req.Name, contractor.Name)
+	assert.Equal(t, req.Company, contractor.InnerContractor.Company)
+	assert.Equal(t, req.Trade, contractor.Trade)
+	assert.Equal(t, req.Email, contractor.Email)
+}
+
+func TestContractorResponse(t *testing.T) {
+	model := db.ContractorModel{
+		InnerContractor: db.InnerContractor{
+			ID:      "1",
+			OwnerID: "1",
+			Name:    "Mario",
+			Trade:   db.TradePlumber,
+			Email:   "mario@example.com",
+		},
+	}
+
+	resp := models.DbContractorToResponse(model)
+	assert.Equal(t, model.ID, resp.ID)
+	assert.Equal(t, model.OwnerID, resp.OwnerID)
+	assert.Equal(t, model.Name, resp.Name)
+	assert.Equal(t, model.Trade, resp.Trade)
+	assert.Equal(t, model.Email, resp.Email)
+}
+
+func TestContractorJobResponse(t *testing.T) {
+	damage := BuildTestDamage("1")
+	damage.RelationsDamage.Lease.RelationsLease.Property.RelationsProperty.Owner = &db.UserModel{
+		InnerUser: db.InnerUser{
+			Firstname: "Jane",
+			Lastname:  "Doe",
+		},
+	}
+	damage.RelationsDamage.Contractor = &db.ContractorModel{
+		InnerContractor: db.InnerContractor{
+			Name: "Mario",
+		},
+	}
+
+	var resp models.ContractorJobResponse
+	resp.FromDbDamage(damage)
+	assert.Equal(t, damage.ID, resp.DamageID)
+	assert.Equal(t, "Mario", resp.ContractorName)
+	assert.Equal(t, "Jane Doe", resp.OwnerName)
+	assert.Equal(t, "Test Property", resp.PropertyName)
+	assert.Equal(t, "Living Room", resp.RoomName)
+	assert.Len(t, resp.Pictures, 1)
+}
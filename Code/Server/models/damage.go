@@ -1,22 +1,27 @@
 package models
 
 import (
+	"time"
+
 	"keyz/backend/prisma/db"
+	"keyz/backend/utils"
 )
 
 type DamageRequest struct {
-	Comment  string      `binding:"required"                    json:"comment"`
-	Priority db.Priority `binding:"required,priority"           json:"priority"`
-	RoomID   string      `binding:"required"                    json:"room_id"`
-	Pictures []string    `binding:"max=5,dive,required,datauri" json:"pictures"`
+	Comment     string      `binding:"required"                    json:"comment"`
+	Priority    db.Priority `binding:"required,priority"           json:"priority"`
+	RoomID      string      `binding:"required"                    json:"room_id"`
+	FurnitureID *string     `json:"furniture_id,omitempty"`
+	Pictures    []string    `binding:"max=5,dive,required,datauri" json:"pictures"`
 }
 
 func (r *DamageRequest) ToDbDamage() db.DamageModel {
 	return db.DamageModel{
 		InnerDamage: db.InnerDamage{
-			Comment:  r.Comment,
-			Priority: r.Priority,
-			RoomID:   r.RoomID,
+			Comment:     r.Comment,
+			Priority:    r.Priority,
+			RoomID:      r.RoomID,
+			FurnitureID: r.FurnitureID,
 		},
 	}
 }
@@ -26,12 +31,55 @@ type DamageOwnerUpdateRequest struct {
 	FixPlannedAt *db.DateTime `json:"fix_planned_at,omitempty"`
 }
 
+type DamageCostRequest struct {
+	EstimatedCost *float64  `binding:"omitempty,gte=0" json:"estimated_cost,omitempty"`
+	ActualCost    *float64  `binding:"omitempty,gte=0" json:"actual_cost,omitempty"`
+	Payer         *db.Payer `binding:"omitempty,payer" json:"payer,omitempty"`
+}
+
+type DamageReasonRequest struct {
+	Reason string `binding:"required" json:"reason"`
+}
+
+type DamageDismissRequest struct {
+	Dismissal db.Dismissal `binding:"required,dismissal" json:"dismissal"`
+	Reason    string       `binding:"required"           json:"reason"`
+}
+
 type DamageTenantUpdateRequest struct {
 	Comment     *string      `json:"comment,omitempty"`
 	Priority    *db.Priority `json:"priority,omitempty"`
 	AddPictures []string     `binding:"max=5,dive,required,datauri" json:"add_pictures,omitempty"`
 }
 
+type DamageSort string
+
+const (
+	DamageSortCreatedAt DamageSort = "created_at"
+	DamageSortUpdatedAt DamageSort = "updated_at"
+	DamageSortPriority  DamageSort = "priority"
+	DamageSortFixedAt   DamageSort = "fixed_at"
+)
+
+type DamageListQuery struct {
+	Fixed       *bool          `form:"fixed"`
+	Closed      *bool          `form:"closed"`
+	FixStatus   []db.FixStatus `binding:"dive,fixStatus" form:"fix_status"`
+	Priority    []db.Priority  `binding:"dive,priority"  form:"priority"`
+	RoomID      *string        `form:"room_id"`
+	Read        *bool          `form:"read"`
+	CreatedFrom *time.Time     `form:"created_from"`
+	CreatedTo   *time.Time     `form:"created_to"`
+	FixedFrom   *time.Time     `form:"fixed_from"`
+	FixedTo     *time.Time     `form:"fixed_to"`
+
+	Sort         DamageSort   `binding:"omitempty,oneof=created_at updated_at priority fixed_at" form:"sort"`
+	Order        db.SortOrder `binding:"omitempty,oneof=asc desc"                                form:"order"`
+	Cursor       *string      `form:"cursor"`
+	Limit        int          `binding:"omitempty,min=1,max=100"                                 form:"limit"`
+	OmitPictures bool         `form:"omit_pictures"`
+}
+
 type DamageResponse struct {
 	ID           string `json:"id"`
 	LeaseID      string `json:"lease_id"`
@@ -41,6 +89,9 @@ type DamageResponse struct {
 	RoomID       string `json:"room_id"`
 	RoomName     string `json:"room_name"`
 
+	FurnitureID   *string `json:"furniture_id,omitempty"`
+	FurnitureName *string `json:"furniture_name,omitempty"`
+
 	Comment      string       `json:"comment"`
 	Priority     db.Priority  `json:"priority"`
 	Read         bool         `json:"read"`
@@ -50,7 +101,30 @@ type DamageResponse struct {
 	FixPlannedAt *db.DateTime `json:"fix_planned_at"`
 	FixedAt      *db.DateTime `json:"fixed_at,omitempty"`
 
-	Pictures []string `json:"pictures"`
+	FixRejectedAt     *db.DateTime `json:"fix_rejected_at,omitempty"`
+	FixRejectedReason *string      `json:"fix_rejected_reason,omitempty"`
+	ReopenedAt        *db.DateTime `json:"reopened_at,omitempty"`
+	ReopenedReason    *string      `json:"reopened_reason,omitempty"`
+
+	WithdrawnAt     *db.DateTime  `json:"withdrawn_at,omitempty"`
+	DismissedAt     *db.DateTime  `json:"dismissed_at,omitempty"`
+	Dismissal       *db.Dismissal `json:"dismissal,omitempty"`
+	DismissedReason *string       `json:"dismissed_reason,omitempty"`
+
+	ContractorID     *string      `json:"contractor_id,omitempty"`
+	InterventionDate *db.DateTime `json:"intervention_date,omitempty"`
+	ContractorDoneAt *db.DateTime `json:"contractor_done_at,omitempty"`
+
+	EstimatedCost *float64  `json:"estimated_cost"`
+	ActualCost    *float64  `json:"actual_cost"`
+	Payer         *db.Payer `json:"payer"`
+
+	// AI triage of the damage, filled in the background after its creation
+	Triage *DamageTriage `json:"triage,omitempty"`
+
+	Pictures []string                `json:"pictures"`
+	Messages []DamageMessageResponse `json:"messages,omitempty"`
+	History  []DamageEventResponse   `json:"history,omitempty"`
 }
 
 func (i *DamageResponse) FromDbDamage(model db.DamageModel) {
@@ -61,6 +135,10 @@ func (i *DamageResponse) FromDbDamage(model db.DamageModel) {
 	i.PropertyName = model.Lease().Property().Name
 	i.RoomID = model.RoomID
 	i.RoomName = model.Room().Name
+	i.FurnitureID = model.InnerDamage.FurnitureID
+	if furniture, ok := model.Furniture(); ok {
+		i.FurnitureName = &furniture.Name
+	}
 
 	i.Comment = model.Comment
 	i.Priority = model.Priority
@@ -71,9 +149,45 @@ func (i *DamageResponse) FromDbDamage(model db.DamageModel) {
 	i.FixStatus = model.FixStatus()
 	i.FixPlannedAt = model.InnerDamage.FixPlannedAt
 	i.FixedAt = model.InnerDamage.FixedAt
+	i.FixRejectedAt = model.InnerDamage.FixRejectedAt
+	i.FixRejectedReason = model.InnerDamage.FixRejectedReason
+	i.ReopenedAt = model.InnerDamage.ReopenedAt
+	i.ReopenedReason = model.InnerDamage.ReopenedReason
+	i.WithdrawnAt = model.InnerDamage.WithdrawnAt
+	i.DismissedAt = model.InnerDamage.DismissedAt
+	i.Dismissal = model.InnerDamage.Dismissal
+	i.DismissedReason = model.InnerDamage.DismissedReason
 
-	for _, picture := range model.Pictures() {
-		i.Pictures = append(i.Pictures, DbImageToResponse(picture).Data)
+	i.ContractorID = model.InnerDamage.ContractorID
+	i.InterventionDate = model.InnerDamage.InterventionDate
+	i.ContractorDoneAt = model.InnerDamage.ContractorDoneAt
+
+	i.EstimatedCost = model.InnerDamage.EstimatedCost
+	i.ActualCost = model.InnerDamage.ActualCost
+	i.Payer = model.InnerDamage.Payer
+
+	if triagedAt, ok := model.TriagedAt(); ok {
+		i.Triage = &DamageTriage{
+			TriagedAt: triagedAt,
+		}
+		i.Triage.Priority, _ = model.TriagePriority()
+		i.Triage.Category, _ = model.TriageCategory()
+		i.Triage.Responsible, _ = model.TriageResponsible()
+		i.Triage.Advice, _ = model.TriageAdvice()
+	}
+
+	// pictures can be omitted from damage lists
+	if model.RelationsDamage.Pictures != nil {
+		for _, picture := range model.Pictures() {
+			i.Pictures = append(i.Pictures, DbImageToResponse(picture).Data)
+		}
+	}
+	// messages and history are only fetched when getting a single damage
+	if model.RelationsDamage.Messages != nil {
+		i.Messages = utils.Map(model.Messages(), DbDamageMessageToResponse)
+	}
+	if model.RelationsDamage.Events != nil {
+		i.History = utils.Map(model.Events(), DbDamageEventToResponse)
 	}
 }
 
@@ -82,3 +196,74 @@ func DbDamageToResponse(pc db.DamageModel) DamageResponse {
 	resp.FromDbDamage(pc)
 	return resp
 }
+
+type DamageMessageRequest struct {
+	Content  string   `binding:"required"                    json:"content"`
+	Pictures []string `binding:"max=5,dive,required,datauri" json:"pictures"`
+}
+
+func (r *DamageMessageRequest) ToDbDamageMessage() db.DamageMessageModel {
+	return db.DamageMessageModel{
+		InnerDamageMessage: db.InnerDamageMessage{
+			Content: r.Content,
+		},
+	}
+}
+
+type DamageMessageResponse struct {
+	ID         string      `json:"id"`
+	AuthorID   string      `json:"author_id"`
+	AuthorName string      `json:"author_name"`
+	AuthorRole db.Role     `json:"author_role"`
+	Content    string      `json:"content"`
+	CreatedAt  db.DateTime `json:"created_at"`
+
+	Pictures []string `json:"pictures"`
+}
+
+func (i *DamageMessageResponse) FromDbDamageMessage(model db.DamageMessageModel) {
+	i.ID = model.ID
+	i.AuthorID = model.AuthorID
+	i.AuthorName = model.Author().Name()
+	i.AuthorRole = model.Author().Role
+	i.Content = model.Content
+	i.CreatedAt = model.CreatedAt
+
+	for _, picture := range model.Pictures() {
+		i.Pictures = append(i.Pictures, DbImageToResponse(picture).Data)
+	}
+}
+
+func DbDamageMessageToResponse(pc db.DamageMessageModel) DamageMessageResponse {
+	var resp DamageMessageResponse
+	resp.FromDbDamageMessage(pc)
+	return resp
+}
+
+type DamageEventResponse struct {
+	ID        string      `json:"id"`
+	Field     string      `json:"field"`
+	OldValue  *string     `json:"old_value"`
+	NewValue  *string     `json:"new_value"`
+	ActorID   string      `json:"actor_id"`
+	ActorName string      `json:"actor_name"`
+	ActorRole db.Role     `json:"actor_role"`
+	CreatedAt db.DateTime `json:"created_at"`
+}
+
+func (i *DamageEventResponse) FromDbDamageEvent(model db.DamageEventModel) {
+	i.ID = model.ID
+	i.Field = model.Field
+	i.OldValue = model.InnerDamageEvent.OldValue
+	i.NewValue = model.InnerDamageEvent.NewValue
+	i.ActorID = model.ActorID
+	i.ActorName = model.Actor().Name()
+	i.ActorRole = model.ActorRole
+	i.CreatedAt = model.CreatedAt
+}
+
+func DbDamageEventToResponse(pc db.DamageEventModel) DamageEventResponse {
+	var resp DamageEventResponse
+	resp.FromDbDamageEvent(pc)
+	return resp
+}
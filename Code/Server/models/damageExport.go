@@ -0,0 +1,122 @@
+package models
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strconv"
+	"strings"
+
+	"keyz/backend/prisma/db"
+)
+
+type DamageExportFormat string
+
+const (
+	DamageExportCSV DamageExportFormat = "csv"
+	DamageExportPDF DamageExportFormat = "pdf"
+)
+
+type DamageExportQuery struct {
+	Format DamageExportFormat `binding:"required,oneof=csv pdf" form:"format"`
+}
+
+// DamageExportRow is a damage formatted as text, shared by the CSV and PDF exports.
+type DamageExportRow struct {
+	ID            string
+	TenantName    string
+	RoomName      string
+	FurnitureName string
+	Comment       string
+	Priority      string
+	FixStatus     string
+	CreatedAt     string
+	FixPlannedAt  string
+	FixedAt       string
+	EstimatedCost string
+	ActualCost    string
+	Payer         string
+}
+
+var DamageExportHeader = []string{
+	"ID", "Tenant", "Room", "Furniture", "Comment", "Priority", "Fix status",
+	"Created at", "Fix planned at", "Fixed at", "Estimated cost", "Actual cost", "Payer",
+}
+
+func formatExportDate(date *db.DateTime) string {
+	if date == nil {
+		return ""
+	}
+	return date.Format("2006-01-02")
+}
+
+func formatExportCost(cost *float64) string {
+	if cost == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*cost, 'f', 2, 64)
+}
+
+func (r *DamageExportRow) FromDbDamage(model db.DamageModel) {
+	r.ID = model.ID
+	r.TenantName = model.Lease().Tenant().Name()
+	r.RoomName = model.Room().Name
+	if furniture, ok := model.Furniture(); ok {
+		r.FurnitureName = furniture.Name
+	}
+	r.Comment = model.Comment
+	r.Priority = string(model.Priority)
+	r.FixStatus = string(model.FixStatus())
+	r.CreatedAt = formatExportDate(&model.CreatedAt)
+	r.FixPlannedAt = formatExportDate(model.InnerDamage.FixPlannedAt)
+	r.FixedAt = formatExportDate(model.InnerDamage.FixedAt)
+	r.EstimatedCost = formatExportCost(model.InnerDamage.EstimatedCost)
+	r.ActualCost = formatExportCost(model.InnerDamage.ActualCost)
+	if payer, ok := model.Payer(); ok {
+		r.Payer = string(payer)
+	}
+}
+
+func DbDamageToExportRow(model db.DamageModel) DamageExportRow {
+	var row DamageExportRow
+	row.FromDbDamage(model)
+	return row
+}
+
+func (r DamageExportRow) Record() []string {
+	return []string{
+		r.ID, r.TenantName, r.RoomName, r.FurnitureName, r.Comment, r.Priority, r.FixStatus,
+		r.CreatedAt, r.FixPlannedAt, r.FixedAt, r.EstimatedCost, r.ActualCost, r.Payer,
+	}
+}
+
+// escapeCSVField prevents spreadsheets from running a field written by a user as a formula.
+func escapeCSVField(field string) string {
+	if field != "" && strings.ContainsRune("=+-@\t\r", rune(field[0])) {
+		return "'" + field
+	}
+	return field
+}
+
+// CSVRecord is the record of the row with its fields escaped for spreadsheets.
+func (r DamageExportRow) CSVRecord() []string {
+	record := r.Record()
+	for i, field := range record {
+		record[i] = escapeCSVField(field)
+	}
+	return record
+}
+
+func NewDamageExportCSV(damages []db.DamageModel) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write(DamageExportHeader); err != nil {
+		return nil, err
+	}
+	for _, damage := range damages {
+		if err := w.Write(DbDamageToExportRow(damage).CSVRecord()); err != nil {
+			return nil, err
+		}
+	}
+	w.Flush()
+	return buf.Bytes(), w.Error()
+}
@@ -0,0 +1,84 @@
+package models_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"keyz/backend/models"
+	"keyz/backend/prisma/db"
+)
+
+func buildExportDamage() db.DamageModel {
+	created := time.Date(2025, 3, 4, 10, 0, 0, 0, time.UTC)
+	fixed := time.Date(2025, 3, 10, 10, 0, 0, 0, time.UTC)
+	estimated := 80.0
+	actual := 95.5
+	payer := db.PayerOwner
+	return db.DamageModel{
+		InnerDamage: db.InnerDamage{
+			ID:            "1",
+			Comment:       "Broken window, \"urgent\"",
+			Priority:      db.PriorityUrgent,
+			CreatedAt:     created,
+			FixedAt:       &fixed,
+			FixedOwner:    true,
+			FixedTenant:   true,
+			EstimatedCost: &estimated,
+			ActualCost:    &actual,
+			Payer:         &payer,
+		},
+		RelationsDamage: db.RelationsDamage{
+			Lease: &db.LeaseModel{
+				RelationsLease: db.RelationsLease{
+					Tenant: &db.UserModel{InnerUser: db.InnerUser{Firstname: "John", Lastname: "Doe"}},
+				},
+			},
+			Room:      &db.RoomModel{InnerRoom: db.InnerRoom{Name: "Bedroom"}},
+			Furniture: &db.FurnitureModel{InnerFurniture: db.InnerFurniture{Name: "Wardrobe"}},
+		},
+	}
+}
+
+func TestDamageExportRow(t *testing.T) {
+	row := models.DbDamageToExportRow(buildExportDamage())
+	assert.Equal(t, "1", row.ID)
+	assert.Equal(t, "John Doe", row.TenantName)
+	assert.Equal(t, "Bedroom", row.RoomName)
+	assert.Equal(t, "Wardrobe", row.FurnitureName)
+	assert.Equal(t, string(db.PriorityUrgent), row.Priority)
+	assert.Equal(t, string(db.FixStatusFixed), row.FixStatus)
+	assert.Equal(t, "2025-03-04", row.CreatedAt)
+	assert.Empty(t, row.FixPlannedAt)
+	assert.Equal(t, "2025-03-10", row.FixedAt)
+	assert.Equal(t, "80.00", row.EstimatedCost)
+	assert.Equal(t, "95.50", row.ActualCost)
+	assert.Equal(t, string(db.PayerOwner), row.Payer)
+	assert.Len(t, row.Record(), len(models.DamageExportHeader))
+}
+
+func TestNewDamageExportCSV(t *testing.T) {
+	data, err := models.NewDamageExportCSV([]db.DamageModel{buildExportDamage()})
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Len(t, lines, 2)
+	assert.Equal(t, strings.Join(models.DamageExportHeader, ","), lines[0])
+	assert.Equal(t, `1,John Doe,Bedroom,Wardrobe,"Broken window, ""urgent""",urgent,fixed,2025-03-04,,2025-03-10,80.00,95.50,owner`, lines[1])
+}
+
+func TestNewDamageExportCSV_Formula(t *testing.T) {
+	damage := buildExportDamage()
+	damage.Comment = "=HYPERLINK(\"http://example.com\")"
+	damage.RelationsDamage.Room = &db.RoomModel{InnerRoom: db.InnerRoom{Name: "+Bedroom"}}
+	damage.RelationsDamage.Furniture = &db.FurnitureModel{InnerFurniture: db.InnerFurniture{Name: "@Wardrobe"}}
+	damage.RelationsDamage.Lease.RelationsLease.Tenant = &db.UserModel{InnerUser: db.InnerUser{Firstname: "-John", Lastname: "Doe-Smith"}}
+	data, err := models.NewDamageExportCSV([]db.DamageModel{damage})
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Len(t, lines, 2)
+	assert.Equal(t, `1,'-John Doe-Smith,'+Bedroom,'@Wardrobe,"'=HYPERLINK(""http://example.com"")",urgent,fixed,2025-03-04,,2025-03-10,80.00,95.50,owner`, lines[1])
+}
@@ -2,19 +2,24 @@ package models_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"keyz/backend/models"
 	"keyz/backend/prisma/db"
+	"keyz/backend/utils"
 )
 
 func TestDamageRequest(t *testing.T) {
 	t.Run("ToDbDamage", func(t *testing.T) {
+		furnitureId := "furniture123"
 		req := models.DamageRequest{
-			Comment:  "Test Comment",
-			Priority: db.PriorityHigh,
-			RoomID:   "room123",
-			Pictures: []string{"base64image1", "base64image2"},
+			Comment:     "Test Comment",
+			Priority:    db.PriorityHigh,
+			RoomID:      "room123",
+			FurnitureID: &furnitureId,
+			Pictures:    []string{"base64image1", "base64image2"},
 		}
 
 		dbDamage := req.ToDbDamage()
@@ -22,6 +27,7 @@ func TestDamageRequest(t *testing.T) {
 		assert.Equal(t, req.Comment, dbDamage.Comment)
 		assert.Equal(t, req.Priority, dbDamage.Priority)
 		assert.Equal(t, req.RoomID, dbDamage.RoomID)
+		assert.Equal(t, req.FurnitureID, dbDamage.InnerDamage.FurnitureID)
 	})
 }
 
@@ -65,7 +71,7 @@ func BuildTestDamage(id string) db.DamageModel {
 				{
 					InnerImage: db.InnerImage{
 						ID:   "1",
-						Data: db.Bytes("base64image1"),
+						Data: utils.Ptr(db.Bytes("base64image1")),
 						Type: db.ImageTypeJpeg,
 					},
 				},
@@ -94,6 +100,88 @@ func TestDamageResponse(t *testing.T) {
 		assert.Nil(t, resp.FixPlannedAt)
 		assert.Nil(t, resp.FixedAt)
 		assert.Len(t, resp.Pictures, 1)
+		assert.Nil(t, resp.Messages)
+		assert.Nil(t, resp.FurnitureID)
+		assert.Nil(t, resp.FurnitureName)
+		assert.Nil(t, resp.Triage)
+	})
+
+	t.Run("FromDbDamageWithTriage", func(t *testing.T) {
+		priority, category, responsible, advice := db.PriorityUrgent, db.DamageCategoryPlumbing, db.PayerOwner, "Close the water valve."
+		triagedAt := time.Now()
+		mockDamageModel := BuildTestDamage("1")
+		mockDamageModel.InnerDamage.TriagePriority = &priority
+		mockDamageModel.InnerDamage.TriageCategory = &category
+		mockDamageModel.InnerDamage.TriageResponsible = &responsible
+		mockDamageModel.InnerDamage.TriageAdvice = &advice
+		mockDamageModel.InnerDamage.TriagedAt = &triagedAt
+
+		resp := models.DbDamageToResponse(mockDamageModel)
+
+		require.NotNil(t, resp.Triage)
+		assert.Equal(t, priority, resp.Triage.Priority)
+		assert.Equal(t, category, resp.Triage.Category)
+		assert.Equal(t, responsible, resp.Triage.Responsible)
+		assert.Equal(t, advice, resp.Triage.Advice)
+		assert.Equal(t, triagedAt, resp.Triage.TriagedAt)
+	})
+
+	t.Run("FromDbDamageWithoutPictures", func(t *testing.T) {
+		mockDamageModel := BuildTestDamage("1")
+		mockDamageModel.RelationsDamage.Pictures = nil
+
+		resp := models.DbDamageToResponse(mockDamageModel)
+
+		assert.Equal(t, mockDamageModel.ID, resp.ID)
+		assert.Nil(t, resp.Pictures)
+	})
+
+	t.Run("FromDbDamageWithFurniture", func(t *testing.T) {
+		furnitureId := "1"
+		mockDamageModel := BuildTestDamage("1")
+		mockDamageModel.InnerDamage.FurnitureID = &furnitureId
+		mockDamageModel.RelationsDamage.Furniture = &db.FurnitureModel{
+			InnerFurniture: db.InnerFurniture{
+				ID:     furnitureId,
+				Name:   "Oven",
+				RoomID: "1",
+			},
+		}
+
+		resp := models.DbDamageToResponse(mockDamageModel)
+
+		assert.Equal(t, &furnitureId, resp.FurnitureID)
+		assert.Equal(t, "Oven", *resp.FurnitureName)
+	})
+
+	t.Run("FromDbDamageWithMessages", func(t *testing.T) {
+		mockDamageModel := BuildTestDamage("1")
+		mockDamageModel.RelationsDamage.Messages = []db.DamageMessageModel{{
+			InnerDamageMessage: db.InnerDamageMessage{
+				ID:       "1",
+				DamageID: "1",
+				AuthorID: "2",
+				Content:  "Plumber comes on Monday",
+			},
+			RelationsDamageMessage: db.RelationsDamageMessage{
+				Author: &db.UserModel{
+					InnerUser: db.InnerUser{
+						ID:        "2",
+						Firstname: "Jane",
+						Lastname:  "Doe",
+						Role:      db.RoleOwner,
+					},
+				},
+				Pictures: []db.ImageModel{},
+			},
+		}}
+
+		resp := models.DbDamageToResponse(mockDamageModel)
+
+		assert.Len(t, resp.Messages, 1)
+		assert.Equal(t, "Jane Doe", resp.Messages[0].AuthorName)
+		assert.Equal(t, db.RoleOwner, resp.Messages[0].AuthorRole)
+		assert.Equal(t, "Plumber comes on Monday", resp.Messages[0].Content)
 	})
 
 	t.Run("DbDamageToResponse", func(t *testing.T) {
@@ -116,3 +204,157 @@ func TestDamageResponse(t *testing.T) {
 		assert.Len(t, resp.Pictures, 1)
 	})
 }
+
+func TestDamageMessageRequest(t *testing.T) {
+	req := models.DamageMessageRequest{Content: "Any news?"}
+
+	message := req.ToDbDamageMessage()
+	assert.Equal(t, req.Content, message.Content)
+}
+
+func TestDamageChanges(t *testing.T) {
+	damage := BuildTestDamage("1")
+	damage.Read = false
+
+	t.Run("NoChanges", func(t *testing.T) {
+		assert.Empty(t, damage.Changes(damage))
+	})
+
+	t.Run("ReadAndPlanned", func(t *testing.T) {
+		updated := damage
+		updated.Read = true
+		planned := time.Date(2025, 7, 1, 9, 0, 0, 0, time.UTC)
+		updated.FixPlannedAt = &planned
+
+		events := damage.Changes(updated)
+		assert.Len(t, events, 2)
+		assert.Equal(t, db.DamageFieldRead, events[0].Field)
+		assert.Equal(t, "false", *events[0].InnerDamageEvent.OldValue)
+		assert.Equal(t, "true", *events[0].InnerDamageEvent.NewValue)
+		assert.Equal(t, db.DamageFieldFixPlannedAt, events[1].Field)
+		assert.Nil(t, events[1].InnerDamageEvent.OldValue)
+		assert.Equal(t, "2025-07-01T09:00:00Z", *events[1].InnerDamageEvent.NewValue)
+	})
+
+	t.Run("Fixed", func(t *testing.T) {
+		damage := damage
+		damage.FixedTenant = true
+		updated := damage
+		updated.FixedOwner = true
+		updated.FixedAt = &db.DateTime{}
+
+		events := damage.Changes(updated)
+		assert.Len(t, events, 2)
+		assert.Equal(t, db.DamageFieldFixedOwner, events[0].Field)
+		assert.Equal(t, db.DamageFieldFixedAt, events[1].Field)
+	})
+
+	t.Run("Assigned", func(t *testing.T) {
+		updated := damage
+		contractorId := "1"
+		updated.ContractorID = &contractorId
+		date := time.Date(2025, 7, 2, 14, 0, 0, 0, time.UTC)
+		updated.FixPlannedAt = &date
+		updated.InterventionDate = &date
+
+		events := damage.Changes(updated)
+		assert.Len(t, events, 3)
+		assert.Equal(t, db.DamageFieldFixPlannedAt, events[0].Field)
+		assert.Equal(t, db.DamageFieldContractorID, events[1].Field)
+		assert.Equal(t, "1", *events[1].InnerDamageEvent.NewValue)
+		assert.Equal(t, db.DamageFieldInterventionDate, events[2].Field)
+	})
+}
+
+func TestDamageFixStatus(t *testing.T) {
+	now := time.Now()
+
+	t.Run("FixRejected", func(t *testing.T) {
+		damage := BuildTestDamage("1")
+		damage.FixRejectedAt = &now
+		assert.Equal(t, db.FixStatusFixRejected, damage.FixStatus())
+	})
+
+	t.Run("Reopened", func(t *testing.T) {
+		damage := BuildTestDamage("1")
+		damage.ReopenedAt = &now
+		assert.Equal(t, db.FixStatusReopened, damage.FixStatus())
+	})
+
+	t.Run("PlannedAfterReopen", func(t *testing.T) {
+		damage := BuildTestDamage("1")
+		damage.ReopenedAt = &now
+		damage.FixPlannedAt = &now
+		assert.Equal(t, db.FixStatusPlanned, damage.FixStatus())
+	})
+
+	t.Run("Withdrawn", func(t *testing.T) {
+		damage := BuildTestDamage("1")
+		damage.WithdrawnAt = &now
+		assert.True(t, damage.IsClosed())
+		assert.Equal(t, db.FixStatusWithdrawn, damage.FixStatus())
+	})
+
+	t.Run("Dismissed", func(t *testing.T) {
+		damage := BuildTestDamage("1")
+		damage.FixPlannedAt = &now
+		damage.DismissedAt = &now
+		assert.True(t, damage.IsClosed())
+		assert.Equal(t, db.FixStatusDismissed, damage.FixStatus())
+	})
+
+	t.Run("AwaitingAfterRejection", func(t *testing.T) {
+		damage := BuildTestDamage("1")
+		damage.FixRejectedAt = &now
+		damage.FixedTenant = true
+		assert.Equal(t, db.FixStatusAwaitingOwnerConfirmation, damage.FixStatus())
+	})
+}
+
+func TestDamageCanRejectFix(t *testing.T) {
+	now := time.Now()
+	damage := BuildTestDamage("1")
+	assert.False(t, damage.CanRejectFix(db.RoleOwner))
+	assert.False(t, damage.CanRejectFix(db.RoleTenant))
+
+	damage.FixedTenant = true
+	assert.True(t, damage.CanRejectFix(db.RoleOwner))
+	assert.False(t, damage.CanRejectFix(db.RoleTenant))
+
+	damage.FixedOwner = true
+	assert.False(t, damage.CanRejectFix(db.RoleOwner))
+	assert.False(t, damage.CanRejectFix(db.RoleTenant))
+
+	damage.FixedOwner = false
+	damage.DismissedAt = &now
+	assert.False(t, damage.CanRejectFix(db.RoleOwner))
+}
+
+func TestDamageEventResponse(t *testing.T) {
+	event := db.DamageEventModel{
+		InnerDamageEvent: db.InnerDamageEvent{
+			ID:        "1",
+			DamageID:  "1",
+			ActorID:   "2",
+			ActorRole: db.RoleOwner,
+			Field:     db.DamageFieldRead,
+		},
+		RelationsDamageEvent: db.RelationsDamageEvent{
+			Actor: &db.UserModel{
+				InnerUser: db.InnerUser{
+					Firstname: "Jane",
+					Lastname:  "Doe",
+				},
+			},
+		},
+	}
+
+	damage := BuildTestDamage("1")
+	damage.RelationsDamage.Events = []db.DamageEventModel{event}
+	resp := models.DbDamageToResponse(damage)
+
+	assert.Len(t, resp.History, 1)
+	assert.Equal(t, event.Field, resp.History[0].Field)
+	assert.Equal(t, "Jane Doe", resp.History[0].ActorName)
+	assert.Equal(t, db.RoleOwner, resp.History[0].ActorRole)
+}
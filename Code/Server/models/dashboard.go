@@ -24,6 +24,10 @@ import (
 // If no reminders:
 // 13. Good news! All your properties are in good condition and have no pending issues.
 //
+// 14. Home insurance certificate of the tenant of property X expires in X days. Ask the tenant for the renewed certificate.
+// 15. Tenant of property X has no valid home insurance certificate. Ask the tenant to upload it.
+// 16. You have used X% of your storage. Empty the document trash or delete the documents you no longer need.
+//
 // TODO later:
 // - Room Y in property X was rated 'broken' or 'needsRepair' in the latest report. Schedule a check and mark it as 'fixed' in the inventory report.
 // - Furniture 'Z' in property X was rated 'broken' 'broken' or 'needsRepair' in the latest report. Schedule a check and mark it as 'fixed' in the inventory report.
@@ -399,6 +403,83 @@ func GetReminderAllGood(lang string) Reminder {
 	return ReminderAllGood.Get(lang)
 }
 
+// 14
+var ReminderInsuranceExpiring = reminderModel{
+	"en": {
+		Id:       "14",
+		Priority: db.PriorityMedium,
+		Title:    "Home insurance certificate of the tenant of property {property} expires in {days} days.",
+		Advice:   "Ask the tenant for the renewed certificate.",
+		Link:     "/real-property/details/{property_id}",
+	},
+	"fr": {
+		Id:       "14",
+		Priority: db.PriorityMedium,
+		Title:    "L'attestation d'assurance habitation du locataire de la propriété {property} expire dans {days} jours.",
+		Advice:   "Demandez au locataire l'attestation renouvelée.",
+		Link:     "/real-property/details/{property_id}",
+	},
+}
+
+func GetReminderInsuranceExpiring(lang string, property db.PropertyModel, days int) Reminder {
+	return ReminderInsuranceExpiring.Get(lang).WithPlaceholders(map[string]string{
+		"property":    property.Name,
+		"days":        strconv.Itoa(days),
+		"property_id": property.ID,
+	})
+}
+
+// 15
+var ReminderInsuranceMissing = reminderModel{
+	"en": {
+		Id:       "15",
+		Priority: db.PriorityHigh,
+		Title:    "Tenant of property {property} has no valid home insurance certificate.",
+		Advice:   "Ask the tenant to upload their home insurance certificate.",
+		Link:     "/real-property/details/{property_id}",
+	},
+	"fr": {
+		Id:       "15",
+		Priority: db.PriorityHigh,
+		Title:    "Le locataire de la propriété {property} n'a pas d'attestation d'assurance habitation valide.",
+		Advice:   "Demandez au locataire de déposer son attestation d'assurance habitation.",
+		Link:     "/real-property/details/{property_id}",
+	},
+}
+
+func GetReminderInsuranceMissing(lang string, property db.PropertyModel) Reminder {
+	return ReminderInsuranceMissing.Get(lang).WithPlaceholders(map[string]string{
+		"property":    property.Name,
+		"property_id": property.ID,
+	})
+}
+
+// 16
+var ReminderStorageAlmostFull = reminderModel{
+	"en": {
+		Id:       "16",
+		Priority: db.PriorityMedium,
+		Title:    "You have used {percent}% of your storage ({used} MB of {quota} MB).",
+		Advice:   "Empty the document trash or delete the documents you no longer need to free up space.",
+		Link:     "",
+	},
+	"fr": {
+		Id:       "16",
+		Priority: db.PriorityMedium,
+		Title:    "Vous avez utilisé {percent} % de votre espace de stockage ({used} Mo sur {quota} Mo).",
+		Advice:   "Videz la corbeille des documents ou supprimez les documents dont vous n'avez plus besoin pour libérer de l'espace.",
+		Link:     "",
+	},
+}
+
+func GetReminderStorageAlmostFull(lang string, usage StorageUsageResponse) Reminder {
+	return ReminderStorageAlmostFull.Get(lang).WithPlaceholders(map[string]string{
+		"percent": strconv.Itoa(usage.Used * 100 / usage.Quota),
+		"used":    strconv.Itoa(usage.Used >> 20),
+		"quota":   strconv.Itoa(usage.Quota >> 20),
+	})
+}
+
 type DashboardProperties struct {
 	NbrTotal          int                `json:"nbr_total"`
 	NbrArchived       int                `json:"nbr_archived"`
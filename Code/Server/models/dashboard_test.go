@@ -34,6 +34,7 @@ func TestReminder(t *testing.T) {
 }
 
 func TestGetReminders(t *testing.T) {
+
 	t.Run("LeaseEnding", func(t *testing.T) {
 		r := models.GetReminderLeaseEnding("en", BuildTestProperty("1"), 30)
 		assert.Equal(t, "Lease of property Test is ending in 30 days.", r.Title)
@@ -98,6 +99,21 @@ func TestGetReminders(t *testing.T) {
 		r := models.GetReminderAllGood("en")
 		assert.Equal(t, "Good news!", r.Title)
 	})
+
+	t.Run("InsuranceExpiring", func(t *testing.T) {
+		r := models.GetReminderInsuranceExpiring("en", BuildTestProperty("1"), 10)
+		assert.Equal(t, "Home insurance certificate of the tenant of property Test expires in 10 days.", r.Title)
+	})
+
+	t.Run("InsuranceMissing", func(t *testing.T) {
+		r := models.GetReminderInsuranceMissing("fr", BuildTestProperty("1"))
+		assert.Equal(t, "Le locataire de la propriété Test n'a pas d'attestation d'assurance habitation valide.", r.Title)
+	})
+
+	t.Run("StorageAlmostFull", func(t *testing.T) {
+		r := models.GetReminderStorageAlmostFull("en", models.NewStorageUsageResponse(900<<20, 20<<20, 1000<<20, 10<<20, 25<<20))
+		assert.Equal(t, "You have used 92% of your storage (920 MB of 1000 MB).", r.Title)
+	})
 }
 
 func TestOpenDamageResponse(t *testing.T) {
@@ -0,0 +1,87 @@
+package models
+
+import (
+	"time"
+
+	"keyz/backend/prisma/db"
+)
+
+// Notice periods (in months) a tenant must respect before leaving, as defined by French law.
+const (
+	UnfurnishedNoticeMonths = 3
+	FurnishedNoticeMonths   = 1
+	ReducedNoticeMonths     = 1
+)
+
+type DepartureNoticeRequest struct {
+	RequestedDate db.DateTime        `binding:"required"                 json:"requested_date"`
+	Reason        db.DepartureReason `binding:"required,departureReason" json:"reason"`
+	Comment       *string            `json:"comment,omitempty"`
+}
+
+// NoticePeriod returns the number of months of notice required for this request.
+func (r *DepartureNoticeRequest) NoticePeriod(furnished bool) int {
+	if furnished {
+		return FurnishedNoticeMonths
+	}
+	if r.Reason != db.DepartureReasonStandard {
+		return ReducedNoticeMonths
+	}
+	return UnfurnishedNoticeMonths
+}
+
+// ComputeEndDate returns the requested date, postponed to the end of the legal notice period if it's too early.
+func (r *DepartureNoticeRequest) ComputeEndDate(submittedAt time.Time, furnished bool) db.DateTime {
+	minEndDate := submittedAt.AddDate(0, r.NoticePeriod(furnished), 0)
+	if r.RequestedDate.Before(minEndDate) {
+		return minEndDate
+	}
+	return r.RequestedDate
+}
+
+func (r *DepartureNoticeRequest) ToDbDepartureNotice(submittedAt time.Time, furnished bool) db.DepartureNoticeModel {
+	return db.DepartureNoticeModel{
+		InnerDepartureNotice: db.InnerDepartureNotice{
+			RequestedDate: r.RequestedDate,
+			EndDate:       r.ComputeEndDate(submittedAt, furnished),
+			Reason:        r.Reason,
+			Comment:       r.Comment,
+		},
+	}
+}
+
+type DepartureNoticeDiscussRequest struct {
+	Comment string `binding:"required" json:"comment"`
+}
+
+type DepartureNoticeResponse struct {
+	ID            string             `json:"id"`
+	LeaseID       string             `json:"lease_id"`
+	RequestedDate db.DateTime        `json:"requested_date"`
+	EndDate       db.DateTime        `json:"end_date"`
+	Reason        db.DepartureReason `json:"reason"`
+	Status        db.NoticeStatus    `json:"status"`
+	Comment       *string            `json:"comment"`
+	OwnerComment  *string            `json:"owner_comment"`
+	CreatedAt     db.DateTime        `json:"created_at"`
+	UpdatedAt     db.DateTime        `json:"updated_at"`
+}
+
+func (n *DepartureNoticeResponse) FromDbDepartureNotice(model db.DepartureNoticeModel) {
+	n.ID = model.ID
+	n.LeaseID = model.LeaseID
+	n.RequestedDate = model.RequestedDate
+	n.EndDate = model.EndDate
+	n.Reason = model.Reason
+	n.Status = model.Status
+	n.Comment = model.InnerDepartureNotice.Comment
+	n.OwnerComment = model.InnerDepartureNotice.OwnerComment
+	n.CreatedAt = model.CreatedAt
+	n.UpdatedAt = model.UpdatedAt
+}
+
+func DbDepartureNoticeToResponse(model db.DepartureNoticeModel) DepartureNoticeResponse {
+	var resp DepartureNoticeResponse
+	resp.FromDbDepartureNotice(model)
+	return resp
+}
@@ -0,0 +1,86 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"keyz/backend/models"
+	"keyz/backend/prisma/db"
+	"keyz/backend/utils"
+)
+
+func TestDepartureNoticeRequest(t *testing.T) {
+	now := time.Date(2025, time.June, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("NoticePeriod", func(t *testing.T) {
+		standard := models.DepartureNoticeRequest{Reason: db.DepartureReasonStandard}
+		reduced := models.DepartureNoticeRequest{Reason: db.DepartureReasonJobLoss}
+
+		assert.Equal(t, models.UnfurnishedNoticeMonths, standard.NoticePeriod(false))
+		assert.Equal(t, models.FurnishedNoticeMonths, standard.NoticePeriod(true))
+		assert.Equal(t, models.ReducedNoticeMonths, reduced.NoticePeriod(false))
+		assert.Equal(t, models.FurnishedNoticeMonths, reduced.NoticePeriod(true))
+	})
+
+	t.Run("ComputeEndDate_TooEarly", func(t *testing.T) {
+		req := models.DepartureNoticeRequest{
+			RequestedDate: now.AddDate(0, 0, 7),
+			Reason:        db.DepartureReasonStandard,
+		}
+
+		assert.Equal(t, now.AddDate(0, 3, 0), req.ComputeEndDate(now, false))
+		assert.Equal(t, now.AddDate(0, 1, 0), req.ComputeEndDate(now, true))
+	})
+
+	t.Run("ComputeEndDate_Requested", func(t *testing.T) {
+		req := models.DepartureNoticeRequest{
+			RequestedDate: now.AddDate(0, 6, 0),
+			Reason:        db.DepartureReasonStandard,
+		}
+
+		assert.Equal(t, req.RequestedDate, req.ComputeEndDate(now, false))
+	})
+
+	t.Run("ToDbDepartureNotice", func(t *testing.T) {
+		req := models.DepartureNoticeRequest{
+			RequestedDate: now.AddDate(0, 0, 7),
+			Reason:        db.DepartureReasonNewJob,
+			Comment:       utils.Ptr("New job in another city"),
+		}
+
+		notice := req.ToDbDepartureNotice(now, false)
+
+		assert.Equal(t, req.RequestedDate, notice.RequestedDate)
+		assert.Equal(t, now.AddDate(0, 1, 0), notice.EndDate)
+		assert.Equal(t, req.Reason, notice.Reason)
+		assert.Equal(t, req.Comment, notice.InnerDepartureNotice.Comment)
+	})
+}
+
+func TestDepartureNoticeResponse(t *testing.T) {
+	model := db.DepartureNoticeModel{
+		InnerDepartureNotice: db.InnerDepartureNotice{
+			ID:            "1",
+			LeaseID:       "1",
+			RequestedDate: time.Now(),
+			EndDate:       time.Now().AddDate(0, 3, 0),
+			Reason:        db.DepartureReasonStandard,
+			Status:        db.NoticeStatusDiscussing,
+			OwnerComment:  utils.Ptr("Can we talk about it?"),
+			CreatedAt:     time.Now(),
+			UpdatedAt:     time.Now(),
+		},
+	}
+
+	resp := models.DbDepartureNoticeToResponse(model)
+
+	assert.Equal(t, model.ID, resp.ID)
+	assert.Equal(t, model.LeaseID, resp.LeaseID)
+	assert.Equal(t, model.RequestedDate, resp.RequestedDate)
+	assert.Equal(t, model.EndDate, resp.EndDate)
+	assert.Equal(t, model.Reason, resp.Reason)
+	assert.Equal(t, model.Status, resp.Status)
+	assert.Nil(t, resp.Comment)
+	assert.Equal(t, model.InnerDepartureNotice.OwnerComment, resp.OwnerComment)
+}
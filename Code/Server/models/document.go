@@ -2,68 +2,219 @@ package models
 
 import (
 	"encoding/base64"
+	"io"
+	"mime/multipart"
+	"path/filepath"
 	"strings"
+	"time"
 
 	"keyz/backend/prisma/db"
+	"keyz/backend/services/storage"
+	"keyz/backend/utils"
 )
 
+// DocumentInfo classifies a document. The category defaults to `other`.
+type DocumentInfo struct {
+	Category  db.DocCategory `binding:"omitempty,docCategory" form:"category" json:"category"`
+	Tags      []string       `binding:"dive,required,max=50"  form:"tags"     json:"tags"`
+	ExpiresAt *time.Time     `form:"expires_at"               json:"expires_at"`
+}
+
+// ToDbDocument returns the classification of a document, to create it or to update it.
+func (i *DocumentInfo) ToDbDocument() db.DocumentModel {
+	category := i.Category
+	if category == "" {
+		category = db.DocCategoryOther
+	}
+	tags := i.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+	return db.DocumentModel{
+		InnerDocument: db.InnerDocument{
+			Category:  category,
+			Tags:      tags,
+			ExpiresAt: i.ExpiresAt,
+		},
+	}
+}
+
+func (i *DocumentInfo) fill(doc *db.DocumentModel) {
+	info := i.ToDbDocument()
+	doc.Category = info.Category
+	doc.Tags = info.Tags
+	doc.InnerDocument.ExpiresAt = info.InnerDocument.ExpiresAt
+}
+
 type DocumentRequest struct {
 	Name string `binding:"required"         json:"name"`
 	Data string `binding:"required,datauri" json:"data"`
+	DocumentInfo
+}
+
+// DocumentMimeTypes are the MIME types of the supported document types.
+var DocumentMimeTypes = map[db.DocType]string{
+	db.DocTypePdf:  "application/pdf",
+	db.DocTypeDocx: "application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+	db.DocTypeXlsx: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+	db.DocTypeOdt:  "application/vnd.oasis.opendocument.text",
+	db.DocTypeOds:  "application/vnd.oasis.opendocument.spreadsheet",
+	db.DocTypeTxt:  "text/plain",
+	db.DocTypeCsv:  "text/csv",
+	db.DocTypePng:  "image/png",
+	db.DocTypeJpeg: "image/jpeg",
+}
+
+// DocTypeFromMime returns the document type of a MIME type, ignoring its parameters.
+func DocTypeFromMime(mimeType string) (db.DocType, bool) {
+	mediaType := utils.NormalizeMimeType(mimeType)
+	for docType, docMime := range DocumentMimeTypes {
+		if docMime == mediaType {
+			return docType, true
+		}
+	}
+	return "", false
+}
+
+// SniffDocType returns the type of a document detected from its content, which must match the declared MIME type if any.
+func SniffDocType(data []byte, declared string) (db.DocType, bool) {
+	accepted := make([]string, 0, len(DocumentMimeTypes))
+	for _, mimeType := range DocumentMimeTypes {
+		accepted = append(accepted, mimeType)
+	}
+	mediaType, ok := utils.SniffMimeType(data, declared, accepted)
+	if !ok {
+		return "", false
+	}
+	return DocTypeFromMime(mediaType)
+}
+
+// DocumentFileName returns the name of the document with the extension of its type, to download it as a file.
+func DocumentFileName(doc db.DocumentModel) string {
+	name := strings.ReplaceAll(doc.Name, "/", "_")
+	if !strings.HasSuffix(strings.ToLower(name), "."+string(doc.Type)) {
+		name += "." + string(doc.Type)
+	}
+	return name
 }
 
 func (i *DocumentRequest) ToDbDocument() *db.DocumentModel {
-	var docType db.DocType
-
-	switch {
-	case strings.HasPrefix(i.Data, "data:application/pdf;base64,"):
-		i.Data = strings.TrimPrefix(i.Data, "data:application/pdf;base64,")
-		docType = db.DocTypePdf
-	case strings.HasPrefix(i.Data, "data:application/vnd.openxmlformats-officedocument.wordprocessingml.document;base64,"):
-		i.Data = strings.TrimPrefix(i.Data, "data:application/vnd.openxmlformats-officedocument.wordprocessingml.document;base64,")
-		docType = db.DocTypeDocx
-	case strings.HasPrefix(i.Data, "data:application/vnd.openxmlformats-officedocument.spreadsheetml.sheet;base64,"):
-		i.Data = strings.TrimPrefix(i.Data, "data:application/vnd.openxmlformats-officedocument.spreadsheetml.sheet;base64,")
-		docType = db.DocTypeXlsx
-	default:
+	mimeType, decoded, ok := utils.ParseDataURI(i.Data)
+	if !ok {
+		return nil
+	}
+	docType, ok := SniffDocType(decoded, mimeType)
+	if !ok {
 		return nil
 	}
 
-	decoded, err := base64.StdEncoding.DecodeString(i.Data)
+	doc := &db.DocumentModel{
+		InnerDocument: db.InnerDocument{
+			Name: i.Name,
+			Data: &decoded,
+			Type: docType,
+		},
+	}
+	i.fill(doc)
+	return doc
+}
+
+// DocumentUploadForm is the multipart form to upload a document as a binary file.
+// The name defaults to the file name. The type is detected from the content of the file, which must match
+// its Content-Type or else its extension when the Content-Type is not a supported one.
+type DocumentUploadForm struct {
+	Name string                `form:"name"`
+	File *multipart.FileHeader `binding:"required" form:"file"`
+	DocumentInfo
+}
+
+func (i *DocumentUploadForm) ToDbDocument() *db.DocumentModel {
+	declared := i.File.Header.Get("Content-Type")
+	if _, ok := DocTypeFromMime(declared); !ok {
+		ext := strings.TrimPrefix(strings.ToLower(filepath.Ext(i.File.Filename)), ".")
+		if ext == "jpg" {
+			ext = "jpeg"
+		}
+		declared = DocumentMimeTypes[db.DocType(ext)]
+	}
+
+	file, err := i.File.Open()
 	if err != nil {
 		return nil
 	}
+	defer file.Close()
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil
+	}
+	docType, ok := SniffDocType(data, declared)
+	if !ok {
+		return nil
+	}
 
-	return &db.DocumentModel{
+	name := i.Name
+	if name == "" {
+		name = i.File.Filename
+	}
+	doc := &db.DocumentModel{
 		InnerDocument: db.InnerDocument{
-			Name: i.Name,
-			Data: decoded,
+			Name: name,
+			Data: &data,
 			Type: docType,
 		},
 	}
+	i.fill(doc)
+	return doc
+}
+
+// DocumentListQuery filters the documents of a lease by category and by tag.
+type DocumentListQuery struct {
+	Category []db.DocCategory `binding:"dive,docCategory" form:"category"`
+	Tag      *string          `form:"tag"`
 }
 
+// DocumentResponse is a version of a document. The latest version number is also the number of versions of the document.
 type DocumentResponse struct {
-	ID        string      `json:"id"`
-	Name      string      `json:"name"`
-	Data      string      `json:"data"`
-	CreatedAt db.DateTime `json:"created_at"`
+	ID          string         `json:"id"`
+	Name        string         `json:"name"`
+	Data        string         `json:"data"`
+	Category    db.DocCategory `json:"category"`
+	Tags        []string       `json:"tags"`
+	ExpiresAt   *db.DateTime   `json:"expires_at"`
+	Version     int            `json:"version"`
+	Latest      bool           `json:"latest"`
+	OriginalID  *string        `json:"original_id"`
+	DeletedAt   *db.DateTime   `json:"deleted_at,omitempty"`
+	PurgeAt     *db.DateTime   `json:"purge_at,omitempty"`
+	GuarantorID *string        `json:"guarantor_id"`
+	DamageID    *string        `json:"damage_id"`
+	CreatedAt   db.DateTime    `json:"created_at"`
 }
 
 func (i *DocumentResponse) FromDbDocument(model db.DocumentModel) {
 	i.ID = model.ID
 	i.Name = model.Name
-	switch model.Type {
-	case db.DocTypePdf:
-		i.Data = "data:application/pdf;base64,"
-	case db.DocTypeDocx:
-		i.Data = "data:application/vnd.openxmlformats-officedocument.wordprocessingml.document;base64,"
-	case db.DocTypeXlsx:
-		i.Data = "data:application/vnd.openxmlformats-officedocument.spreadsheetml.sheet;base64,"
-	default:
+	mimeType, ok := DocumentMimeTypes[model.Type]
+	if !ok {
 		panic("unknown document type")
 	}
-	i.Data += base64.StdEncoding.EncodeToString(model.Data)
+	data, err := storage.Load(model.InnerDocument.Data, model.InnerDocument.StorageKey)
+	if err != nil {
+		panic(err)
+	}
+	i.Data = "data:" + mimeType + ";base64," + base64.StdEncoding.EncodeToString(data)
+	i.Category = model.Category
+	i.Tags = model.Tags
+	i.ExpiresAt = model.InnerDocument.ExpiresAt
+	i.Version = model.Version
+	i.Latest = model.Latest
+	i.OriginalID = model.InnerDocument.OriginalID
+	i.DeletedAt = model.InnerDocument.DeletedAt
+	if purgeAt, ok := model.PurgeAt(); ok {
+		i.PurgeAt = &purgeAt
+	}
+	i.GuarantorID = model.InnerDocument.GuarantorID
+	i.DamageID = model.InnerDocument.DamageID
 	i.CreatedAt = model.CreatedAt
 }
 
@@ -1,6 +1,9 @@
 package models_test
 
 import (
+	"archive/zip"
+	"bytes"
+	"encoding/base64"
 	"testing"
 	"time"
 
@@ -8,13 +11,14 @@ import (
 	"github.com/stretchr/testify/require"
 	"keyz/backend/models"
 	"keyz/backend/prisma/db"
+	"keyz/backend/utils"
 )
 
 func TestDocumentResponse(t *testing.T) {
 	document := db.DocumentModel{
 		InnerDocument: db.InnerDocument{
 			ID:        "1",
-			Data:      []byte("test data"),
+			Data:      utils.Ptr([]byte("test data")),
 			Type:      db.DocTypePdf,
 			CreatedAt: time.Now(),
 		},
@@ -71,53 +75,133 @@ func TestDocumentResponse(t *testing.T) {
 	})
 }
 
+// buildZip stores the files uncompressed, as OpenDocument files store their mimetype entry.
+func buildZip(t *testing.T, files ...string) []byte {
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for i := 0; i < len(files); i += 2 {
+		f, err := w.CreateHeader(&zip.FileHeader{Name: files[i], Method: zip.Store})
+		require.NoError(t, err)
+		_, err = f.Write([]byte(files[i+1]))
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func dataURI(mimeType string, data []byte) string {
+	return "data:" + mimeType + ";base64," + base64.StdEncoding.EncodeToString(data)
+}
+
 func TestDocumentRequest(t *testing.T) {
+	pdfData := []byte("%PDF-1.4\n")
 	t.Run("ValidPDF", func(t *testing.T) {
 		documentRequest := models.DocumentRequest{
 			Name: "Test Document",
-			Data: "data:application/pdf;base64,dGVzdCBkYXRh", // base64 for "test data"
+			Data: dataURI("application/pdf", pdfData),
 		}
 
 		dbDocument := documentRequest.ToDbDocument()
 
 		require.NotNil(t, dbDocument)
 		assert.Equal(t, documentRequest.Name, dbDocument.Name)
-		assert.Equal(t, []byte("test data"), dbDocument.Data)
+		assert.Equal(t, pdfData, *dbDocument.InnerDocument.Data)
 		assert.Equal(t, db.DocTypePdf, dbDocument.Type)
 	})
 
 	t.Run("ValidDOCX", func(t *testing.T) {
+		data := buildZip(t, "[Content_Types].xml", "<Types/>", "word/document.xml", "<document/>")
 		documentRequest := models.DocumentRequest{
 			Name: "Test Document",
-			Data: "data:application/vnd.openxmlformats-officedocument.wordprocessingml.document;base64,dGVzdCBkYXRh", // base64 for "test data"
+			Data: dataURI("application/vnd.openxmlformats-officedocument.wordprocessingml.document", data),
 		}
 
 		dbDocument := documentRequest.ToDbDocument()
 
 		require.NotNil(t, dbDocument)
 		assert.Equal(t, documentRequest.Name, dbDocument.Name)
-		assert.Equal(t, []byte("test data"), dbDocument.Data)
+		assert.Equal(t, data, *dbDocument.InnerDocument.Data)
 		assert.Equal(t, db.DocTypeDocx, dbDocument.Type)
 	})
 
 	t.Run("ValidXLSX", func(t *testing.T) {
+		data := buildZip(t, "[Content_Types].xml", "<Types/>", "xl/workbook.xml", "<workbook/>")
 		documentRequest := models.DocumentRequest{
 			Name: "Test Document",
-			Data: "data:application/vnd.openxmlformats-officedocument.spreadsheetml.sheet;base64,dGVzdCBkYXRh", // base64 for "test data"
+			Data: dataURI("application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", data),
 		}
 
 		dbDocument := documentRequest.ToDbDocument()
 
 		require.NotNil(t, dbDocument)
 		assert.Equal(t, documentRequest.Name, dbDocument.Name)
-		assert.Equal(t, []byte("test data"), dbDocument.Data)
+		assert.Equal(t, data, *dbDocument.InnerDocument.Data)
 		assert.Equal(t, db.DocTypeXlsx, dbDocument.Type)
 	})
 
+	t.Run("ValidODT", func(t *testing.T) {
+		documentRequest := models.DocumentRequest{
+			Name: "Test Document",
+			Data: dataURI("application/vnd.oasis.opendocument.text", buildZip(t, "mimetype", "application/vnd.oasis.opendocument.text")),
+		}
+
+		dbDocument := documentRequest.ToDbDocument()
+
+		require.NotNil(t, dbDocument)
+		assert.Equal(t, db.DocTypeOdt, dbDocument.Type)
+	})
+
+	t.Run("ValidCSV", func(t *testing.T) {
+		documentRequest := models.DocumentRequest{
+			Name: "Test Document",
+			Data: dataURI("text/csv", []byte("name,amount\nrent,500\nfees,20\n")),
+		}
+
+		dbDocument := documentRequest.ToDbDocument()
+
+		require.NotNil(t, dbDocument)
+		assert.Equal(t, db.DocTypeCsv, dbDocument.Type)
+	})
+
+	t.Run("ValidTXT", func(t *testing.T) {
+		documentRequest := models.DocumentRequest{
+			Name: "Test Document",
+			Data: dataURI("text/plain", []byte("Keys are in the mailbox.\n")),
+		}
+
+		dbDocument := documentRequest.ToDbDocument()
+
+		require.NotNil(t, dbDocument)
+		assert.Equal(t, db.DocTypeTxt, dbDocument.Type)
+	})
+
+	t.Run("ValidScan", func(t *testing.T) {
+		documentRequest := models.DocumentRequest{
+			Name: "Test Document",
+			Data: "data:image/jpeg;base64,/9j/4A==", // JPEG signature
+		}
+
+		dbDocument := documentRequest.ToDbDocument()
+
+		require.NotNil(t, dbDocument)
+		assert.Equal(t, db.DocTypeJpeg, dbDocument.Type)
+	})
+
 	t.Run("InvalidType", func(t *testing.T) {
 		documentRequest := models.DocumentRequest{
 			Name: "Test Document",
-			Data: "data:text/plain;base64,dGVzdCBkYXRh", // base64 for "test data"
+			Data: "data:image/gif;base64,R0lGODlh", // GIF signature
+		}
+
+		dbDocument := documentRequest.ToDbDocument()
+
+		assert.Nil(t, dbDocument)
+	})
+
+	t.Run("TypeMismatch", func(t *testing.T) {
+		documentRequest := models.DocumentRequest{
+			Name: "Test Document",
+			Data: "data:application/pdf;base64,dGVzdCBkYXRh", // base64 for "test data"
 		}
 
 		dbDocument := documentRequest.ToDbDocument()
@@ -135,4 +219,93 @@ func TestDocumentRequest(t *testing.T) {
 
 		assert.Nil(t, dbDocument)
 	})
+
+	t.Run("DefaultInfo", func(t *testing.T) {
+		documentRequest := models.DocumentRequest{
+			Name: "Test Document",
+			Data: dataURI("application/pdf", pdfData),
+		}
+
+		dbDocument := documentRequest.ToDbDocument()
+
+		require.NotNil(t, dbDocument)
+		assert.Equal(t, db.DocCategoryOther, dbDocument.Category)
+		assert.Empty(t, dbDocument.Tags)
+		assert.Nil(t, dbDocument.InnerDocument.ExpiresAt)
+	})
+
+	t.Run("WithInfo", func(t *testing.T) {
+		expiresAt := time.Now().AddDate(1, 0, 0)
+		documentRequest := models.DocumentRequest{
+			Name: "Insurance",
+			Data: dataURI("application/pdf", pdfData),
+			DocumentInfo: models.DocumentInfo{
+				Category:  db.DocCategoryInsurance,
+				Tags:      []string{"home", "2025"},
+				ExpiresAt: &expiresAt,
+			},
+		}
+
+		dbDocument := documentRequest.ToDbDocument()
+
+		require.NotNil(t, dbDocument)
+		assert.Equal(t, db.DocCategoryInsurance, dbDocument.Category)
+		assert.Equal(t, []string{"home", "2025"}, dbDocument.Tags)
+		assert.Equal(t, &expiresAt, dbDocument.InnerDocument.ExpiresAt)
+	})
+}
+
+func TestDocumentVersion(t *testing.T) {
+	t.Run("FirstVersion", func(t *testing.T) {
+		document := db.DocumentModel{InnerDocument: db.InnerDocument{ID: "1"}}
+		assert.Equal(t, "1", document.FirstVersionID())
+	})
+
+	t.Run("NextVersion", func(t *testing.T) {
+		original := "1"
+		document := db.DocumentModel{InnerDocument: db.InnerDocument{ID: "2", OriginalID: &original}}
+		assert.Equal(t, "1", document.FirstVersionID())
+	})
+
+	t.Run("PurgeAt", func(t *testing.T) {
+		deletedAt := time.Now()
+		document := db.DocumentModel{InnerDocument: db.InnerDocument{ID: "1", DeletedAt: &deletedAt}}
+		purgeAt, ok := document.PurgeAt()
+		require.True(t, ok)
+		assert.Equal(t, deletedAt.Add(db.DocumentRetentionPeriod), purgeAt)
+
+		_, ok = db.DocumentModel{}.PurgeAt()
+		assert.False(t, ok)
+	})
+}
+
+func TestDocTypeFromMime(t *testing.T) {
+	docType, ok := models.DocTypeFromMime("application/pdf")
+	assert.True(t, ok)
+	assert.Equal(t, db.DocTypePdf, docType)
+
+	docType, ok = models.DocTypeFromMime("application/vnd.openxmlformats-officedocument.spreadsheetml.sheet; charset=binary")
+	assert.True(t, ok)
+	assert.Equal(t, db.DocTypeXlsx, docType)
+
+	docType, ok = models.DocTypeFromMime("image/jpg")
+	assert.True(t, ok)
+	assert.Equal(t, db.DocTypeJpeg, docType)
+
+	_, ok = models.DocTypeFromMime("image/gif")
+	assert.False(t, ok)
+	_, ok = models.DocTypeFromMime("")
+	assert.False(t, ok)
+}
+
+func TestDocumentFileName(t *testing.T) {
+	doc := db.DocumentModel{InnerDocument: db.InnerDocument{Name: "lease", Type: db.DocTypePdf}}
+	assert.Equal(t, "lease.pdf", models.DocumentFileName(doc))
+
+	doc.Name = "Invoice.PDF"
+	assert.Equal(t, "Invoice.PDF", models.DocumentFileName(doc))
+
+	doc.Name = "../report"
+	doc.Type = db.DocTypeXlsx
+	assert.Equal(t, ".._report.xlsx", models.DocumentFileName(doc))
 }
@@ -0,0 +1,76 @@
+package models
+
+import (
+	"keyz/backend/prisma/db"
+)
+
+type ExpenseResponse struct {
+	DamageID      string       `json:"damage_id"`
+	LeaseID       string       `json:"lease_id"`
+	TenantName    string       `json:"tenant_name"`
+	RoomName      string       `json:"room_name"`
+	Comment       string       `json:"comment"`
+	CreatedAt     db.DateTime  `json:"created_at"`
+	FixedAt       *db.DateTime `json:"fixed_at"`
+	EstimatedCost *float64     `json:"estimated_cost"`
+	ActualCost    *float64     `json:"actual_cost"`
+	Payer         *db.Payer    `json:"payer"`
+}
+
+func (i *ExpenseResponse) FromDbDamage(model db.DamageModel) {
+	i.DamageID = model.ID
+	i.LeaseID = model.LeaseID
+	i.TenantName = model.Lease().Tenant().Name()
+	i.RoomName = model.Room().Name
+	i.Comment = model.Comment
+	i.CreatedAt = model.CreatedAt
+	i.FixedAt = model.InnerDamage.FixedAt
+	i.EstimatedCost = model.InnerDamage.EstimatedCost
+	i.ActualCost = model.InnerDamage.ActualCost
+	i.Payer = model.InnerDamage.Payer
+}
+
+func DbDamageToExpenseResponse(model db.DamageModel) ExpenseResponse {
+	var resp ExpenseResponse
+	resp.FromDbDamage(model)
+	return resp
+}
+
+// ExpenseReportResponse sums the repair costs of a property over a year.
+// Totals by payer use the actual cost of a repair, or its estimated cost if the actual one is not known yet.
+type ExpenseReportResponse struct {
+	PropertyID     string               `json:"property_id"`
+	Year           int                  `json:"year"`
+	TotalEstimated float64              `json:"total_estimated"`
+	TotalActual    float64              `json:"total_actual"`
+	TotalByPayer   map[db.Payer]float64 `json:"total_by_payer"`
+	Unattributed   float64              `json:"unattributed"`
+	Expenses       []ExpenseResponse    `json:"expenses"`
+}
+
+func NewExpenseReport(propertyId string, year int, damages []db.DamageModel) ExpenseReportResponse {
+	report := ExpenseReportResponse{
+		PropertyID:   propertyId,
+		Year:         year,
+		TotalByPayer: map[db.Payer]float64{},
+		Expenses:     []ExpenseResponse{},
+	}
+
+	for _, damage := range damages {
+		if cost, ok := damage.EstimatedCost(); ok {
+			report.TotalEstimated += cost
+		}
+		if cost, ok := damage.ActualCost(); ok {
+			report.TotalActual += cost
+		}
+		if cost, ok := damage.Cost(); ok {
+			if payer, ok := damage.Payer(); ok {
+				report.TotalByPayer[payer] += cost
+			} else {
+				report.Unattributed += cost
+			}
+		}
+		report.Expenses = append(report.Expenses, DbDamageToExpenseResponse(damage))
+	}
+	return report
+}
@@ -0,0 +1,48 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"keyz/backend/models"
+	"keyz/backend/prisma/db"
+)
+
+func TestNewExpenseReport(t *testing.T) {
+	estimated, actual := 100.0, 80.0
+	tenant, owner := db.PayerTenant, db.PayerOwner
+
+	paidByTenant := BuildTestDamage("1")
+	paidByTenant.EstimatedCost = &estimated
+	paidByTenant.ActualCost = &actual
+	paidByTenant.Payer = &tenant
+
+	estimatedOnly := BuildTestDamage("2")
+	estimatedOnly.EstimatedCost = &estimated
+	estimatedOnly.Payer = &owner
+
+	unattributed := BuildTestDamage("3")
+	unattributed.ActualCost = &actual
+
+	report := models.NewExpenseReport("1", 2025, []db.DamageModel{paidByTenant, estimatedOnly, unattributed})
+
+	assert.Equal(t, "1", report.PropertyID)
+	assert.Equal(t, 2025, report.Year)
+	assert.InDelta(t, 200.0, report.TotalEstimated, 0)
+	assert.InDelta(t, 160.0, report.TotalActual, 0)
+	assert.InDelta(t, 80.0, report.TotalByPayer[db.PayerTenant], 0)
+	assert.InDelta(t, 100.0, report.TotalByPayer[db.PayerOwner], 0)
+	assert.InDelta(t, 80.0, report.Unattributed, 0)
+	require.Len(t, report.Expenses, 3)
+	assert.Equal(t, "John Doe", report.Expenses[0].TenantName)
+	assert.Equal(t, &tenant, report.Expenses[0].Payer)
+}
+
+func TestNewExpenseReport_Empty(t *testing.T) {
+	report := models.NewExpenseReport("1", 2025, nil)
+
+	assert.Empty(t, report.Expenses)
+	assert.NotNil(t, report.Expenses)
+	assert.Empty(t, report.TotalByPayer)
+}
@@ -0,0 +1,65 @@
+package models
+
+import (
+	"keyz/backend/prisma/db"
+)
+
+type GuarantorRequest struct {
+	Firstname      string           `binding:"required"               json:"firstname"`
+	Lastname       string           `binding:"required"               json:"lastname"`
+	Email          string           `binding:"required,email"         json:"email"`
+	Phone          *string          `json:"phone,omitempty"`
+	Address        *string          `json:"address,omitempty"`
+	Type           db.GuaranteeType `binding:"required,guaranteeType" json:"type"`
+	AmountCap      *float64         `binding:"omitempty,gt=0"         json:"amount_cap,omitempty"`
+	DurationMonths *int             `binding:"omitempty,gt=0"         json:"duration_months,omitempty"`
+}
+
+func (r *GuarantorRequest) ToDbGuarantor() db.GuarantorModel {
+	return db.GuarantorModel{
+		InnerGuarantor: db.InnerGuarantor{
+			Firstname:      r.Firstname,
+			Lastname:       r.Lastname,
+			Email:          r.Email,
+			Phone:          r.Phone,
+			Address:        r.Address,
+			Type:           r.Type,
+			AmountCap:      r.AmountCap,
+			DurationMonths: r.DurationMonths,
+		},
+	}
+}
+
+type GuarantorResponse struct {
+	ID             string           `json:"id"`
+	LeaseID        string           `json:"lease_id"`
+	Firstname      string           `json:"firstname"`
+	Lastname       string           `json:"lastname"`
+	Email          string           `json:"email"`
+	Phone          *string          `json:"phone"`
+	Address        *string          `json:"address"`
+	Type           db.GuaranteeType `json:"type"`
+	AmountCap      *float64         `json:"amount_cap"`
+	DurationMonths *int             `json:"duration_months"`
+	CreatedAt      db.DateTime      `json:"created_at"`
+}
+
+func (g *GuarantorResponse) FromDbGuarantor(model db.GuarantorModel) {
+	g.ID = model.ID
+	g.LeaseID = model.LeaseID
+	g.Firstname = model.Firstname
+	g.Lastname = model.Lastname
+	g.Email = model.Email
+	g.Phone = model.InnerGuarantor.Phone
+	g.Address = model.InnerGuarantor.Address
+	g.Type = model.Type
+	g.AmountCap = model.InnerGuarantor.AmountCap
+	g.DurationMonths = model.InnerGuarantor.DurationMonths
+	g.CreatedAt = model.CreatedAt
+}
+
+func DbGuarantorToResponse(model db.GuarantorModel) GuarantorResponse {
+	var resp GuarantorResponse
+	resp.FromDbGuarantor(model)
+	return resp
+}
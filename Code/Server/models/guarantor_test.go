@@ -0,0 +1,62 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"keyz/backend/models"
+	"keyz/backend/prisma/db"
+	"keyz/backend/utils"
+)
+
+func TestGuarantorRequest(t *testing.T) {
+	req := models.GuarantorRequest{
+		Firstname: "Jane",
+		Lastname:  "Doe",
+		Email:     "janedoe@example.com",
+		Phone:     utils.Ptr("0601020304"),
+		Type:      db.GuaranteeTypeJoint,
+		AmountCap: utils.Ptr(15000.0),
+	}
+
+	t.Run("ToDbGuarantor", func(t *testing.T) {
+		guarantor := req.ToDbGuarantor()
+
+		assert.Equal(t, req.Firstname, guarantor.Firstname)
+		assert.Equal(t, req.Lastname, guarantor.Lastname)
+		assert.Equal(t, req.Email, guarantor.Email)
+		assert.Equal(t, req.Phone, guarantor.InnerGuarantor.Phone)
+		assert.Nil(t, guarantor.InnerGuarantor.Address)
+		assert.Equal(t, req.Type, guarantor.Type)
+		assert.Equal(t, req.AmountCap, guarantor.InnerGuarantor.AmountCap)
+		assert.Nil(t, guarantor.InnerGuarantor.DurationMonths)
+	})
+}
+
+func TestGuarantorResponse(t *testing.T) {
+	model := db.GuarantorModel{
+		InnerGuarantor: db.InnerGuarantor{
+			ID:             "1",
+			LeaseID:        "1",
+			Firstname:      "Jane",
+			Lastname:       "Doe",
+			Email:          "janedoe@example.com",
+			Type:           db.GuaranteeTypeVisale,
+			DurationMonths: utils.Ptr(36),
+			CreatedAt:      time.Now(),
+		},
+	}
+
+	resp := models.DbGuarantorToResponse(model)
+
+	assert.Equal(t, model.ID, resp.ID)
+	assert.Equal(t, model.LeaseID, resp.LeaseID)
+	assert.Equal(t, model.Firstname, resp.Firstname)
+	assert.Equal(t, model.Lastname, resp.Lastname)
+	assert.Equal(t, model.Email, resp.Email)
+	assert.Equal(t, model.Type, resp.Type)
+	assert.Nil(t, resp.AmountCap)
+	assert.Equal(t, model.InnerGuarantor.DurationMonths, resp.DurationMonths)
+	assert.Equal(t, model.CreatedAt, resp.CreatedAt)
+}
@@ -2,43 +2,49 @@ package models
 
 import (
 	"encoding/base64"
-	"strings"
 
 	"keyz/backend/prisma/db"
+	"keyz/backend/services/storage"
+	"keyz/backend/utils"
 )
 
 type ImageRequest struct {
 	Data string `binding:"required,datauri" json:"data"`
 }
 
-func (i *ImageRequest) ToDbImage() *db.ImageModel {
-	var imgType db.ImageType
+// ImageMimeTypes are the MIME types of the supported image types.
+var ImageMimeTypes = map[db.ImageType]string{
+	db.ImageTypePng:  "image/png",
+	db.ImageTypeJpeg: "image/jpeg",
+	db.ImageTypeWebp: "image/webp",
+}
 
-	switch {
-	case strings.HasPrefix(i.Data, "data:image/png;base64,"):
-		i.Data = strings.TrimPrefix(i.Data, "data:image/png;base64,")
-		imgType = db.ImageTypePng
-	case strings.HasPrefix(i.Data, "data:image/jpeg;base64,"):
-		i.Data = strings.TrimPrefix(i.Data, "data:image/jpeg;base64,")
-		imgType = db.ImageTypeJpeg
-	case strings.HasPrefix(i.Data, "data:image/jpg;base64,"):
-		i.Data = strings.TrimPrefix(i.Data, "data:image/jpg;base64,")
-		imgType = db.ImageTypeJpeg
-	default:
+// ToDbImage returns the image of the data URI, or nil if its content is not a supported image of the declared type.
+func (i *ImageRequest) ToDbImage() *db.ImageModel {
+	mimeType, decoded, ok := utils.ParseDataURI(i.Data)
+	if !ok {
 		return nil
 	}
-
-	decoded, err := base64.StdEncoding.DecodeString(i.Data)
-	if err != nil {
+	accepted := make([]string, 0, len(ImageMimeTypes))
+	for _, imgMime := range ImageMimeTypes {
+		accepted = append(accepted, imgMime)
+	}
+	mimeType, ok = utils.SniffMimeType(decoded, mimeType, accepted)
+	if !ok {
 		return nil
 	}
 
-	return &db.ImageModel{
-		InnerImage: db.InnerImage{
-			Data: decoded,
-			Type: imgType,
-		},
+	for imgType, imgMime := range ImageMimeTypes {
+		if imgMime == mimeType {
+			return &db.ImageModel{
+				InnerImage: db.InnerImage{
+					Data: &decoded,
+					Type: imgType,
+				},
+			}
+		}
 	}
+	return nil
 }
 
 func StringToDbImage(data string) *db.ImageModel {
@@ -53,15 +59,15 @@ type ImageResponse struct {
 
 func (i *ImageResponse) FromDbImage(model db.ImageModel) {
 	i.ID = model.ID
-	switch model.Type {
-	case db.ImageTypePng:
-		i.Data = "data:image/png;base64,"
-	case db.ImageTypeJpeg:
-		i.Data = "data:image/jpeg;base64,"
-	default:
+	mimeType, ok := ImageMimeTypes[model.Type]
+	if !ok {
 		panic("unknown image type")
 	}
-	i.Data += base64.StdEncoding.EncodeToString(model.Data)
+	data, err := storage.Load(model.InnerImage.Data, model.InnerImage.StorageKey)
+	if err != nil {
+		panic(err)
+	}
+	i.Data = "data:" + mimeType + ";base64," + base64.StdEncoding.EncodeToString(data)
 	i.CreatedAt = model.CreatedAt
 }
 
@@ -8,45 +8,74 @@ import (
 	"github.com/stretchr/testify/require"
 	"keyz/backend/models"
 	"keyz/backend/prisma/db"
+	"keyz/backend/utils"
 )
 
 func TestImageRequest(t *testing.T) {
 	t.Run("ValidJPEG", func(t *testing.T) {
 		req := models.ImageRequest{
-			Data: "data:image/jpeg;base64,dGVzdCBkYXRh", // base64 for "test data"
+			Data: "data:image/jpeg;base64,/9j/4A==", // JPEG signature
 		}
 		image := req.ToDbImage()
 
 		require.NotNil(t, image)
-		assert.Equal(t, "test data", string(image.Data))
+		assert.Equal(t, []byte("\xff\xd8\xff\xe0"), *image.InnerImage.Data)
 		assert.Equal(t, db.ImageTypeJpeg, image.Type)
 	})
 
 	t.Run("ValidJPG", func(t *testing.T) {
 		req := models.ImageRequest{
-			Data: "data:image/jpg;base64,dGVzdCBkYXRh", // base64 for "test data"
+			Data: "data:image/jpg;base64,/9j/4A==", // JPEG signature
 		}
 		image := req.ToDbImage()
 
 		require.NotNil(t, image)
-		assert.Equal(t, "test data", string(image.Data))
+		assert.Equal(t, []byte("\xff\xd8\xff\xe0"), *image.InnerImage.Data)
 		assert.Equal(t, db.ImageTypeJpeg, image.Type)
 	})
 
 	t.Run("ValidPNG", func(t *testing.T) {
 		req := models.ImageRequest{
-			Data: "data:image/png;base64,dGVzdCBkYXRh", // base64 for "test data"
+			Data: "data:image/png;base64,iVBORw0KGgo=", // PNG signature
 		}
 		image := req.ToDbImage()
 
 		require.NotNil(t, image)
-		assert.Equal(t, "test data", string(image.Data))
+		assert.Equal(t, []byte("\x89PNG\r\n\x1a\n"), *image.InnerImage.Data)
 		assert.Equal(t, db.ImageTypePng, image.Type)
 	})
 
+	t.Run("ValidWebP", func(t *testing.T) {
+		req := models.ImageRequest{
+			Data: "data:image/webp;base64,UklGRgAAAABXRUJQVlA4IA==", // WebP signature
+		}
+		image := req.ToDbImage()
+
+		require.NotNil(t, image)
+		assert.Equal(t, db.ImageTypeWebp, image.Type)
+	})
+
 	t.Run("InvalidType", func(t *testing.T) {
 		req := models.ImageRequest{
-			Data: "data:image/gif;base64,dGVzdCBkYXRh", // base64 for "test data"
+			Data: "data:image/gif;base64,R0lGODlh", // GIF signature
+		}
+		image := req.ToDbImage()
+
+		assert.Nil(t, image)
+	})
+
+	t.Run("TypeMismatch", func(t *testing.T) {
+		req := models.ImageRequest{
+			Data: "data:image/png;base64,/9j/4A==", // JPEG signature
+		}
+		image := req.ToDbImage()
+
+		assert.Nil(t, image)
+	})
+
+	t.Run("NotAnImage", func(t *testing.T) {
+		req := models.ImageRequest{
+			Data: "data:image/jpeg;base64,dGVzdCBkYXRh", // base64 for "test data"
 		}
 		image := req.ToDbImage()
 
@@ -65,11 +94,11 @@ func TestImageRequest(t *testing.T) {
 
 func TestStringToDbImage(t *testing.T) {
 	t.Run("ValidBase64", func(t *testing.T) {
-		data := "data:image/jpeg;base64,dGVzdCBkYXRh" // base64 for "test data"
+		data := "data:image/jpeg;base64,/9j/4A==" // JPEG signature
 		image := models.StringToDbImage(data)
 
 		require.NotNil(t, image)
-		assert.Equal(t, "test data", string(image.Data))
+		assert.Equal(t, []byte("\xff\xd8\xff\xe0"), *image.InnerImage.Data)
 	})
 
 	t.Run("InvalidBase64", func(t *testing.T) {
@@ -84,7 +113,7 @@ func TestImageResponse(t *testing.T) {
 	image := db.ImageModel{
 		InnerImage: db.InnerImage{
 			ID:        "1",
-			Data:      []byte("test data"),
+			Data:      utils.Ptr([]byte("test data")),
 			Type:      db.ImageTypeJpeg,
 			CreatedAt: time.Now(),
 		},
@@ -4,6 +4,7 @@ import (
 	"encoding/base64"
 
 	"keyz/backend/prisma/db"
+	"keyz/backend/services/storage"
 )
 
 type FurnitureStateRequest struct {
@@ -122,7 +123,11 @@ func (c *CreateInventoryReportResponse) FromDbInventoryReport(model db.Inventory
 	c.Type = model.Type
 	if pdf != nil {
 		c.PdfName = pdf.Name
-		c.PdfData = "data:application/pdf;base64," + base64.StdEncoding.EncodeToString(pdf.Data)
+		data, err := storage.Load(pdf.InnerDocument.Data, pdf.InnerDocument.StorageKey)
+		if err != nil {
+			panic(err)
+		}
+		c.PdfData = "data:application/pdf;base64," + base64.StdEncoding.EncodeToString(data)
 	}
 	c.Errors = errors
 }
@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"keyz/backend/models"
 	"keyz/backend/prisma/db"
+	"keyz/backend/utils"
 )
 
 func TestInventoryReport(t *testing.T) {
@@ -37,7 +38,7 @@ func TestInventoryReport(t *testing.T) {
 						Pictures: []db.ImageModel{
 							{
 								InnerImage: db.InnerImage{
-									Data: []byte("base64image1"),
+									Data: utils.Ptr([]byte("base64image1")),
 									Type: db.ImageTypeJpeg,
 								},
 							},
@@ -61,7 +62,7 @@ func TestInventoryReport(t *testing.T) {
 						Pictures: []db.ImageModel{
 							{
 								InnerImage: db.InnerImage{
-									Data: []byte("base64image1"),
+									Data: utils.Ptr([]byte("base64image1")),
 									Type: db.ImageTypeJpeg,
 								},
 							},
@@ -87,7 +88,7 @@ func TestInventoryReport(t *testing.T) {
 						Pictures: []db.ImageModel{
 							{
 								InnerImage: db.InnerImage{
-									Data: []byte("base64image2"),
+									Data: utils.Ptr([]byte("base64image2")),
 									Type: db.ImageTypeJpeg,
 								},
 							},
@@ -195,7 +196,7 @@ func TestCreateInventoryReportResponse(t *testing.T) {
 	pdf := &db.DocumentModel{
 		InnerDocument: db.InnerDocument{
 			Name: "report.pdf",
-			Data: []byte("pdfdata"),
+			Data: utils.Ptr([]byte("pdfdata")),
 		},
 	}
 
@@ -210,7 +211,7 @@ func TestCreateInventoryReportResponse(t *testing.T) {
 		assert.Equal(t, model.Date, resp.Date)
 		assert.Equal(t, model.Type, resp.Type)
 		assert.Equal(t, pdf.Name, resp.PdfName)
-		assert.Equal(t, "data:application/pdf;base64,"+base64.StdEncoding.EncodeToString(pdf.Data), resp.PdfData)
+		assert.Equal(t, "data:application/pdf;base64,"+base64.StdEncoding.EncodeToString(*pdf.InnerDocument.Data), resp.PdfData)
 		assert.Equal(t, errors, resp.Errors)
 	})
 
@@ -235,7 +236,7 @@ func TestCreateInventoryReportResponse(t *testing.T) {
 		assert.Equal(t, model.Date, resp.Date)
 		assert.Equal(t, model.Type, resp.Type)
 		assert.Equal(t, pdf.Name, resp.PdfName)
-		assert.Equal(t, "data:application/pdf;base64,"+base64.StdEncoding.EncodeToString(pdf.Data), resp.PdfData)
+		assert.Equal(t, "data:application/pdf;base64,"+base64.StdEncoding.EncodeToString(*pdf.InnerDocument.Data), resp.PdfData)
 		assert.Equal(t, errors, resp.Errors)
 	})
 
@@ -251,3 +252,29 @@ func TestCreateInventoryReportResponse(t *testing.T) {
 		assert.Equal(t, errors, resp.Errors)
 	})
 }
+
+func TestInventoryReportStates(t *testing.T) {
+	model := db.InventoryReportModel{
+		RelationsInventoryReport: db.RelationsInventoryReport{
+			RoomStates: []db.RoomStateModel{
+				{InnerRoomState: db.InnerRoomState{ID: "1", RoomID: "1", State: db.StateGood}},
+				{InnerRoomState: db.InnerRoomState{ID: "2", RoomID: "2", State: db.StateBad}},
+			},
+			FurnitureStates: []db.FurnitureStateModel{
+				{InnerFurnitureState: db.InnerFurnitureState{ID: "1", FurnitureID: "1", State: db.StateNew}},
+			},
+		},
+	}
+
+	roomState := model.RoomState("2")
+	if assert.NotNil(t, roomState) {
+		assert.Equal(t, db.StateBad, roomState.State)
+	}
+	assert.Nil(t, model.RoomState("3"))
+
+	furnitureState := model.FurnitureState("1")
+	if assert.NotNil(t, furnitureState) {
+		assert.Equal(t, db.StateNew, furnitureState.State)
+	}
+	assert.Nil(t, model.FurnitureState("2"))
+}
@@ -2,22 +2,34 @@ package models
 
 import (
 	"keyz/backend/prisma/db"
+	"keyz/backend/utils"
 )
 
 type LeaseResponse struct {
-	ID           string       `json:"id"`
-	PropertyID   string       `json:"property_id"`
-	PropertyName string       `json:"property_name"`
-	OwnerID      string       `json:"owner_id"`
-	OwnerName    string       `json:"owner_name"`
-	OwnerEmail   string       `json:"owner_email"`
-	TenantID     string       `json:"tenant_id"`
-	TenantName   string       `json:"tenant_name"`
-	TenantEmail  string       `json:"tenant_email"`
-	Active       bool         `json:"active"`
-	StartDate    db.DateTime  `json:"start_date"`
-	EndDate      *db.DateTime `json:"end_date"`
-	CreatedAt    db.DateTime  `json:"created_at"`
+	ID           string         `json:"id"`
+	PropertyID   string         `json:"property_id"`
+	PropertyName string         `json:"property_name"`
+	OwnerID      string         `json:"owner_id"`
+	OwnerName    string         `json:"owner_name"`
+	OwnerEmail   string         `json:"owner_email"`
+	TenantID     string         `json:"tenant_id"`
+	TenantName   string         `json:"tenant_name"`
+	TenantEmail  string         `json:"tenant_email"`
+	Active       bool           `json:"active"`
+	Status       db.LeaseStatus `json:"status"`
+	Furnished    bool           `json:"furnished"`
+	StartDate    db.DateTime    `json:"start_date"`
+	EndDate      *db.DateTime   `json:"end_date"`
+	CreatedAt    db.DateTime    `json:"created_at"`
+
+	RentalPricePerMonth float64      `json:"rental_price_per_month"`
+	Charges             float64      `json:"charges"`
+	DepositPrice        float64      `json:"deposit_price"`
+	PaymentDay          int          `json:"payment_day"`
+	SpecialClauses      *string      `json:"special_clauses"`
+	TermsAcceptedAt     *db.DateTime `json:"terms_accepted_at"`
+
+	Guarantors []GuarantorResponse `json:"guarantors"`
 }
 
 func (l *LeaseResponse) FromDbLease(model db.LeaseModel) {
@@ -31,9 +43,18 @@ func (l *LeaseResponse) FromDbLease(model db.LeaseModel) {
 	l.TenantName = model.Tenant().Name()
 	l.TenantEmail = model.Tenant().Email
 	l.Active = model.Active
+	l.Status = model.Status
+	l.Furnished = model.Furnished
 	l.StartDate = model.StartDate
 	l.EndDate = model.InnerLease.EndDate
 	l.CreatedAt = model.CreatedAt
+	l.RentalPricePerMonth = model.RentalPricePerMonth
+	l.Charges = model.Charges
+	l.DepositPrice = model.DepositPrice
+	l.PaymentDay = model.PaymentDay
+	l.SpecialClauses = model.InnerLease.SpecialClauses
+	l.TermsAcceptedAt = model.InnerLease.TermsAcceptedAt
+	l.Guarantors = utils.Map(model.Guarantors(), DbGuarantorToResponse)
 }
 
 func DbLeaseToResponse(model db.LeaseModel) LeaseResponse {
@@ -42,18 +63,87 @@ func DbLeaseToResponse(model db.LeaseModel) LeaseResponse {
 	return resp
 }
 
+// InviteRequest holds the terms proposed to the tenant.
+// Rent and deposit default to the ones of the property when omitted.
 type InviteRequest struct {
-	TenantEmail string       `binding:"required,email" json:"tenant_email"`
-	StartDate   db.DateTime  `binding:"required"       json:"start_date"`
-	EndDate     *db.DateTime `binding:"-"              json:"end_date,omitempty"`
+	TenantEmail         string       `binding:"required,email"         json:"tenant_email"`
+	StartDate           db.DateTime  `binding:"required"               json:"start_date"`
+	EndDate             *db.DateTime `binding:"-"                      json:"end_date,omitempty"`
+	Furnished           bool         `binding:"-"                      json:"furnished"`
+	RentalPricePerMonth *float64     `binding:"omitempty,gte=0"        json:"rental_price_per_month,omitempty"`
+	Charges             float64      `binding:"gte=0"                  json:"charges"`
+	DepositPrice        *float64     `binding:"omitempty,gte=0"        json:"deposit_price,omitempty"`
+	PaymentDay          int          `binding:"omitempty,min=1,max=28" json:"payment_day,omitempty"`
+	SpecialClauses      *string      `binding:"-"                      json:"special_clauses,omitempty"`
 }
 
-func (i *InviteRequest) ToDbLeaseInvite() db.LeaseInviteModel {
+func (i *InviteRequest) ToDbLeaseInvite(property db.PropertyModel) db.LeaseInviteModel {
+	rent := property.RentalPricePerMonth
+	if i.RentalPricePerMonth != nil {
+		rent = *i.RentalPricePerMonth
+	}
+	deposit := property.DepositPrice
+	if i.DepositPrice != nil {
+		deposit = *i.DepositPrice
+	}
 	return db.LeaseInviteModel{
 		InnerLeaseInvite: db.InnerLeaseInvite{
-			TenantEmail: i.TenantEmail,
-			StartDate:   i.StartDate,
-			EndDate:     i.EndDate,
+			TenantEmail:         i.TenantEmail,
+			StartDate:           i.StartDate,
+			EndDate:             i.EndDate,
+			Furnished:           i.Furnished,
+			RentalPricePerMonth: rent,
+			Charges:             i.Charges,
+			DepositPrice:        deposit,
+			PaymentDay:          utils.Ternary(i.PaymentDay == 0, 1, i.PaymentDay),
+			SpecialClauses:      i.SpecialClauses,
 		},
 	}
 }
+
+type AcceptInviteRequest struct {
+	AcceptTerms bool `binding:"-" json:"accept_terms"`
+}
+
+type TenantInviteResponse struct {
+	ID           string       `json:"id"`
+	PropertyID   string       `json:"property_id"`
+	PropertyName string       `json:"property_name"`
+	City         string       `json:"city"`
+	OwnerName    string       `json:"owner_name"`
+	StartDate    db.DateTime  `json:"start_date"`
+	EndDate      *db.DateTime `json:"end_date"`
+	CreatedAt    db.DateTime  `json:"created_at"`
+	ExpiresAt    db.DateTime  `json:"expires_at"`
+
+	RentalPricePerMonth float64 `json:"rental_price_per_month"`
+	Charges             float64 `json:"charges"`
+	DepositPrice        float64 `json:"deposit_price"`
+	PaymentDay          int     `json:"payment_day"`
+	Furnished           bool    `json:"furnished"`
+	SpecialClauses      *string `json:"special_clauses"`
+}
+
+func (i *TenantInviteResponse) FromDbLeaseInvite(model db.LeaseInviteModel) {
+	i.ID = model.ID
+	i.PropertyID = model.PropertyID
+	i.PropertyName = model.Property().Name
+	i.City = model.Property().City
+	i.OwnerName = model.Property().Owner().Name()
+	i.StartDate = model.StartDate
+	i.EndDate = model.InnerLeaseInvite.EndDate
+	i.CreatedAt = model.CreatedAt
+	i.ExpiresAt = model.ExpiresAt
+	i.RentalPricePerMonth = model.RentalPricePerMonth
+	i.Charges = model.Charges
+	i.DepositPrice = model.DepositPrice
+	i.PaymentDay = model.PaymentDay
+	i.Furnished = model.Furnished
+	i.SpecialClauses = model.InnerLeaseInvite.SpecialClauses
+}
+
+func DbLeaseInviteToTenantResponse(model db.LeaseInviteModel) TenantInviteResponse {
+	var resp TenantInviteResponse
+	resp.FromDbLeaseInvite(model)
+	return resp
+}
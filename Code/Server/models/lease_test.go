@@ -5,34 +5,67 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"keyz/backend/models"
 	"keyz/backend/prisma/db"
+	"keyz/backend/utils"
 )
 
 func TestInviteRequest(t *testing.T) {
 	req := models.InviteRequest{
-		TenantEmail: "test1@example.com",
-		StartDate:   time.Now(),
+		TenantEmail:         "test1@example.com",
+		StartDate:           time.Now(),
+		RentalPricePerMonth: utils.Ptr(750.0),
+		Charges:             50,
+		PaymentDay:          5,
+		SpecialClauses:      utils.Ptr("No pets"),
+	}
+	property := db.PropertyModel{
+		InnerProperty: db.InnerProperty{
+			RentalPricePerMonth: 800,
+			DepositPrice:        1600,
+		},
 	}
 
 	t.Run("ToInvite", func(t *testing.T) {
-		pc := req.ToDbLeaseInvite()
+		pc := req.ToDbLeaseInvite(property)
 
 		assert.Equal(t, req.TenantEmail, pc.TenantEmail)
 		assert.Equal(t, req.StartDate, pc.StartDate)
+		assert.InDelta(t, *req.RentalPricePerMonth, pc.RentalPricePerMonth, 0)
+		assert.InDelta(t, req.Charges, pc.Charges, 0)
+		assert.InDelta(t, property.DepositPrice, pc.DepositPrice, 0)
+		assert.Equal(t, req.PaymentDay, pc.PaymentDay)
+		assert.Equal(t, req.SpecialClauses, pc.InnerLeaseInvite.SpecialClauses)
+	})
+
+	t.Run("ToInviteDefaults", func(t *testing.T) {
+		req := models.InviteRequest{
+			TenantEmail: "test1@example.com",
+			StartDate:   time.Now(),
+		}
+		pc := req.ToDbLeaseInvite(property)
+
+		assert.InDelta(t, property.RentalPricePerMonth, pc.RentalPricePerMonth, 0)
+		assert.InDelta(t, property.DepositPrice, pc.DepositPrice, 0)
+		assert.Equal(t, 1, pc.PaymentDay)
 	})
 }
 
 func TestLeaseResponse(t *testing.T) {
 	model := db.LeaseModel{
 		InnerLease: db.InnerLease{
-			ID:         "1",
-			PropertyID: "101",
-			TenantID:   "201",
-			Active:     true,
-			StartDate:  time.Now(),
-			EndDate:    nil,
-			CreatedAt:  time.Now(),
+			ID:                  "1",
+			PropertyID:          "101",
+			TenantID:            "201",
+			Active:              true,
+			Status:              db.LeaseStatusActive,
+			RentalPricePerMonth: 800,
+			DepositPrice:        1600,
+			PaymentDay:          5,
+			StartDate:           time.Now(),
+			EndDate:             nil,
+			CreatedAt:           time.Now(),
 		},
 		RelationsLease: db.RelationsLease{
 			Tenant: &db.UserModel{
@@ -58,6 +91,16 @@ func TestLeaseResponse(t *testing.T) {
 					},
 				},
 			},
+			Guarantors: []db.GuarantorModel{{
+				InnerGuarantor: db.InnerGuarantor{
+					ID:        "1",
+					LeaseID:   "1",
+					Firstname: "Jane",
+					Lastname:  "Doe",
+					Email:     "janedoe@example.com",
+					Type:      db.GuaranteeTypeJoint,
+				},
+			}},
 		},
 	}
 
@@ -73,7 +116,13 @@ func TestLeaseResponse(t *testing.T) {
 		assert.Equal(t, model.StartDate, resp.StartDate)
 		assert.Equal(t, model.InnerLease.EndDate, resp.EndDate)
 		assert.Equal(t, model.Active, resp.Active)
+		assert.Equal(t, model.Status, resp.Status)
 		assert.Equal(t, model.CreatedAt, resp.CreatedAt)
+		assert.InDelta(t, model.RentalPricePerMonth, resp.RentalPricePerMonth, 0)
+		assert.InDelta(t, model.DepositPrice, resp.DepositPrice, 0)
+		assert.Equal(t, model.PaymentDay, resp.PaymentDay)
+		require.Len(t, resp.Guarantors, 1)
+		assert.Equal(t, model.Guarantors()[0].ID, resp.Guarantors[0].ID)
 	})
 
 	t.Run("DbLeaseToResponse", func(t *testing.T) {
@@ -87,6 +136,87 @@ func TestLeaseResponse(t *testing.T) {
 		assert.Equal(t, model.StartDate, resp.StartDate)
 		assert.Equal(t, model.InnerLease.EndDate, resp.EndDate)
 		assert.Equal(t, model.Active, resp.Active)
+		assert.Equal(t, model.Status, resp.Status)
 		assert.Equal(t, model.CreatedAt, resp.CreatedAt)
 	})
 }
+
+func TestTenantInviteResponse(t *testing.T) {
+	model := db.LeaseInviteModel{
+		InnerLeaseInvite: db.InnerLeaseInvite{
+			ID:                  "1",
+			PropertyID:          "1",
+			TenantEmail:         "test@example.com",
+			Furnished:           true,
+			StartDate:           time.Now(),
+			RentalPricePerMonth: 800,
+			DepositPrice:        1600,
+			PaymentDay:          5,
+			CreatedAt:           time.Now(),
+			ExpiresAt:           time.Now().Add(time.Hour),
+		},
+		RelationsLeaseInvite: db.RelationsLeaseInvite{
+			Property: &db.PropertyModel{
+				InnerProperty: db.InnerProperty{
+					ID:   "1",
+					Name: "Test Property",
+					City: "Paris",
+				},
+				RelationsProperty: db.RelationsProperty{
+					Owner: &db.UserModel{
+						InnerUser: db.InnerUser{
+							Firstname: "John",
+							Lastname:  "Doe",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	resp := models.DbLeaseInviteToTenantResponse(model)
+
+	assert.Equal(t, model.ID, resp.ID)
+	assert.Equal(t, model.Property().Name, resp.PropertyName)
+	assert.Equal(t, model.Property().City, resp.City)
+	assert.InDelta(t, model.RentalPricePerMonth, resp.RentalPricePerMonth, 0)
+	assert.Equal(t, model.PaymentDay, resp.PaymentDay)
+	assert.Equal(t, model.Property().Owner().Name(), resp.OwnerName)
+	assert.Equal(t, model.Furnished, resp.Furnished)
+	assert.Equal(t, model.StartDate, resp.StartDate)
+	assert.Equal(t, model.ExpiresAt, resp.ExpiresAt)
+}
+
+func TestLeaseExpectedStatus(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name     string
+		status   db.LeaseStatus
+		start    time.Time
+		end      *time.Time
+		expected db.LeaseStatus
+	}{
+		{"Upcoming", db.LeaseStatusUpcoming, now.Add(time.Nanosecond), nil, db.LeaseStatusUpcoming},
+		{"StartsNow", db.LeaseStatusUpcoming, now, nil, db.LeaseStatusActive},
+		{"ActiveNoEnd", db.LeaseStatusActive, now.Add(-time.Hour), nil, db.LeaseStatusActive},
+		{"ActiveEndsAfterPeriod", db.LeaseStatusActive, now.Add(-time.Hour), utils.Ptr(now.Add(db.LeaseEndingPeriod)), db.LeaseStatusActive},
+		{"EndingWithinPeriod", db.LeaseStatusActive, now.Add(-time.Hour), utils.Ptr(now.Add(db.LeaseEndingPeriod - time.Nanosecond)), db.LeaseStatusEnding},
+		{"EndingBeforeEnd", db.LeaseStatusEnding, now.Add(-time.Hour), utils.Ptr(now.Add(time.Nanosecond)), db.LeaseStatusEnding},
+		{"EndsNow", db.LeaseStatusEnding, now.Add(-time.Hour), utils.Ptr(now), db.LeaseStatusEnded},
+		{"Ended", db.LeaseStatusEnding, now.Add(-time.Hour), utils.Ptr(now.Add(-time.Nanosecond)), db.LeaseStatusEnded},
+		{"UpcomingEndedManually", db.LeaseStatusEnded, now.Add(time.Hour), nil, db.LeaseStatusEnded},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lease := db.LeaseModel{
+				InnerLease: db.InnerLease{
+					Status:    tt.status,
+					StartDate: tt.start,
+					EndDate:   tt.end,
+				},
+			}
+			assert.Equal(t, tt.expected, lease.ExpectedStatus(now))
+		})
+	}
+}
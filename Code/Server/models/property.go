@@ -57,18 +57,20 @@ type PropertyUpdateRequest struct {
 }
 
 type propertyLeaseResponse struct {
-	ID          string       `json:"id"`
-	TenantName  string       `json:"tenant_name"`
-	TenantEmail string       `json:"tenant_email"`
-	Active      bool         `json:"active"`
-	StartDate   db.DateTime  `json:"start_date"`
-	EndDate     *db.DateTime `json:"end_date"`
+	ID          string         `json:"id"`
+	TenantName  string         `json:"tenant_name"`
+	TenantEmail string         `json:"tenant_email"`
+	Active      bool           `json:"active"`
+	Status      db.LeaseStatus `json:"status"`
+	StartDate   db.DateTime    `json:"start_date"`
+	EndDate     *db.DateTime   `json:"end_date"`
 }
 
 type propertyInviteResponse struct {
 	TenantEmail string       `json:"tenant_email"`
 	StartDate   db.DateTime  `json:"start_date"`
 	EndDate     *db.DateTime `json:"end_date"`
+	ExpiresAt   db.DateTime  `json:"expires_at"`
 }
 
 type PropertyResponse struct {
@@ -113,7 +115,7 @@ func (p *PropertyResponse) FromDbProperty(model db.PropertyModel, leaseId string
 
 	p.NbDamage = 0
 	for _, lease := range model.Leases() {
-		p.NbDamage += utils.CountIf(lease.Damages(), func(x db.DamageModel) bool { return x.InnerDamage.FixedAt == nil })
+		p.NbDamage += utils.CountIf(lease.Damages(), func(x db.DamageModel) bool { return x.InnerDamage.FixedAt == nil && !x.IsClosed() })
 	}
 	p.Lease = nil
 	p.Invite = nil
@@ -128,6 +130,7 @@ func (p *PropertyResponse) FromDbProperty(model db.PropertyModel, leaseId string
 			TenantEmail: invite.TenantEmail,
 			StartDate:   invite.StartDate,
 			EndDate:     invite.InnerLeaseInvite.EndDate,
+			ExpiresAt:   invite.ExpiresAt,
 		}
 	default:
 		p.Status = StatusAvailable
@@ -141,6 +144,7 @@ func (p *PropertyResponse) FromDbProperty(model db.PropertyModel, leaseId string
 			TenantName:  active.Tenant().Name(),
 			TenantEmail: active.Tenant().Email,
 			Active:      active.Active,
+			Status:      active.Status,
 			StartDate:   active.StartDate,
 			EndDate:     active.InnerLease.EndDate,
 		}
@@ -158,6 +162,9 @@ type furnitureResponse struct {
 	Name     string `json:"name"`
 	Quantity int    `json:"quantity"`
 	Archived bool   `json:"archived"`
+
+	// IDs of the unfixed damages linked to this furniture, to flag it in the next inventory report
+	UnresolvedDamages []string `json:"unresolved_damages,omitempty"`
 }
 
 type roomResponse struct {
@@ -176,6 +183,15 @@ type PropertyInventoryResponse struct {
 func (p *PropertyInventoryResponse) FromDbProperty(model db.PropertyModel, leaseId string) {
 	p.PropertyResponse.FromDbProperty(model, leaseId)
 
+	unresolved := make(map[string][]string)
+	for _, lease := range model.Leases() {
+		for _, damage := range lease.Damages() {
+			if furnitureId, ok := damage.FurnitureID(); ok && damage.InnerDamage.FixedAt == nil && !damage.IsClosed() {
+				unresolved[furnitureId] = append(unresolved[furnitureId], damage.ID)
+			}
+		}
+	}
+
 	p.Rooms = make([]roomResponse, len(model.Rooms()))
 	for i, room := range model.Rooms() {
 		p.Rooms[i].ID = room.ID
@@ -188,6 +204,7 @@ func (p *PropertyInventoryResponse) FromDbProperty(model db.PropertyModel, lease
 			p.Rooms[i].Furnitures[j].Name = furniture.Name
 			p.Rooms[i].Furnitures[j].Quantity = furniture.Quantity
 			p.Rooms[i].Furnitures[j].Archived = furniture.Archived
+			p.Rooms[i].Furnitures[j].UnresolvedDamages = unresolved[furniture.ID]
 		}
 	}
 }
@@ -328,4 +328,19 @@ func TestPropertyInventoryResponse(t *testing.T) {
 		assert.Len(t, propertyResponse.Rooms, 1)
 		assert.Len(t, propertyResponse.Rooms[0].Furnitures, 1)
 	})
+
+	t.Run("FurnitureUnresolvedDamages", func(t *testing.T) {
+		newPc := BuildTestPropertyWithInventory("4")
+		newPc.RelationsProperty.Rooms[0].RelationsRoom.Furnitures = []db.FurnitureModel{
+			{InnerFurniture: db.InnerFurniture{ID: "1", Name: "Oven"}},
+			{InnerFurniture: db.InnerFurniture{ID: "2", Name: "Sofa"}},
+		}
+		newPc.RelationsProperty.Leases[0].RelationsLease.Damages[0].InnerDamage.FurnitureID = utils.Ptr("1")
+
+		propertyResponse := models.DbPropertyInventoryToResponse(newPc, "current")
+
+		assert.Len(t, propertyResponse.Rooms[0].Furnitures, 2)
+		assert.Equal(t, []string{"1"}, propertyResponse.Rooms[0].Furnitures[0].UnresolvedDamages)
+		assert.Nil(t, propertyResponse.Rooms[0].Furnitures[1].UnresolvedDamages)
+	})
 }
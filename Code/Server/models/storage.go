@@ -0,0 +1,25 @@
+package models
+
+// StorageUsageResponse is the storage used by an owner and the limits of their uploads, in bytes.
+type StorageUsageResponse struct {
+	Images          int `json:"images"`
+	Documents       int `json:"documents"`
+	Used            int `json:"used"`
+	Quota           int `json:"quota"`
+	Available       int `json:"available"`
+	MaxImageSize    int `json:"max_image_size"`
+	MaxDocumentSize int `json:"max_document_size"`
+}
+
+func NewStorageUsageResponse(images int, documents int, quota int, maxImageSize int, maxDocumentSize int) StorageUsageResponse {
+	used := images + documents
+	return StorageUsageResponse{
+		Images:          images,
+		Documents:       documents,
+		Used:            used,
+		Quota:           quota,
+		Available:       max(quota-used, 0),
+		MaxImageSize:    maxImageSize,
+		MaxDocumentSize: maxDocumentSize,
+	}
+}
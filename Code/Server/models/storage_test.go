@@ -0,0 +1,21 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"keyz/backend/models"
+)
+
+func TestNewStorageUsageResponse(t *testing.T) {
+	res := models.NewStorageUsageResponse(300, 200, 1000, 10, 20)
+	assert.Equal(t, 500, res.Used)
+	assert.Equal(t, 500, res.Available)
+	assert.Equal(t, 1000, res.Quota)
+	assert.Equal(t, 10, res.MaxImageSize)
+	assert.Equal(t, 20, res.MaxDocumentSize)
+
+	res = models.NewStorageUsageResponse(800, 400, 1000, 10, 20)
+	assert.Equal(t, 1200, res.Used)
+	assert.Equal(t, 0, res.Available)
+}
@@ -22,6 +22,12 @@ func (u *UserRequest) ToDbUser() db.UserModel {
 	}
 }
 
+type TenantRegisterRequest struct {
+	UserRequest
+
+	AcceptTerms bool `binding:"-" json:"accept_terms"`
+}
+
 type UserUpdateRequest struct {
 	Email     *string `binding:"omitempty,email"  json:"email,omitempty"`
 	Firstname *string `json:"firstname,omitempty"`
@@ -1,13 +1,147 @@
 package db
 
+import (
+	"strconv"
+	"time"
+)
+
 func (u UserModel) Name() string {
 	return u.Firstname + " " + u.Lastname
 }
 
+// LeaseEndingPeriod is how long before its end date a lease is considered as ending.
+const LeaseEndingPeriod = 30 * 24 * time.Hour
+
+// ExpectedStatus returns the status the lease should have at the given time, based on its dates.
+// A lease that was ended manually stays ended.
+func (l LeaseModel) ExpectedStatus(now time.Time) LeaseStatus {
+	if l.Status == LeaseStatusEnded {
+		return LeaseStatusEnded
+	}
+	if l.StartDate.After(now) {
+		return LeaseStatusUpcoming
+	}
+	end, ok := l.EndDate()
+	if !ok {
+		return LeaseStatusActive
+	}
+	if !end.After(now) {
+		return LeaseStatusEnded
+	}
+	if end.Before(now.Add(LeaseEndingPeriod)) {
+		return LeaseStatusEnding
+	}
+	return LeaseStatusActive
+}
+
 func (d DamageModel) IsFixed() bool {
 	return d.FixedOwner && d.FixedTenant
 }
 
+// IsClosed returns true if the damage was withdrawn by the tenant or dismissed by the owner without being fixed.
+func (d DamageModel) IsClosed() bool {
+	return d.InnerDamage.WithdrawnAt != nil || d.InnerDamage.DismissedAt != nil
+}
+
+// Fields recorded in the history of a damage.
+const (
+	DamageFieldCreated      = "created"
+	DamageFieldComment      = "comment"
+	DamageFieldPriority     = "priority"
+	DamageFieldPictures     = "pictures"
+	DamageFieldRead         = "read"
+	DamageFieldFixPlannedAt = "fix_planned_at"
+	DamageFieldFixedTenant  = "fixed_tenant"
+	DamageFieldFixedOwner   = "fixed_owner"
+	DamageFieldFixedAt      = "fixed_at"
+	DamageFieldFixRejected  = "fix_rejected"
+	DamageFieldReopened     = "reopened"
+	DamageFieldWithdrawn    = "withdrawn"
+	DamageFieldDismissed    = "dismissed"
+
+	DamageFieldContractorID     = "contractor_id"
+	DamageFieldInterventionDate = "intervention_date"
+	DamageFieldContractorDoneAt = "contractor_done_at"
+
+	DamageFieldEstimatedCost = "estimated_cost"
+	DamageFieldActualCost    = "actual_cost"
+	DamageFieldPayer         = "payer"
+)
+
+func NewDamageEvent(damageId string, field string, oldValue *string, newValue *string) DamageEventModel {
+	return DamageEventModel{
+		InnerDamageEvent: InnerDamageEvent{
+			DamageID: damageId,
+			Field:    field,
+			OldValue: oldValue,
+			NewValue: newValue,
+		},
+	}
+}
+
+func formatCost(cost *float64) *string {
+	if cost == nil {
+		return nil
+	}
+	value := strconv.FormatFloat(*cost, 'f', 2, 64)
+	return &value
+}
+
+func formatPayer(payer *Payer) *string {
+	if payer == nil {
+		return nil
+	}
+	value := string(*payer)
+	return &value
+}
+
+func formatDate(date *DateTime) *string {
+	if date == nil {
+		return nil
+	}
+	value := date.Format(time.RFC3339)
+	return &value
+}
+
+// Changes returns one event for each field that differs between the damage and its updated version.
+func (d DamageModel) Changes(updated DamageModel) []DamageEventModel {
+	var events []DamageEventModel
+	addChange := func(field string, oldValue string, newValue string) {
+		if oldValue != newValue {
+			events = append(events, NewDamageEvent(d.ID, field, &oldValue, &newValue))
+		}
+	}
+
+	addOptionalChange := func(field string, oldValue *string, newValue *string) {
+		if (oldValue == nil) != (newValue == nil) || (oldValue != nil && *oldValue != *newValue) {
+			events = append(events, NewDamageEvent(d.ID, field, oldValue, newValue))
+		}
+	}
+
+	addChange(DamageFieldComment, d.Comment, updated.Comment)
+	addChange(DamageFieldPriority, string(d.Priority), string(updated.Priority))
+	addChange(DamageFieldRead, strconv.FormatBool(d.Read), strconv.FormatBool(updated.Read))
+	addOptionalChange(DamageFieldFixPlannedAt, formatDate(d.InnerDamage.FixPlannedAt), formatDate(updated.InnerDamage.FixPlannedAt))
+	addOptionalChange(DamageFieldContractorID, d.InnerDamage.ContractorID, updated.InnerDamage.ContractorID)
+	addOptionalChange(DamageFieldInterventionDate, formatDate(d.InnerDamage.InterventionDate), formatDate(updated.InnerDamage.InterventionDate))
+	addOptionalChange(DamageFieldContractorDoneAt, formatDate(d.InnerDamage.ContractorDoneAt), formatDate(updated.InnerDamage.ContractorDoneAt))
+	addOptionalChange(DamageFieldEstimatedCost, formatCost(d.InnerDamage.EstimatedCost), formatCost(updated.InnerDamage.EstimatedCost))
+	addOptionalChange(DamageFieldActualCost, formatCost(d.InnerDamage.ActualCost), formatCost(updated.InnerDamage.ActualCost))
+	addOptionalChange(DamageFieldPayer, formatPayer(d.InnerDamage.Payer), formatPayer(updated.InnerDamage.Payer))
+	addChange(DamageFieldFixedTenant, strconv.FormatBool(d.FixedTenant), strconv.FormatBool(updated.FixedTenant))
+	addChange(DamageFieldFixedOwner, strconv.FormatBool(d.FixedOwner), strconv.FormatBool(updated.FixedOwner))
+	addOptionalChange(DamageFieldFixedAt, formatDate(d.InnerDamage.FixedAt), formatDate(updated.InnerDamage.FixedAt))
+	return events
+}
+
+// Cost returns the actual cost of the repair, or its estimated cost if the actual one is not known yet.
+func (d DamageModel) Cost() (float64, bool) {
+	if cost, ok := d.ActualCost(); ok {
+		return cost, true
+	}
+	return d.EstimatedCost()
+}
+
 type FixStatus string
 
 const (
@@ -16,10 +150,18 @@ const (
 	FixStatusAwaitingOwnerConfirmation  FixStatus = "awaiting_owner_confirmation"
 	FixStatusAwaitingTenantConfirmation FixStatus = "awaiting_tenant_confirmation"
 	FixStatusFixed                      FixStatus = "fixed"
+	FixStatusFixRejected                FixStatus = "fix_rejected"
+	FixStatusReopened                   FixStatus = "reopened"
+	FixStatusWithdrawn                  FixStatus = "withdrawn"
+	FixStatusDismissed                  FixStatus = "dismissed"
 )
 
 func (d DamageModel) FixStatus() FixStatus {
-	if d.IsFixed() {
+	if d.InnerDamage.WithdrawnAt != nil {
+		return FixStatusWithdrawn
+	} else if d.InnerDamage.DismissedAt != nil {
+		return FixStatusDismissed
+	} else if d.IsFixed() {
 		return FixStatusFixed
 	} else if d.FixedTenant && !d.FixedOwner {
 		return FixStatusAwaitingOwnerConfirmation
@@ -27,7 +169,91 @@ func (d DamageModel) FixStatus() FixStatus {
 		return FixStatusAwaitingTenantConfirmation
 	} else if d.InnerDamage.FixPlannedAt != nil {
 		return FixStatusPlanned
+	} else if d.InnerDamage.FixRejectedAt != nil {
+		return FixStatusFixRejected
+	} else if d.InnerDamage.ReopenedAt != nil {
+		return FixStatusReopened
 	} else {
 		return FixStatusPending
 	}
 }
+
+// CanRejectFix returns true if the other party marked the damage as fixed and is waiting for the given role to confirm.
+func (d DamageModel) CanRejectFix(role Role) bool {
+	if d.IsFixed() || d.IsClosed() {
+		return false
+	}
+	if role == RoleOwner {
+		return d.FixedTenant || d.InnerDamage.ContractorDoneAt != nil
+	}
+	return d.FixedOwner
+}
+
+// RoomState returns the state of the given room in the inventory report, or nil if it was not inspected.
+func (r InventoryReportModel) RoomState(roomID string) *RoomStateModel {
+	for _, roomState := range r.RoomStates() {
+		if roomState.RoomID == roomID {
+			return &roomState
+		}
+	}
+	return nil
+}
+
+// FurnitureState returns the state of the given furniture in the inventory report, or nil if it was not inspected.
+func (r InventoryReportModel) FurnitureState(furnitureID string) *FurnitureStateModel {
+	for _, furnitureState := range r.FurnitureStates() {
+		if furnitureState.FurnitureID == furnitureID {
+			return &furnitureState
+		}
+	}
+	return nil
+}
+
+// AppointmentReminderDelay is how long before a confirmed appointment its reminder email is sent.
+const AppointmentReminderDelay = 24 * time.Hour
+
+func (a DamageAppointmentModel) EndAt() DateTime {
+	return a.StartAt.Add(time.Duration(a.DurationMinutes) * time.Minute)
+}
+
+// LeaseInviteValidity is how long an invite can be accepted after being sent.
+const LeaseInviteValidity = 14 * 24 * time.Hour
+
+// LeaseInviteReminders are the delays before expiry at which a follow-up email is sent, in order.
+var LeaseInviteReminders = []time.Duration{7 * 24 * time.Hour, 2 * 24 * time.Hour}
+
+func (i LeaseInviteModel) IsExpired(now time.Time) bool {
+	return !i.ExpiresAt.After(now)
+}
+
+// NeedsReminder returns true if the next follow-up email of the invite is due.
+func (i LeaseInviteModel) NeedsReminder(now time.Time) bool {
+	if i.IsExpired(now) || i.RemindersSent >= len(LeaseInviteReminders) {
+		return false
+	}
+	return i.ExpiresAt.Sub(now) <= LeaseInviteReminders[i.RemindersSent]
+}
+
+func (g GuarantorModel) Name() string {
+	return g.Firstname + " " + g.Lastname
+}
+
+// DocumentRetentionPeriod is how long a deleted document stays in the trash before being purged.
+const DocumentRetentionPeriod = 30 * 24 * time.Hour
+
+// FirstVersionID returns the ID of the first version of the document, which identifies all its versions.
+func (d DocumentModel) FirstVersionID() string {
+	if originalID, ok := d.OriginalID(); ok {
+		return originalID
+	}
+	return d.ID
+}
+
+// PurgeAt returns when a deleted document will be permanently deleted.
+func (d DocumentModel) PurgeAt() (DateTime, bool) {
+	deletedAt, ok := d.DeletedAt()
+	if !ok {
+		return DateTime{}, false
+	}
+	return deletedAt.Add(DocumentRetentionPeriod), true
+}
@@ -0,0 +1,9 @@
+-- CreateEnum
+CREATE TYPE "leaseStatus" AS ENUM ('upcoming', 'active', 'ending', 'ended');
+
+-- AlterTable
+ALTER TABLE "lease" ADD COLUMN     "status" "leaseStatus" NOT NULL DEFAULT 'active';
+
+-- Initialize status of existing leases
+UPDATE "lease" SET "status" = 'ended' WHERE "active" = false;
+UPDATE "lease" SET "status" = 'upcoming' WHERE "active" = true AND "start_date" > NOW();
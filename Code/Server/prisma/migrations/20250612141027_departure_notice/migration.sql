@@ -0,0 +1,33 @@
+-- CreateEnum
+CREATE TYPE "departureReason" AS ENUM ('standard', 'tenseZone', 'jobLoss', 'newJob', 'transfer', 'health', 'socialAid');
+
+-- CreateEnum
+CREATE TYPE "noticeStatus" AS ENUM ('pending', 'discussing', 'accepted');
+
+-- AlterTable
+ALTER TABLE "lease" ADD COLUMN     "furnished" BOOLEAN NOT NULL DEFAULT false;
+
+-- AlterTable
+ALTER TABLE "leaseInvite" ADD COLUMN     "furnished" BOOLEAN NOT NULL DEFAULT false;
+
+-- CreateTable
+CREATE TABLE "departureNotice" (
+    "id" TEXT NOT NULL,
+    "requested_date" TIMESTAMP(3) NOT NULL,
+    "end_date" TIMESTAMP(3) NOT NULL,
+    "reason" "departureReason" NOT NULL,
+    "status" "noticeStatus" NOT NULL DEFAULT 'pending',
+    "comment" TEXT,
+    "owner_comment" TEXT,
+    "created_at" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" TIMESTAMP(3) NOT NULL,
+    "lease_id" TEXT NOT NULL,
+
+    CONSTRAINT "departureNotice_pkey" PRIMARY KEY ("id")
+);
+
+-- CreateIndex
+CREATE UNIQUE INDEX "departureNotice_lease_id_key" ON "departureNotice"("lease_id");
+
+-- AddForeignKey
+ALTER TABLE "departureNotice" ADD CONSTRAINT "departureNotice_lease_id_fkey" FOREIGN KEY ("lease_id") REFERENCES "lease"("id") ON DELETE RESTRICT ON UPDATE CASCADE;
@@ -0,0 +1,31 @@
+-- CreateEnum
+CREATE TYPE "guaranteeType" AS ENUM ('joint', 'simple', 'visale', 'organization', 'bank');
+
+-- AlterTable
+ALTER TABLE "document" ADD COLUMN     "guarantor_id" TEXT;
+
+-- CreateTable
+CREATE TABLE "guarantor" (
+    "id" TEXT NOT NULL,
+    "firstname" TEXT NOT NULL,
+    "lastname" TEXT NOT NULL,
+    "email" VARCHAR(255) NOT NULL,
+    "phone" TEXT,
+    "address" TEXT,
+    "type" "guaranteeType" NOT NULL,
+    "amount_cap" DOUBLE PRECISION,
+    "duration_months" INTEGER,
+    "created_at" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "lease_id" TEXT NOT NULL,
+
+    CONSTRAINT "guarantor_pkey" PRIMARY KEY ("id")
+);
+
+-- CreateIndex
+CREATE INDEX "guarantor_lease_id_idx" ON "guarantor"("lease_id");
+
+-- AddForeignKey
+ALTER TABLE "guarantor" ADD CONSTRAINT "guarantor_lease_id_fkey" FOREIGN KEY ("lease_id") REFERENCES "lease"("id") ON DELETE RESTRICT ON UPDATE CASCADE;
+
+-- AddForeignKey
+ALTER TABLE "document" ADD CONSTRAINT "document_guarantor_id_fkey" FOREIGN KEY ("guarantor_id") REFERENCES "guarantor"("id") ON DELETE SET NULL ON UPDATE CASCADE;
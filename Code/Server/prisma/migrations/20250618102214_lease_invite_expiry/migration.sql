@@ -0,0 +1,9 @@
+-- AlterTable
+ALTER TABLE "leaseInvite" ADD COLUMN     "expires_at" TIMESTAMP(3),
+ADD COLUMN     "reminders_sent" INTEGER NOT NULL DEFAULT 0;
+
+-- Existing invites expire 14 days after their creation
+UPDATE "leaseInvite" SET "expires_at" = "created_at" + INTERVAL '14 days';
+
+-- AlterTable
+ALTER TABLE "leaseInvite" ALTER COLUMN "expires_at" SET NOT NULL;
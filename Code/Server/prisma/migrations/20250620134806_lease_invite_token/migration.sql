@@ -0,0 +1,13 @@
+-- AlterTable
+ALTER TABLE "leaseInvite" ADD COLUMN     "token_hash" TEXT;
+
+-- Links already sent contain the invite id, which must no longer give access to the invite:
+-- expire the pending invites with an unknown random token, the owners can resend them
+UPDATE "leaseInvite" SET "token_hash" = encode(sha256(convert_to(gen_random_uuid()::text, 'UTF8')), 'hex'),
+                         "expires_at" = LEAST("expires_at", CURRENT_TIMESTAMP);
+
+-- AlterTable
+ALTER TABLE "leaseInvite" ALTER COLUMN "token_hash" SET NOT NULL;
+
+-- CreateIndex
+CREATE UNIQUE INDEX "leaseInvite_token_hash_key" ON "leaseInvite"("token_hash");
@@ -0,0 +1,28 @@
+-- AlterTable
+ALTER TABLE "lease" ADD COLUMN     "charges" DOUBLE PRECISION NOT NULL DEFAULT 0,
+ADD COLUMN     "deposit_price" DOUBLE PRECISION,
+ADD COLUMN     "payment_day" INTEGER NOT NULL DEFAULT 1,
+ADD COLUMN     "rental_price_per_month" DOUBLE PRECISION,
+ADD COLUMN     "special_clauses" TEXT,
+ADD COLUMN     "terms_accepted_at" TIMESTAMP(3);
+
+-- AlterTable
+ALTER TABLE "leaseInvite" ADD COLUMN     "charges" DOUBLE PRECISION NOT NULL DEFAULT 0,
+ADD COLUMN     "deposit_price" DOUBLE PRECISION,
+ADD COLUMN     "payment_day" INTEGER NOT NULL DEFAULT 1,
+ADD COLUMN     "rental_price_per_month" DOUBLE PRECISION,
+ADD COLUMN     "special_clauses" TEXT;
+
+-- Existing leases and invites take the current terms of their property
+UPDATE "lease" SET "rental_price_per_month" = "property"."rental_price_per_month", "deposit_price" = "property"."deposit_price"
+FROM "property" WHERE "lease"."property_id" = "property"."id";
+UPDATE "leaseInvite" SET "rental_price_per_month" = "property"."rental_price_per_month", "deposit_price" = "property"."deposit_price"
+FROM "property" WHERE "leaseInvite"."property_id" = "property"."id";
+
+-- AlterTable
+ALTER TABLE "lease" ALTER COLUMN "deposit_price" SET NOT NULL,
+ALTER COLUMN "rental_price_per_month" SET NOT NULL;
+
+-- AlterTable
+ALTER TABLE "leaseInvite" ALTER COLUMN "deposit_price" SET NOT NULL,
+ALTER COLUMN "rental_price_per_month" SET NOT NULL;
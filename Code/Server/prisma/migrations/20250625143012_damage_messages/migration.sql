@@ -0,0 +1,37 @@
+-- CreateTable
+CREATE TABLE "damageMessage" (
+    "id" TEXT NOT NULL,
+    "content" TEXT NOT NULL,
+    "created_at" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "damage_id" TEXT NOT NULL,
+    "author_id" TEXT NOT NULL,
+
+    CONSTRAINT "damageMessage_pkey" PRIMARY KEY ("id")
+);
+
+-- CreateTable
+CREATE TABLE "_damageMessageToimage" (
+    "A" TEXT NOT NULL,
+    "B" TEXT NOT NULL
+);
+
+-- CreateIndex
+CREATE INDEX "damageMessage_damage_id_idx" ON "damageMessage"("damage_id");
+
+-- CreateIndex
+CREATE UNIQUE INDEX "_damageMessageToimage_AB_unique" ON "_damageMessageToimage"("A", "B");
+
+-- CreateIndex
+CREATE INDEX "_damageMessageToimage_B_index" ON "_damageMessageToimage"("B");
+
+-- AddForeignKey
+ALTER TABLE "damageMessage" ADD CONSTRAINT "damageMessage_damage_id_fkey" FOREIGN KEY ("damage_id") REFERENCES "damage"("id") ON DELETE CASCADE ON UPDATE CASCADE;
+
+-- AddForeignKey
+ALTER TABLE "damageMessage" ADD CONSTRAINT "damageMessage_author_id_fkey" FOREIGN KEY ("author_id") REFERENCES "user"("id") ON DELETE RESTRICT ON UPDATE CASCADE;
+
+-- AddForeignKey
+ALTER TABLE "_damageMessageToimage" ADD CONSTRAINT "_damageMessageToimage_A_fkey" FOREIGN KEY ("A") REFERENCES "damageMessage"("id") ON DELETE CASCADE ON UPDATE CASCADE;
+
+-- AddForeignKey
+ALTER TABLE "_damageMessageToimage" ADD CONSTRAINT "_damageMessageToimage_B_fkey" FOREIGN KEY ("B") REFERENCES "image"("id") ON DELETE CASCADE ON UPDATE CASCADE;
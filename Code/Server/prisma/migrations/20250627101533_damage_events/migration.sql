@@ -0,0 +1,22 @@
+-- CreateTable
+CREATE TABLE "damageEvent" (
+    "id" TEXT NOT NULL,
+    "field" TEXT NOT NULL,
+    "old_value" TEXT,
+    "new_value" TEXT,
+    "actor_role" "role" NOT NULL,
+    "created_at" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "damage_id" TEXT NOT NULL,
+    "actor_id" TEXT NOT NULL,
+
+    CONSTRAINT "damageEvent_pkey" PRIMARY KEY ("id")
+);
+
+-- CreateIndex
+CREATE INDEX "damageEvent_damage_id_idx" ON "damageEvent"("damage_id");
+
+-- AddForeignKey
+ALTER TABLE "damageEvent" ADD CONSTRAINT "damageEvent_damage_id_fkey" FOREIGN KEY ("damage_id") REFERENCES "damage"("id") ON DELETE CASCADE ON UPDATE CASCADE;
+
+-- AddForeignKey
+ALTER TABLE "damageEvent" ADD CONSTRAINT "damageEvent_actor_id_fkey" FOREIGN KEY ("actor_id") REFERENCES "user"("id") ON DELETE RESTRICT ON UPDATE CASCADE;
@@ -0,0 +1,35 @@
+-- CreateEnum
+CREATE TYPE "trade" AS ENUM ('plumber', 'electrician', 'locksmith', 'carpenter', 'painter', 'heating', 'appliance', 'cleaning', 'other');
+
+-- AlterTable
+ALTER TABLE "damage" ADD COLUMN     "contractor_done_at" TIMESTAMP(3),
+ADD COLUMN     "contractor_id" TEXT,
+ADD COLUMN     "contractor_token_hash" TEXT,
+ADD COLUMN     "intervention_date" TIMESTAMP(3);
+
+-- CreateTable
+CREATE TABLE "contractor" (
+    "id" TEXT NOT NULL,
+    "name" TEXT NOT NULL,
+    "company" TEXT,
+    "trade" "trade" NOT NULL,
+    "email" VARCHAR(255) NOT NULL,
+    "phone" TEXT,
+    "notes" TEXT,
+    "created_at" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "owner_id" TEXT NOT NULL,
+
+    CONSTRAINT "contractor_pkey" PRIMARY KEY ("id")
+);
+
+-- CreateIndex
+CREATE INDEX "contractor_owner_id_idx" ON "contractor"("owner_id");
+
+-- CreateIndex
+CREATE UNIQUE INDEX "damage_contractor_token_hash_key" ON "damage"("contractor_token_hash");
+
+-- AddForeignKey
+ALTER TABLE "damage" ADD CONSTRAINT "damage_contractor_id_fkey" FOREIGN KEY ("contractor_id") REFERENCES "contractor"("id") ON DELETE SET NULL ON UPDATE CASCADE;
+
+-- AddForeignKey
+ALTER TABLE "contractor" ADD CONSTRAINT "contractor_owner_id_fkey" FOREIGN KEY ("owner_id") REFERENCES "user"("id") ON DELETE RESTRICT ON UPDATE CASCADE;
@@ -0,0 +1,13 @@
+-- CreateEnum
+CREATE TYPE "payer" AS ENUM ('owner', 'tenant', 'insurance');
+
+-- AlterTable
+ALTER TABLE "damage" ADD COLUMN     "actual_cost" DOUBLE PRECISION,
+ADD COLUMN     "estimated_cost" DOUBLE PRECISION,
+ADD COLUMN     "payer" "payer";
+
+-- AlterTable
+ALTER TABLE "document" ADD COLUMN     "damage_id" TEXT;
+
+-- AddForeignKey
+ALTER TABLE "document" ADD CONSTRAINT "document_damage_id_fkey" FOREIGN KEY ("damage_id") REFERENCES "damage"("id") ON DELETE SET NULL ON UPDATE CASCADE;
@@ -0,0 +1,5 @@
+-- AlterTable
+ALTER TABLE "damage" ADD COLUMN     "fix_rejected_at" TIMESTAMP(3),
+ADD COLUMN     "fix_rejected_reason" TEXT,
+ADD COLUMN     "reopened_at" TIMESTAMP(3),
+ADD COLUMN     "reopened_reason" TEXT;
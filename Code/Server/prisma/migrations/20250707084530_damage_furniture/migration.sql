@@ -0,0 +1,5 @@
+-- AlterTable
+ALTER TABLE "damage" ADD COLUMN     "furniture_id" TEXT;
+
+-- AddForeignKey
+ALTER TABLE "damage" ADD CONSTRAINT "damage_furniture_id_fkey" FOREIGN KEY ("furniture_id") REFERENCES "furniture"("id") ON DELETE SET NULL ON UPDATE CASCADE;
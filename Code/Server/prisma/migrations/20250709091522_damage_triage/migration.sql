@@ -0,0 +1,9 @@
+-- CreateEnum
+CREATE TYPE "damageCategory" AS ENUM ('plumbing', 'electrical', 'heating', 'appliance', 'structural', 'openings', 'furniture', 'pests', 'cosmetic', 'other');
+
+-- AlterTable
+ALTER TABLE "damage" ADD COLUMN     "triage_advice" TEXT,
+ADD COLUMN     "triage_category" "damageCategory",
+ADD COLUMN     "triage_priority" "priority",
+ADD COLUMN     "triage_responsible" "payer",
+ADD COLUMN     "triaged_at" TIMESTAMP(3);
@@ -0,0 +1,29 @@
+-- CreateEnum
+CREATE TYPE "appointmentStatus" AS ENUM ('proposed', 'confirmed', 'declined', 'cancelled');
+
+-- CreateEnum
+CREATE TYPE "appointmentParty" AS ENUM ('owner', 'tenant', 'contractor');
+
+-- CreateTable
+CREATE TABLE "damageAppointment" (
+    "id" TEXT NOT NULL,
+    "start_at" TIMESTAMP(3) NOT NULL,
+    "duration_minutes" INTEGER NOT NULL,
+    "status" "appointmentStatus" NOT NULL DEFAULT 'proposed',
+    "proposed_by" "appointmentParty" NOT NULL,
+    "created_at" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "confirmed_at" TIMESTAMP(3),
+    "reminder_sent" BOOLEAN NOT NULL DEFAULT false,
+    "damage_id" TEXT NOT NULL,
+
+    CONSTRAINT "damageAppointment_pkey" PRIMARY KEY ("id")
+);
+
+-- CreateIndex
+CREATE INDEX "damageAppointment_damage_id_idx" ON "damageAppointment"("damage_id");
+
+-- CreateIndex
+CREATE INDEX "damageAppointment_status_start_at_idx" ON "damageAppointment"("status", "start_at");
+
+-- AddForeignKey
+ALTER TABLE "damageAppointment" ADD CONSTRAINT "damageAppointment_damage_id_fkey" FOREIGN KEY ("damage_id") REFERENCES "damage"("id") ON DELETE CASCADE ON UPDATE CASCADE;
@@ -0,0 +1,8 @@
+-- CreateEnum
+CREATE TYPE "dismissal" AS ENUM ('notADamage', 'tenantResponsibility');
+
+-- AlterTable
+ALTER TABLE "damage" ADD COLUMN     "dismissal" "dismissal",
+ADD COLUMN     "dismissed_at" TIMESTAMP(3),
+ADD COLUMN     "dismissed_reason" TEXT,
+ADD COLUMN     "withdrawn_at" TIMESTAMP(3);
@@ -0,0 +1,17 @@
+-- AlterTable
+ALTER TABLE "document" ADD COLUMN     "size" INTEGER NOT NULL DEFAULT 0,
+ADD COLUMN     "storage_key" TEXT;
+
+-- AlterTable
+ALTER TABLE "image" ADD COLUMN     "size" INTEGER NOT NULL DEFAULT 0,
+ADD COLUMN     "storage_key" TEXT;
+
+-- Backfill the size of the blobs still in the database
+UPDATE "document" SET "size" = octet_length("data");
+UPDATE "image" SET "size" = octet_length("data");
+
+-- CreateIndex
+CREATE UNIQUE INDEX "document_storage_key_key" ON "document"("storage_key");
+
+-- CreateIndex
+CREATE UNIQUE INDEX "image_storage_key_key" ON "image"("storage_key");
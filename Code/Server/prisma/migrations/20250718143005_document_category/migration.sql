@@ -0,0 +1,10 @@
+-- CreateEnum
+CREATE TYPE "docCategory" AS ENUM ('lease', 'insurance', 'diagnostics', 'payslip', 'receipt', 'inventoryReport', 'other');
+
+-- AlterTable
+ALTER TABLE "document" ADD COLUMN     "category" "docCategory" NOT NULL DEFAULT 'other',
+ADD COLUMN     "expires_at" TIMESTAMP(3),
+ADD COLUMN     "tags" TEXT[];
+
+-- Classify the inventory reports generated by the server
+UPDATE "document" SET "category" = 'inventoryReport' WHERE "name" LIKE 'inventory\_report\_%';
@@ -0,0 +1,8 @@
+-- AlterTable
+ALTER TABLE "document" ADD COLUMN     "deleted_at" TIMESTAMP(3),
+ADD COLUMN     "latest" BOOLEAN NOT NULL DEFAULT true,
+ADD COLUMN     "original_id" TEXT,
+ADD COLUMN     "version" INTEGER NOT NULL DEFAULT 1;
+
+-- AddForeignKey
+ALTER TABLE "document" ADD CONSTRAINT "document_original_id_fkey" FOREIGN KEY ("original_id") REFERENCES "document"("id") ON DELETE CASCADE ON UPDATE CASCADE;
@@ -0,0 +1,17 @@
+-- AlterEnum
+-- This migration adds more than one value to an enum.
+-- With PostgreSQL versions 11 and earlier, this is not possible
+-- in a single migration. This can be worked around by creating
+-- multiple migrations, each migration adding only one value to
+-- the enum.
+
+
+ALTER TYPE "docType" ADD VALUE 'odt';
+ALTER TYPE "docType" ADD VALUE 'ods';
+ALTER TYPE "docType" ADD VALUE 'txt';
+ALTER TYPE "docType" ADD VALUE 'csv';
+ALTER TYPE "docType" ADD VALUE 'png';
+ALTER TYPE "docType" ADD VALUE 'jpeg';
+
+-- AlterEnum
+ALTER TYPE "imageType" ADD VALUE 'webp';
@@ -0,0 +1,9 @@
+-- AlterTable
+ALTER TABLE "document" ALTER COLUMN "data" DROP NOT NULL;
+
+-- AlterTable
+ALTER TABLE "image" ALTER COLUMN "data" DROP NOT NULL;
+
+-- Blobs moved to the storage kept an empty content in the database
+UPDATE "document" SET "data" = NULL WHERE "storage_key" IS NOT NULL;
+UPDATE "image" SET "data" = NULL WHERE "storage_key" IS NOT NULL;
@@ -0,0 +1,5 @@
+-- DropIndex
+DROP INDEX "leaseInvite_tenant_email_key";
+
+-- CreateIndex
+CREATE INDEX "leaseInvite_tenant_email_idx" ON "leaseInvite"("tenant_email");
@@ -179,6 +179,8 @@ func createMainProperty(c *services.PrismaDB, owner db.UserModel, tenant db.User
 
 	lease, err := c.Client.Lease.CreateOne(
 		db.Lease.StartDate.Set(time.Now()),
+		db.Lease.RentalPricePerMonth.Set(property.RentalPricePerMonth),
+		db.Lease.DepositPrice.Set(property.DepositPrice),
 		db.Lease.Tenant.Link(db.User.ID.Equals(tenant.ID)),
 		db.Lease.Property.Link(db.Property.ID.Equals(property.ID)),
 		db.Lease.EndDate.Set(time.Now().AddDate(1, 0, 0)),
@@ -239,6 +241,8 @@ func createReminder_1(c *services.PrismaDB, owner db.UserModel, password string)
 
 	lease, err := c.Client.Lease.CreateOne(
 		db.Lease.StartDate.Set(time.Now().AddDate(-1, 0, 0)),
+		db.Lease.RentalPricePerMonth.Set(property.RentalPricePerMonth),
+		db.Lease.DepositPrice.Set(property.DepositPrice),
 		db.Lease.Tenant.Link(db.User.ID.Equals(tenant.ID)),
 		db.Lease.Property.Link(db.Property.ID.Equals(property.ID)),
 		db.Lease.EndDate.Set(time.Now().AddDate(0, 0, 25)),
@@ -294,6 +298,8 @@ func createReminder_2(c *services.PrismaDB, owner db.UserModel, password string)
 
 	_, err = c.Client.Lease.CreateOne(
 		db.Lease.StartDate.Set(time.Now().AddDate(0, 0, -7)),
+		db.Lease.RentalPricePerMonth.Set(property.RentalPricePerMonth),
+		db.Lease.DepositPrice.Set(property.DepositPrice),
 		db.Lease.Tenant.Link(db.User.ID.Equals(tenant.ID)),
 		db.Lease.Property.Link(db.Property.ID.Equals(property.ID)),
 		db.Lease.EndDate.Set(time.Now().AddDate(1, 0, 0)),
@@ -347,7 +353,11 @@ func createReminder_5(c *services.PrismaDB, owner db.UserModel) {
 
 	_, err = c.Client.LeaseInvite.CreateOne(
 		db.LeaseInvite.TenantEmail.Set("tenant.invite@example.com"),
+		db.LeaseInvite.TokenHash.Set(utils.HashToken("seed-invite")),
 		db.LeaseInvite.StartDate.Set(time.Now().AddDate(0, 0, 7)),
+		db.LeaseInvite.ExpiresAt.Set(time.Now().AddDate(0, 0, 6)),
+		db.LeaseInvite.RentalPricePerMonth.Set(property.RentalPricePerMonth),
+		db.LeaseInvite.DepositPrice.Set(property.DepositPrice),
 		db.LeaseInvite.Property.Link(db.Property.ID.Equals(property.ID)),
 		db.LeaseInvite.CreatedAt.Set(time.Now().AddDate(0, 0, -8)),
 	).Exec(c.Context)
@@ -0,0 +1,23 @@
+package middlewares
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"keyz/backend/services/database"
+	"keyz/backend/utils"
+)
+
+func CheckContractorOwnership(contractorIdUrlParam string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims := utils.GetClaims(c)
+		contractor := database.GetContractorByID(c.Param(contractorIdUrlParam))
+		if contractor == nil || contractor.OwnerID != claims["id"] {
+			utils.AbortSendError(c, http.StatusNotFound, utils.ContractorNotFound, nil)
+			return
+		}
+
+		c.Set("contractor", *contractor)
+		c.Next()
+	}
+}
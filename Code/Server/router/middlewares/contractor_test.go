@@ -0,0 +1,74 @@
+package middlewares_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"keyz/backend/prisma/db"
+	"keyz/backend/router/middlewares"
+	"keyz/backend/services"
+	"keyz/backend/services/database"
+)
+
+func BuildTestContractor(id string, ownerId string) db.ContractorModel {
+	return db.ContractorModel{
+		InnerContractor: db.InnerContractor{
+			ID:      id,
+			Name:    "Mario",
+			Trade:   db.TradePlumber,
+			Email:   "mario@example.com",
+			OwnerID: ownerId,
+		},
+	}
+}
+
+func TestCheckContractorOwnership(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	m.Contractor.Expect(database.MockGetContractorByID(c)).Returns(BuildTestContractor("1", "1"))
+
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Params = gin.Params{gin.Param{Key: "contractorId", Value: "1"}}
+	ctx.Set("oauth.claims", map[string]string{"id": "1"})
+
+	middlewares.CheckContractorOwnership("contractorId")(ctx)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestCheckContractorOwnership_NotFound(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	m.Contractor.Expect(database.MockGetContractorByID(c)).Errors(db.ErrNotFound)
+
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Params = gin.Params{gin.Param{Key: "contractorId", Value: "1"}}
+	ctx.Set("oauth.claims", map[string]string{"id": "1"})
+
+	middlewares.CheckContractorOwnership("contractorId")(ctx)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestCheckContractorOwnership_NotYours(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	m.Contractor.Expect(database.MockGetContractorByID(c)).Returns(BuildTestContractor("1", "2"))
+
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Params = gin.Params{gin.Param{Key: "contractorId", Value: "1"}}
+	ctx.Set("oauth.claims", map[string]string{"id": "1"})
+
+	middlewares.CheckContractorOwnership("contractorId")(ctx)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -1,14 +1,21 @@
 package middlewares
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"keyz/backend/services/storage"
+	"keyz/backend/utils"
 )
 
 func PanicRecovery(c *gin.Context, err any) {
 	switch e := err.(type) {
 	case error:
+		if errors.Is(e, storage.ErrNotFound) {
+			utils.AbortSendError(c, http.StatusNotFound, utils.FileNotFound, e)
+			return
+		}
 		c.Abort()
 		c.String(http.StatusInternalServerError, e.Error())
 	case string:
@@ -8,6 +8,8 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"keyz/backend/router/middlewares"
+	"keyz/backend/services/storage"
+	"keyz/backend/utils"
 )
 
 func TestPanicRecovery_WithError(t *testing.T) {
@@ -43,3 +45,14 @@ func TestPanicRecovery_WithUnknownType(t *testing.T) {
 	assert.Equal(t, http.StatusInternalServerError, w.Code)
 	assert.Equal(t, "An unknown error occurred", w.Body.String())
 }
+
+func TestPanicRecovery_WithBlobNotFound(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+
+	middlewares.PanicRecovery(c, storage.ErrNotFound)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Contains(t, w.Body.String(), string(utils.FileNotFound))
+}
@@ -68,12 +68,14 @@ func CheckLeaseTenantOwnership(leaseIdUrlParam string) gin.HandlerFunc {
 	}
 }
 
-func CheckDocumentLeaseOwnership(docIdUrlParam string) gin.HandlerFunc {
+// checkDocumentLeaseOwnership only finds documents of the lease that are in the trash when deleted is true,
+// and that are not otherwise.
+func checkDocumentLeaseOwnership(docIdUrlParam string, deleted bool) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		lease, _ := c.MustGet("lease").(db.LeaseModel)
 
 		doc := database.GetDocumentByID(c.Param(docIdUrlParam))
-		if doc == nil || doc.LeaseID != lease.ID {
+		if doc == nil || doc.LeaseID != lease.ID || (doc.InnerDocument.DeletedAt != nil) != deleted {
 			utils.AbortSendError(c, http.StatusNotFound, utils.DocumentNotFound, nil)
 			return
 		}
@@ -83,6 +85,14 @@ func CheckDocumentLeaseOwnership(docIdUrlParam string) gin.HandlerFunc {
 	}
 }
 
+func CheckDocumentLeaseOwnership(docIdUrlParam string) gin.HandlerFunc {
+	return checkDocumentLeaseOwnership(docIdUrlParam, false)
+}
+
+func CheckDeletedDocumentLeaseOwnership(docIdUrlParam string) gin.HandlerFunc {
+	return checkDocumentLeaseOwnership(docIdUrlParam, true)
+}
+
 func CheckDamageLeaseOwnership(damageIdUrlParam string) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		lease, _ := c.MustGet("lease").(db.LeaseModel)
@@ -97,3 +107,33 @@ func CheckDamageLeaseOwnership(damageIdUrlParam string) gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+func CheckAppointmentDamageOwnership(appointmentIdUrlParam string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		damage, _ := c.MustGet("damage").(db.DamageModel)
+
+		appointment := database.GetDamageAppointmentByID(c.Param(appointmentIdUrlParam))
+		if appointment == nil || appointment.DamageID != damage.ID {
+			utils.AbortSendError(c, http.StatusNotFound, utils.AppointmentNotFound, nil)
+			return
+		}
+
+		c.Set("appointment", *appointment)
+		c.Next()
+	}
+}
+
+func CheckGuarantorLeaseOwnership(guarantorIdUrlParam string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		lease, _ := c.MustGet("lease").(db.LeaseModel)
+
+		guarantor := database.GetGuarantorByID(c.Param(guarantorIdUrlParam))
+		if guarantor == nil || guarantor.LeaseID != lease.ID {
+			utils.AbortSendError(c, http.StatusNotFound, utils.GuarantorNotFound, nil)
+			return
+		}
+
+		c.Set("guarantor", *guarantor)
+		c.Next()
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
@@ -11,6 +12,7 @@ import (
 	"keyz/backend/router/middlewares"
 	"keyz/backend/services"
 	"keyz/backend/services/database"
+	"keyz/backend/utils"
 )
 
 func TestCheckLeaseInvite(t *testing.T) {
@@ -217,6 +219,90 @@ func TestCheckDocumentOwnership_LeaseMismatch(t *testing.T) {
 	assert.Equal(t, http.StatusNotFound, w.Code)
 }
 
+func TestCheckDocumentOwnership_Deleted(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	lease := db.LeaseModel{
+		InnerLease: db.InnerLease{
+			ID: "1",
+		},
+	}
+	doc := db.DocumentModel{
+		InnerDocument: db.InnerDocument{
+			ID:        "1",
+			LeaseID:   "1",
+			DeletedAt: utils.Ptr(time.Now()),
+		},
+	}
+	m.Document.Expect(database.MockGetDocumentByID(c)).Returns(doc)
+
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Set("lease", lease)
+	ctx.Params = gin.Params{gin.Param{Key: "docId", Value: "1"}}
+
+	middlewares.CheckDocumentLeaseOwnership("docId")(ctx)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestCheckDeletedDocumentOwnership(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	lease := db.LeaseModel{
+		InnerLease: db.InnerLease{
+			ID: "1",
+		},
+	}
+	doc := db.DocumentModel{
+		InnerDocument: db.InnerDocument{
+			ID:        "1",
+			LeaseID:   "1",
+			DeletedAt: utils.Ptr(time.Now()),
+		},
+	}
+	m.Document.Expect(database.MockGetDocumentByID(c)).Returns(doc)
+
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Set("lease", lease)
+	ctx.Params = gin.Params{gin.Param{Key: "docId", Value: "1"}}
+
+	middlewares.CheckDeletedDocumentLeaseOwnership("docId")(ctx)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.False(t, ctx.IsAborted())
+}
+
+func TestCheckDeletedDocumentOwnership_NotDeleted(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	lease := db.LeaseModel{
+		InnerLease: db.InnerLease{
+			ID: "1",
+		},
+	}
+	doc := db.DocumentModel{
+		InnerDocument: db.InnerDocument{
+			ID:      "1",
+			LeaseID: "1",
+		},
+	}
+	m.Document.Expect(database.MockGetDocumentByID(c)).Returns(doc)
+
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Set("lease", lease)
+	ctx.Params = gin.Params{gin.Param{Key: "docId", Value: "1"}}
+
+	middlewares.CheckDeletedDocumentLeaseOwnership("docId")(ctx)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
 func TestCheckLeaseTenantOwnership_CurrentLease(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	c, m, ensure := services.ConnectDBTest()
@@ -375,3 +461,78 @@ func TestCheckDamageLeaseOwnership_LeaseMismatch(t *testing.T) {
 	middlewares.CheckDamageLeaseOwnership("damageId")(ctx)
 	assert.Equal(t, http.StatusNotFound, w.Code)
 }
+
+func TestCheckAppointmentDamageOwnership(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	damage := db.DamageModel{
+		InnerDamage: db.InnerDamage{
+			ID: "1",
+		},
+	}
+	appointment := db.DamageAppointmentModel{
+		InnerDamageAppointment: db.InnerDamageAppointment{
+			ID:       "1",
+			DamageID: "1",
+		},
+	}
+	m.DamageAppointment.Expect(database.MockGetDamageAppointmentByID(c)).Returns(appointment)
+
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Set("damage", damage)
+	ctx.Params = gin.Params{gin.Param{Key: "appointmentId", Value: "1"}}
+
+	middlewares.CheckAppointmentDamageOwnership("appointmentId")(ctx)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestCheckAppointmentDamageOwnership_AppointmentNotFound(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	damage := db.DamageModel{
+		InnerDamage: db.InnerDamage{
+			ID: "1",
+		},
+	}
+	m.DamageAppointment.Expect(database.MockGetDamageAppointmentByID(c)).Errors(db.ErrNotFound)
+
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Set("damage", damage)
+	ctx.Params = gin.Params{gin.Param{Key: "appointmentId", Value: "1"}}
+
+	middlewares.CheckAppointmentDamageOwnership("appointmentId")(ctx)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestCheckAppointmentDamageOwnership_DamageMismatch(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	damage := db.DamageModel{
+		InnerDamage: db.InnerDamage{
+			ID: "1",
+		},
+	}
+	appointment := db.DamageAppointmentModel{
+		InnerDamageAppointment: db.InnerDamageAppointment{
+			ID:       "1",
+			DamageID: "2",
+		},
+	}
+	m.DamageAppointment.Expect(database.MockGetDamageAppointmentByID(c)).Returns(appointment)
+
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Set("damage", damage)
+	ctx.Params = gin.Params{gin.Param{Key: "appointmentId", Value: "1"}}
+
+	middlewares.CheckAppointmentDamageOwnership("appointmentId")(ctx)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -0,0 +1,25 @@
+package middlewares
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"keyz/backend/services/storage"
+	"keyz/backend/utils"
+)
+
+// LimitRequestSize refuses the requests whose body is bigger than storage.MaxRequestSize. When the size is not
+// announced, reading the body fails past the limit, so the binding of the request fails.
+func LimitRequestSize() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		limit := int64(storage.MaxRequestSize)
+		if c.Request.ContentLength > limit {
+			utils.AbortSendError(c, http.StatusRequestEntityTooLarge, utils.FileTooLarge, nil)
+			return
+		}
+		if c.Request.Body != nil {
+			c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, limit)
+		}
+		c.Next()
+	}
+}
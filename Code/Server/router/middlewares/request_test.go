@@ -0,0 +1,70 @@
+package middlewares_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"keyz/backend/router/middlewares"
+	"keyz/backend/services/storage"
+	"keyz/backend/utils"
+)
+
+func limitRequestSizeRouter(t *testing.T) *gin.Engine {
+	maxRequestSize := storage.MaxRequestSize
+	t.Cleanup(func() { storage.MaxRequestSize = maxRequestSize })
+	storage.MaxRequestSize = 10
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(middlewares.LimitRequestSize())
+	r.POST("/", func(c *gin.Context) {
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+		c.String(http.StatusOK, string(body))
+	})
+	return r
+}
+
+func TestLimitRequestSize(t *testing.T) {
+	r := limitRequestSizeRouter(t)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/", strings.NewReader("small"))
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "small", w.Body.String())
+}
+
+func TestLimitRequestSize_TooLarge(t *testing.T) {
+	r := limitRequestSizeRouter(t)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/", strings.NewReader("far too large"))
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	var resp utils.Error
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, utils.FileTooLarge, resp.Code)
+}
+
+func TestLimitRequestSize_UnknownLength(t *testing.T) {
+	r := limitRequestSizeRouter(t)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/", io.NopCloser(strings.NewReader("far too large")))
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
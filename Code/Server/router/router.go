@@ -31,6 +31,7 @@ func registerAPIRoutes(r *gin.Engine, test bool) {
 		nil)
 
 	v1 := r.Group("/v1")
+	v1.Use(middlewares.LimitRequestSize())
 	{
 		contact := v1.Group("/contact/")
 		{
@@ -44,12 +45,20 @@ func registerAPIRoutes(r *gin.Engine, test bool) {
 		auth := v1.Group("/auth/")
 		{
 			auth.POST("/register/", controllers.RegisterOwner)
-			auth.POST("/invite/:id/", controllers.RegisterTenant)
+			auth.GET("/invite/:token/", controllers.GetInvite)
+			auth.POST("/invite/:token/", controllers.RegisterTenant)
 			if !test {
 				auth.POST("/token/", controllers.TokenAuth(bServer))
 			}
 		}
 
+		contractor := v1.Group("/contractor/")
+		{
+			contractor.GET("/jobs/:token/", controllers.GetContractorJob)
+			contractor.POST("/jobs/:token/done/", controllers.MarkContractorJobDone)
+			contractor.POST("/jobs/:token/appointments/", controllers.ProposeContractorJobAppointments)
+		}
+
 		root := v1.Group("/")
 		{
 			if !test {
@@ -81,10 +90,17 @@ func registerValidators() {
 		panic("Could not register validator")
 	}
 	_ = v.RegisterValidation("priority", validators.Priority)
+	_ = v.RegisterValidation("payer", validators.Payer)
+	_ = v.RegisterValidation("fixStatus", validators.FixStatus)
+	_ = v.RegisterValidation("dismissal", validators.Dismissal)
 	_ = v.RegisterValidation("reportType", validators.ReportType)
 	_ = v.RegisterValidation("state", validators.State)
 	_ = v.RegisterValidation("cleanliness", validators.Cleanliness)
 	_ = v.RegisterValidation("roomType", validators.RoomType)
+	_ = v.RegisterValidation("departureReason", validators.DepartureReason)
+	_ = v.RegisterValidation("guaranteeType", validators.GuaranteeType)
+	_ = v.RegisterValidation("trade", validators.Trade)
+	_ = v.RegisterValidation("docCategory", validators.DocCategory)
 }
 
 func Routes() *gin.Engine {
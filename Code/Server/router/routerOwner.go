@@ -11,6 +11,21 @@ func registerOwnerRoutes(owner *gin.RouterGroup) {
 	owner.Use(middlewares.AuthorizeOwner())
 
 	owner.GET("/dashboard/", controllers.GetOwnerDashboard)
+	owner.GET("/storage/", controllers.GetOwnerStorage)
+
+	contractors := owner.Group("/contractors/")
+	{
+		contractors.POST("/", controllers.CreateContractor)
+		contractors.GET("/", controllers.GetContractors)
+
+		contractorId := contractors.Group("/:contractor_id/")
+		{
+			contractorId.Use(middlewares.CheckContractorOwnership("contractor_id"))
+			contractorId.GET("/", controllers.GetContractor)
+			contractorId.PUT("/", controllers.UpdateContractor)
+			contractorId.DELETE("/", controllers.DeleteContractor)
+		}
+	}
 
 	properties := owner.Group("/properties/")
 	{
@@ -29,8 +44,11 @@ func registerOwnerRoutes(owner *gin.RouterGroup) {
 			// TODO: move to lease routes
 			propertyId.POST("/send-invite/", controllers.InviteTenant)
 			propertyId.DELETE("/cancel-invite/", middlewares.CheckLeaseInvite("property_id"), controllers.CancelInvite)
+			propertyId.POST("/resend-invite/", middlewares.CheckLeaseInvite("property_id"), controllers.ResendInvite)
 
 			propertyId.GET("/damages/", controllers.GetDamagesByProperty)
+			propertyId.GET("/damages/export/", controllers.ExportDamagesByProperty)
+			propertyId.GET("/expenses/", controllers.GetPropertyExpenses)
 
 			reports := propertyId.Group("/inventory-reports/")
 			{
@@ -57,9 +75,31 @@ func registerOwnerLeaseRoutes(leases *gin.RouterGroup) {
 		leaseId.GET("/", controllers.GetLease)
 		leaseId.PUT("/end/", controllers.EndLease)
 
+		notice := leaseId.Group("/notice/")
+		{
+			notice.GET("/", controllers.GetDepartureNotice)
+			notice.PUT("/accept/", controllers.AcceptDepartureNotice)
+			notice.PUT("/discuss/", controllers.DiscussDepartureNotice)
+		}
+
+		guarantors := leaseId.Group("/guarantors/")
+		{
+			guarantors.POST("/", controllers.CreateGuarantor)
+			guarantors.GET("/", controllers.GetGuarantorsByLease)
+
+			guarantorId := guarantors.Group("/:guarantor_id/")
+			{
+				guarantorId.Use(middlewares.CheckGuarantorLeaseOwnership("guarantor_id"))
+				guarantorId.DELETE("/", controllers.DeleteGuarantor)
+				guarantorId.POST("/docs/", controllers.UploadGuarantorDocument)
+				guarantorId.POST("/notify/", controllers.NotifyGuarantor)
+			}
+		}
+
 		damages := leaseId.Group("/damages/")
 		{
 			damages.GET("/", controllers.GetDamagesByLease)
+			damages.GET("/export/", controllers.ExportDamagesByLease)
 
 			damageId := damages.Group("/:damage_id/")
 			{
@@ -67,6 +107,18 @@ func registerOwnerLeaseRoutes(leases *gin.RouterGroup) {
 				damageId.GET("/", controllers.GetDamage)
 				damageId.PUT("/", controllers.UpdateDamageOwner)
 				damageId.PUT("/fix/", controllers.FixDamage)
+				damageId.PUT("/fix/reject/", controllers.RejectDamageFix)
+				damageId.PUT("/reopen/", controllers.ReopenDamage)
+				damageId.PUT("/dismiss/", controllers.DismissDamage)
+				damageId.PUT("/assign/", controllers.AssignDamageContractor)
+				damageId.PUT("/costs/", controllers.UpdateDamageCosts)
+				damageId.POST("/invoices/", controllers.UploadDamageInvoice)
+				damageId.GET("/invoices/", controllers.GetDamageInvoices)
+				damageId.GET("/claim/", controllers.ExportDamageClaim)
+				damageId.POST("/messages/", controllers.CreateDamageMessage)
+				damageId.GET("/appointments/", controllers.GetDamageAppointments)
+				damageId.POST("/appointments/", controllers.ProposeDamageAppointments)
+				damageId.PUT("/appointments/:appointment_id/confirm/", middlewares.CheckAppointmentDamageOwnership("appointment_id"), controllers.ConfirmDamageAppointment)
 			}
 		}
 
@@ -74,11 +126,17 @@ func registerOwnerLeaseRoutes(leases *gin.RouterGroup) {
 		{
 			docs.POST("/", controllers.UploadDocument)
 			docs.GET("/", controllers.GetAllDocumentsByLease)
+			docs.GET("/trash/", controllers.GetDeletedDocuments)
+			docs.POST("/trash/:doc_id/restore/", middlewares.CheckDeletedDocumentLeaseOwnership("doc_id"), controllers.RestoreDocument)
 
 			docId := docs.Group("/:doc_id/")
 			{
 				docId.Use(middlewares.CheckDocumentLeaseOwnership("doc_id"))
 				docId.GET("/", controllers.GetDocument)
+				docId.GET("/download/", controllers.DownloadDocument)
+				docId.PUT("/", controllers.UpdateDocumentInfo)
+				docId.GET("/versions/", controllers.GetDocumentVersions)
+				docId.POST("/versions/", controllers.UploadDocumentVersion)
 				docId.DELETE("/", controllers.DeleteDocument)
 			}
 		}
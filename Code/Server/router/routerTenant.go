@@ -10,7 +10,14 @@ import (
 func registerTenantRoutes(tenant *gin.RouterGroup) {
 	tenant.Use(middlewares.AuthorizeTenant())
 
-	tenant.POST("/invite/:id/", controllers.AcceptInvite)
+	tenant.POST("/invite/:token/", controllers.AcceptInvite)
+
+	invites := tenant.Group("/invites/")
+	{
+		invites.GET("/", controllers.GetTenantInvites)
+		invites.POST("/:invite_id/accept/", controllers.AcceptInviteByID)
+		invites.POST("/:invite_id/decline/", controllers.DeclineInvite)
+	}
 
 	leases := tenant.Group("/leases/")
 	{
@@ -20,6 +27,9 @@ func registerTenantRoutes(tenant *gin.RouterGroup) {
 		{
 			leaseId.Use(middlewares.CheckLeaseTenantOwnership("lease_id"))
 			leaseId.GET("/", controllers.GetLease)
+			leaseId.GET("/notice/", controllers.GetDepartureNotice)
+			leaseId.POST("/notice/", controllers.SubmitDepartureNotice)
+			leaseId.GET("/guarantors/", controllers.GetGuarantorsByLease)
 
 			property := leaseId.Group("/property/")
 			{
@@ -35,6 +45,7 @@ func registerTenantRoutes(tenant *gin.RouterGroup) {
 			{
 				damages.POST("/", controllers.CreateDamage)
 				damages.GET("/", controllers.GetDamagesByLease)
+				damages.GET("/export/", controllers.ExportDamagesByLease)
 
 				damageId := damages.Group("/:damage_id/")
 				{
@@ -42,6 +53,14 @@ func registerTenantRoutes(tenant *gin.RouterGroup) {
 					damageId.GET("/", controllers.GetDamage)
 					damageId.PUT("/", controllers.UpdateDamageTenant)
 					damageId.PUT("/fix/", controllers.FixDamage)
+					damageId.PUT("/fix/reject/", controllers.RejectDamageFix)
+					damageId.PUT("/reopen/", controllers.ReopenDamage)
+					damageId.PUT("/withdraw/", controllers.WithdrawDamage)
+					damageId.POST("/messages/", controllers.CreateDamageMessage)
+					damageId.GET("/invoices/", controllers.GetDamageInvoices)
+					damageId.GET("/appointments/", controllers.GetDamageAppointments)
+					damageId.POST("/appointments/", controllers.ProposeDamageAppointments)
+					damageId.PUT("/appointments/:appointment_id/confirm/", middlewares.CheckAppointmentDamageOwnership("appointment_id"), controllers.ConfirmDamageAppointment)
 				}
 			}
 
@@ -54,6 +73,9 @@ func registerTenantRoutes(tenant *gin.RouterGroup) {
 				{
 					docId.Use(middlewares.CheckDocumentLeaseOwnership("doc_id"))
 					docId.GET("/", controllers.GetDocument)
+					docId.GET("/download/", controllers.DownloadDocument)
+					docId.GET("/versions/", controllers.GetDocumentVersions)
+					docId.POST("/versions/", controllers.UploadDocumentVersion)
 				}
 			}
 
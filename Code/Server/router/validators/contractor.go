@@ -0,0 +1,20 @@
+package validators
+
+import (
+	"github.com/go-playground/validator/v10"
+	"keyz/backend/prisma/db"
+)
+
+var Trade validator.Func = func(fl validator.FieldLevel) bool {
+	p, ok := fl.Field().Interface().(db.Trade)
+	if !ok {
+		return false
+	}
+	switch p {
+	case db.TradePlumber, db.TradeElectrician, db.TradeLocksmith, db.TradeCarpenter, db.TradePainter,
+		db.TradeHeating, db.TradeAppliance, db.TradeCleaning, db.TradeOther:
+		return true
+	default:
+		return false
+	}
+}
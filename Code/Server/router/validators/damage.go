@@ -17,3 +17,43 @@ var Priority validator.Func = func(fl validator.FieldLevel) bool {
 		return false
 	}
 }
+
+var Payer validator.Func = func(fl validator.FieldLevel) bool {
+	p, ok := fl.Field().Interface().(db.Payer)
+	if !ok {
+		return false
+	}
+	switch p {
+	case db.PayerOwner, db.PayerTenant, db.PayerInsurance:
+		return true
+	default:
+		return false
+	}
+}
+
+var FixStatus validator.Func = func(fl validator.FieldLevel) bool {
+	s, ok := fl.Field().Interface().(db.FixStatus)
+	if !ok {
+		return false
+	}
+	switch s {
+	case db.FixStatusPending, db.FixStatusPlanned, db.FixStatusAwaitingOwnerConfirmation, db.FixStatusAwaitingTenantConfirmation,
+		db.FixStatusFixed, db.FixStatusFixRejected, db.FixStatusReopened, db.FixStatusWithdrawn, db.FixStatusDismissed:
+		return true
+	default:
+		return false
+	}
+}
+
+var Dismissal validator.Func = func(fl validator.FieldLevel) bool {
+	d, ok := fl.Field().Interface().(db.Dismissal)
+	if !ok {
+		return false
+	}
+	switch d {
+	case db.DismissalNotADamage, db.DismissalTenantResponsibility:
+		return true
+	default:
+		return false
+	}
+}
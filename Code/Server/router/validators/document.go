@@ -0,0 +1,20 @@
+package validators
+
+import (
+	"github.com/go-playground/validator/v10"
+	"keyz/backend/prisma/db"
+)
+
+var DocCategory validator.Func = func(fl validator.FieldLevel) bool {
+	c, ok := fl.Field().Interface().(db.DocCategory)
+	if !ok {
+		return false
+	}
+	switch c {
+	case db.DocCategoryLease, db.DocCategoryInsurance, db.DocCategoryDiagnostics, db.DocCategoryPayslip,
+		db.DocCategoryReceipt, db.DocCategoryInventoryReport, db.DocCategoryOther:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,33 @@
+package validators
+
+import (
+	"github.com/go-playground/validator/v10"
+	"keyz/backend/prisma/db"
+)
+
+var DepartureReason validator.Func = func(fl validator.FieldLevel) bool {
+	p, ok := fl.Field().Interface().(db.DepartureReason)
+	if !ok {
+		return false
+	}
+	switch p {
+	case db.DepartureReasonStandard, db.DepartureReasonTenseZone, db.DepartureReasonJobLoss, db.DepartureReasonNewJob,
+		db.DepartureReasonTransfer, db.DepartureReasonHealth, db.DepartureReasonSocialAid:
+		return true
+	default:
+		return false
+	}
+}
+
+var GuaranteeType validator.Func = func(fl validator.FieldLevel) bool {
+	p, ok := fl.Field().Interface().(db.GuaranteeType)
+	if !ok {
+		return false
+	}
+	switch p {
+	case db.GuaranteeTypeJoint, db.GuaranteeTypeSimple, db.GuaranteeTypeVisale, db.GuaranteeTypeOrganization, db.GuaranteeTypeBank:
+		return true
+	default:
+		return false
+	}
+}
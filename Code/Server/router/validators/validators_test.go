@@ -95,3 +95,108 @@ func TestPriority(t *testing.T) {
 	}
 	assert.False(t, validators.Priority(MockFieldLevel{Val: "invalid"}))
 }
+
+func TestPayer(t *testing.T) {
+	validPayers := []db.Payer{
+		db.PayerOwner,
+		db.PayerTenant,
+		db.PayerInsurance,
+	}
+	for _, p := range validPayers {
+		assert.True(t, validators.Payer(MockFieldLevel{Val: p}))
+	}
+	assert.False(t, validators.Payer(MockFieldLevel{Val: "invalid"}))
+}
+
+func TestFixStatus(t *testing.T) {
+	validStatuses := []db.FixStatus{
+		db.FixStatusPending,
+		db.FixStatusPlanned,
+		db.FixStatusAwaitingOwnerConfirmation,
+		db.FixStatusAwaitingTenantConfirmation,
+		db.FixStatusFixed,
+		db.FixStatusFixRejected,
+		db.FixStatusReopened,
+		db.FixStatusWithdrawn,
+		db.FixStatusDismissed,
+	}
+	for _, s := range validStatuses {
+		assert.True(t, validators.FixStatus(MockFieldLevel{Val: s}))
+	}
+	assert.False(t, validators.FixStatus(MockFieldLevel{Val: "invalid"}))
+}
+
+func TestDismissal(t *testing.T) {
+	validDismissals := []db.Dismissal{
+		db.DismissalNotADamage,
+		db.DismissalTenantResponsibility,
+	}
+	for _, d := range validDismissals {
+		assert.True(t, validators.Dismissal(MockFieldLevel{Val: d}))
+	}
+	assert.False(t, validators.Dismissal(MockFieldLevel{Val: "invalid"}))
+}
+
+func TestDepartureReason(t *testing.T) {
+	validReasons := []db.DepartureReason{
+		db.DepartureReasonStandard,
+		db.DepartureReasonTenseZone,
+		db.DepartureReasonJobLoss,
+		db.DepartureReasonNewJob,
+		db.DepartureReasonTransfer,
+		db.DepartureReasonHealth,
+		db.DepartureReasonSocialAid,
+	}
+	for _, reason := range validReasons {
+		assert.True(t, validators.DepartureReason(MockFieldLevel{Val: reason}))
+	}
+	assert.False(t, validators.DepartureReason(MockFieldLevel{Val: "invalid"}))
+}
+
+func TestGuaranteeType(t *testing.T) {
+	validTypes := []db.GuaranteeType{
+		db.GuaranteeTypeJoint,
+		db.GuaranteeTypeSimple,
+		db.GuaranteeTypeVisale,
+		db.GuaranteeTypeOrganization,
+		db.GuaranteeTypeBank,
+	}
+	for _, typ := range validTypes {
+		assert.True(t, validators.GuaranteeType(MockFieldLevel{Val: typ}))
+	}
+	assert.False(t, validators.GuaranteeType(MockFieldLevel{Val: "invalid"}))
+}
+
+func TestTrade(t *testing.T) {
+	validTrades := []db.Trade{
+		db.TradePlumber,
+		db.TradeElectrician,
+		db.TradeLocksmith,
+		db.TradeCarpenter,
+		db.TradePainter,
+		db.TradeHeating,
+		db.TradeAppliance,
+		db.TradeCleaning,
+		db.TradeOther,
+	}
+	for _, trade := range validTrades {
+		assert.True(t, validators.Trade(MockFieldLevel{Val: trade}))
+	}
+	assert.False(t, validators.Trade(MockFieldLevel{Val: "invalid"}))
+}
+
+func TestDocCategory(t *testing.T) {
+	validCategories := []db.DocCategory{
+		db.DocCategoryLease,
+		db.DocCategoryInsurance,
+		db.DocCategoryDiagnostics,
+		db.DocCategoryPayslip,
+		db.DocCategoryReceipt,
+		db.DocCategoryInventoryReport,
+		db.DocCategoryOther,
+	}
+	for _, category := range validCategories {
+		assert.True(t, validators.DocCategory(MockFieldLevel{Val: category}))
+	}
+	assert.False(t, validators.DocCategory(MockFieldLevel{Val: "invalid"}))
+}
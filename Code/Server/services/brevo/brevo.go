@@ -2,13 +2,18 @@ package brevo
 
 import (
 	"bytes"
+	"encoding/base64"
 	"encoding/json"
 	"io"
 	"net/http"
 	"os"
+	"strconv"
+	"time"
 
 	brevo "github.com/getbrevo/brevo-go/lib"
 	"keyz/backend/prisma/db"
+	"keyz/backend/services/storage"
+	"keyz/backend/utils"
 )
 
 type emailBody struct {
@@ -58,10 +63,13 @@ func buildBody(fromName string, toEmail string, cc []string, replyTo string, tem
 }
 
 func callBrevo(fromName string, toEmail string, cc []string, replyTo string, templateId int64, subject string, params map[string]any) (string, error) {
+	return sendEmail(buildBody(fromName, toEmail, cc, replyTo, templateId, subject, params))
+}
+
+func sendEmail(body emailBody) (string, error) {
 	apiURL := "https://api.brevo.com/v3/smtp/email"
 	apiKey := os.Getenv("BREVO_API_KEY")
 
-	body := buildBody(fromName, toEmail, cc, replyTo, templateId, subject, params)
 	bodyBytes, err := json.Marshal(body)
 	if err != nil {
 		panic(err)
@@ -90,24 +98,55 @@ func callBrevo(fromName string, toEmail string, cc []string, replyTo string, tem
 	return string(respBody), nil
 }
 
-func SendEmailInvite(invite db.LeaseInviteModel, userExists bool) (string, error) {
-	ownerName := invite.Property().Owner().Name()
-	ownerEmail := invite.Property().Owner().Email
-	var inviteLink string
+func getInviteLink(token string, userExists bool) string {
 	if userExists {
-		inviteLink = os.Getenv("WEB_PUBLIC_URL") + "/login/invite/" + invite.ID
-	} else {
-		inviteLink = os.Getenv("WEB_PUBLIC_URL") + "/register/invite/" + invite.ID
+		return os.Getenv("WEB_PUBLIC_URL") + "/login/invite/" + token
 	}
+	return os.Getenv("WEB_PUBLIC_URL") + "/register/invite/" + token
+}
+
+func SendEmailInvite(invite db.LeaseInviteModel, token string, userExists bool) (string, error) {
+	ownerName := invite.Property().Owner().Name()
+	ownerEmail := invite.Property().Owner().Email
 	params := map[string]any{
 		"ownerName":  ownerName,
-		"inviteLink": inviteLink,
+		"inviteLink": getInviteLink(token, userExists),
+		"expiresAt":  invite.ExpiresAt.Format("2006-01-02"),
 	}
 	subject := "You've been invited to join a property on Keyz"
 
 	return callBrevo(ownerName+" via Keyz", invite.TenantEmail, []string{}, ownerEmail, 1, subject, params)
 }
 
+// SendEmailInviteReminder reminds a tenant of a pending invite. Only the hash of the token is stored, so the reminder
+// comes with a new link.
+func SendEmailInviteReminder(invite db.LeaseInviteModel, token string, userExists bool) (string, error) {
+	ownerName := invite.Property().Owner().Name()
+	ownerEmail := invite.Property().Owner().Email
+	params := map[string]any{
+		"ownerName":  ownerName,
+		"inviteLink": getInviteLink(token, userExists),
+		"expiresAt":  invite.ExpiresAt.Format("2006-01-02"),
+	}
+	subject := "Reminder: your invite to join a property on Keyz expires soon"
+
+	return callBrevo(ownerName+" via Keyz", invite.TenantEmail, []string{}, ownerEmail, 10, subject, params)
+}
+
+func SendInviteDeclined(invite db.LeaseInviteModel, tenant db.UserModel) (string, error) {
+	tenantName := tenant.Name()
+	propertyName := invite.Property().Name
+	params := map[string]any{
+		"ownerName":    invite.Property().Owner().Name(),
+		"tenantName":   tenantName,
+		"propertyName": propertyName,
+		"propertyLink": os.Getenv("WEB_PUBLIC_URL") + "/real-property/details/" + invite.PropertyID,
+	}
+	subject := tenantName + " declined your invite for " + propertyName
+
+	return callBrevo(tenantName+" via Keyz", invite.Property().Owner().Email, []string{}, tenant.Email, 11, subject, params)
+}
+
 func SendNewDamage(lease db.LeaseModel) (string, error) {
 	tenantName := lease.Tenant().Name()
 	tenantEmail := lease.Tenant().Email
@@ -122,6 +161,21 @@ func SendNewDamage(lease db.LeaseModel) (string, error) {
 	return callBrevo(tenantName+" via Keyz", lease.Property().Owner().Email, []string{}, tenantEmail, 5, subject, params)
 }
 
+func SendNewDamageMessage(lease db.LeaseModel, damage db.DamageModel, message db.DamageMessageModel, author db.UserModel, recipient db.UserModel) (string, error) {
+	authorName := author.Name()
+	propertyName := lease.Property().Name
+	params := map[string]any{
+		"authorName":   authorName,
+		"propertyName": propertyName,
+		"roomName":     damage.Room().Name,
+		"message":      message.Content,
+		"damageLink":   os.Getenv("WEB_PUBLIC_URL") + "/real-property/details/" + lease.PropertyID,
+	}
+	subject := authorName + " replied to a damage in " + propertyName
+
+	return callBrevo(authorName+" via Keyz", recipient.Email, []string{}, author.Email, 12, subject, params)
+}
+
 func SendNewContactMessage(cm db.ContactMessageModel) (string, error) {
 	name := cm.Firstname + " " + cm.Lastname
 	params := map[string]any{
@@ -135,3 +189,199 @@ func SendNewContactMessage(cm db.ContactMessageModel) (string, error) {
 
 	return callBrevo(name+" via keyz-app.fr", "contact@keyz-app.fr", []string{}, cm.Email, 4, subject, params)
 }
+
+func SendLeaseStatusUpdate(lease db.LeaseModel) (string, error) {
+	propertyName := lease.Property().Name
+	endDate := "-"
+	if end, ok := lease.EndDate(); ok {
+		endDate = end.Format("2006-01-02")
+	}
+	params := map[string]any{
+		"tenantName":   lease.Tenant().Name(),
+		"ownerName":    lease.Property().Owner().Name(),
+		"propertyName": propertyName,
+		"status":       string(lease.Status),
+		"startDate":    lease.StartDate.Format("2006-01-02"),
+		"endDate":      endDate,
+		"leaseLink":    os.Getenv("WEB_PUBLIC_URL") + "/real-property/details/" + lease.PropertyID,
+	}
+	subject := "The lease of " + propertyName + " is now " + string(lease.Status)
+
+	return callBrevo("Keyz", lease.Tenant().Email, []string{lease.Property().Owner().Email}, "", 6, subject, params)
+}
+
+func SendDepartureNotice(lease db.LeaseModel, notice db.DepartureNoticeModel) (string, error) {
+	tenantName := lease.Tenant().Name()
+	propertyName := lease.Property().Name
+	params := map[string]any{
+		"tenantName":    tenantName,
+		"propertyName":  propertyName,
+		"requestedDate": notice.RequestedDate.Format("2006-01-02"),
+		"endDate":       notice.EndDate.Format("2006-01-02"),
+		"reason":        string(notice.Reason),
+		"leaseLink":     os.Getenv("WEB_PUBLIC_URL") + "/real-property/details/" + lease.PropertyID,
+	}
+	subject := tenantName + " gave notice of departure from " + propertyName
+
+	return callBrevo(tenantName+" via Keyz", lease.Property().Owner().Email, []string{}, lease.Tenant().Email, 7, subject, params)
+}
+
+func SendDepartureNoticeAnswer(lease db.LeaseModel, notice db.DepartureNoticeModel) (string, error) {
+	ownerName := lease.Property().Owner().Name()
+	propertyName := lease.Property().Name
+	ownerComment, _ := notice.OwnerComment()
+	params := map[string]any{
+		"ownerName":    ownerName,
+		"propertyName": propertyName,
+		"status":       string(notice.Status),
+		"endDate":      notice.EndDate.Format("2006-01-02"),
+		"ownerComment": ownerComment,
+	}
+	subject := "Your notice of departure from " + propertyName + " has been answered"
+
+	return callBrevo(ownerName+" via Keyz", lease.Tenant().Email, []string{}, lease.Property().Owner().Email, 8, subject, params)
+}
+
+func SendGuarantorNotification(lease db.LeaseModel, guarantor db.GuarantorModel) (string, error) {
+	ownerName := lease.Property().Owner().Name()
+	endDate := "-"
+	if end, ok := lease.EndDate(); ok {
+		endDate = end.Format("2006-01-02")
+	}
+	amountCap := "-"
+	if value, ok := guarantor.AmountCap(); ok {
+		amountCap = strconv.FormatFloat(value, 'f', 2, 64) + "€"
+	}
+	params := map[string]any{
+		"guarantorName": guarantor.Name(),
+		"ownerName":     ownerName,
+		"tenantName":    lease.Tenant().Name(),
+		"propertyName":  lease.Property().Name,
+		"address":       lease.Property().Address + ", " + lease.Property().PostalCode + " " + lease.Property().City,
+		"rent":          strconv.FormatFloat(lease.RentalPricePerMonth, 'f', 2, 64) + "€",
+		"startDate":     lease.StartDate.Format("2006-01-02"),
+		"endDate":       endDate,
+		"guaranteeType": string(guarantor.Type),
+		"amountCap":     amountCap,
+	}
+	subject := "You are the guarantor of " + lease.Tenant().Name() + "'s lease"
+
+	return callBrevo(ownerName+" via Keyz", guarantor.Email, []string{}, lease.Property().Owner().Email, 9, subject, params)
+}
+
+func getContractorJobLink(token string) string {
+	return os.Getenv("WEB_PUBLIC_URL") + "/contractor/jobs/" + token
+}
+
+// SendDamageAssignment sends the damage details and its pictures to the contractor in charge of the repair.
+// The job link is only included when a token is given.
+func SendDamageAssignment(lease db.LeaseModel, damage db.DamageModel, contractor db.ContractorModel, token *string) (string, error) {
+	property := lease.Property()
+	ownerName := property.Owner().Name()
+	address := property.Address + ", " + property.PostalCode + " " + property.City
+	if apartment, ok := property.ApartmentNumber(); ok {
+		address = apartment + ", " + address
+	}
+	jobLink := ""
+	if token != nil {
+		jobLink = getContractorJobLink(*token)
+	}
+	interventionDate := "-"
+	if date, ok := damage.InterventionDate(); ok {
+		interventionDate = date.Format("2006-01-02 15:04")
+	}
+	params := map[string]any{
+		"contractorName":   contractor.Name,
+		"ownerName":        ownerName,
+		"ownerEmail":       property.Owner().Email,
+		"address":          address,
+		"roomName":         damage.Room().Name,
+		"comment":          damage.Comment,
+		"priority":         string(damage.Priority),
+		"interventionDate": interventionDate,
+		"jobLink":          jobLink,
+	}
+	subject := "New intervention request from " + ownerName
+
+	body := buildBody(ownerName+" via Keyz", contractor.Email, []string{}, property.Owner().Email, 13, subject, params)
+	for i, picture := range damage.Pictures() {
+		data, err := storage.Load(picture.InnerImage.Data, picture.InnerImage.StorageKey)
+		if err != nil {
+			return "", err
+		}
+		body.Attachment = append(body.Attachment, brevo.SendSmtpEmailAttachment{
+			Content: base64.StdEncoding.EncodeToString(data),
+			Name:    "picture-" + strconv.Itoa(i+1) + "." + string(picture.Type),
+		})
+	}
+	return sendEmail(body)
+}
+
+func SendContractorJobDone(damage db.DamageModel) (string, error) {
+	property := damage.Lease().Property()
+	contractor, _ := damage.Contractor()
+	params := map[string]any{
+		"ownerName":      property.Owner().Name(),
+		"contractorName": contractor.Name,
+		"propertyName":   property.Name,
+		"roomName":       damage.Room().Name,
+		"damageLink":     os.Getenv("WEB_PUBLIC_URL") + "/real-property/details/" + property.ID,
+	}
+	subject := contractor.Name + " marked the intervention at " + property.Name + " as done"
+
+	return callBrevo(contractor.Name+" via Keyz", property.Owner().Email, []string{}, contractor.Email, 14, subject, params)
+}
+
+func buildAppointmentEmail(damage db.DamageModel, appointment db.DamageAppointmentModel, templateId int64, subject string) emailBody {
+	property := damage.Lease().Property()
+	owner := property.Owner()
+	address := property.Address + ", " + property.PostalCode + " " + property.City
+	if apartment, ok := property.ApartmentNumber(); ok {
+		address = apartment + ", " + address
+	}
+	cc := []string{owner.Email}
+	contractorName := "-"
+	if contractor, ok := damage.Contractor(); ok {
+		cc = append(cc, contractor.Email)
+		contractorName = contractor.Name
+	}
+	params := map[string]any{
+		"tenantName":     damage.Lease().Tenant().Name(),
+		"ownerName":      owner.Name(),
+		"contractorName": contractorName,
+		"propertyName":   property.Name,
+		"address":        address,
+		"roomName":       damage.Room().Name,
+		"comment":        damage.Comment,
+		"startAt":        appointment.StartAt.Format("2006-01-02 15:04"),
+		"endAt":          appointment.EndAt().Format("2006-01-02 15:04"),
+	}
+
+	event := utils.CalendarEvent{
+		UID:         appointment.ID + "@keyz-app.fr",
+		Summary:     "Repair at " + property.Name + " (" + damage.Room().Name + ")",
+		Description: damage.Comment,
+		Location:    address,
+		Start:       appointment.StartAt,
+		Duration:    time.Duration(appointment.DurationMinutes) * time.Minute,
+		CreatedAt:   appointment.CreatedAt,
+	}
+	body := buildBody(owner.Name()+" via Keyz", damage.Lease().Tenant().Email, cc, owner.Email, templateId, subject, params)
+	body.Attachment = append(body.Attachment, brevo.SendSmtpEmailAttachment{
+		Content: base64.StdEncoding.EncodeToString([]byte(event.ICS())),
+		Name:    "appointment.ics",
+	})
+	return body
+}
+
+// SendAppointmentConfirmed sends the confirmed repair appointment to the tenant, the owner and the contractor,
+// with a calendar invite attached.
+func SendAppointmentConfirmed(damage db.DamageModel, appointment db.DamageAppointmentModel) (string, error) {
+	subject := "Repair appointment confirmed at " + damage.Lease().Property().Name
+	return sendEmail(buildAppointmentEmail(damage, appointment, 15, subject))
+}
+
+func SendAppointmentReminder(damage db.DamageModel, appointment db.DamageAppointmentModel) (string, error) {
+	subject := "Reminder: upcoming repair appointment at " + damage.Lease().Property().Name
+	return sendEmail(buildAppointmentEmail(damage, appointment, 16, subject))
+}
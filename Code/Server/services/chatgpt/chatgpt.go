@@ -144,6 +144,34 @@ Provide an objective and concise assessment based on the visual differences betw
 	return utils.Ternary(stuffType == "room", promptRoom, promptFurniture)
 }
 
+func buildTriagePromptMessage(roomName string, comment string) string {
+	return `
+This request is part of a damage report for a real estate lease. A tenant reported a damage in the room: ` + roomName + `.
+The tenant's description of the damage is: "` + comment + `".
+The provided images, if any, show the damage.
+
+Your task is to triage this damage for the owner of the property, based on the description and the images.
+
+The response must strictly follow this format:
+<priority>|<category>|<responsible>|<advice>
+Ex: "high|plumbing|owner|Close the water supply valve under the sink and wipe the water to avoid damaging the floor."
+DON'T SEND ANYTHING ELSE IN THE RESPONSE.
+
+In case of error, your response must strictly follow this format:
+error|<error_message>
+Ex: "error|The description and the images do not show any damage."
+DON'T SEND ANYTHING ELSE IN THE ERROR RESPONSE.
+
+Where:
+- **Priority** is how urgently the damage must be fixed and must be one of: low, medium, high, urgent. Water leaks, electrical hazards, no heating in winter or a door that can't be locked are urgent, while cosmetic issues are low.
+- **Category** must be one of: plumbing, electrical, heating, appliance, structural, openings, furniture, pests, cosmetic, other. Openings are doors, windows, shutters and locks.
+- **Responsible** is the party likely responsible for the repair and must be one of: owner, tenant, insurance. Routine maintenance and damages caused by misuse are usually the tenant's responsibility, while wear, obsolescence and structural issues are the owner's. Accidents such as water damage or break-ins are usually covered by the insurance.
+- **Advice** is a short text for the tenant, in the language of the description, explaining what they can safely do while waiting for the repair.
+
+Provide an objective and concise assessment.
+`
+}
+
 func callChatGPT(messages []map[string]any) (string, error) {
 	apiURL := "https://api.openai.com/v1/chat/completions"
 	apiKey := os.Getenv("OPENAI_API_KEY")
@@ -286,3 +314,36 @@ func CompareRoom(roomName string, initialReport db.RoomStateModel, initialPictur
 func CompareFurniture(furnitureName string, initialReport db.FurnitureStateModel, initialPicturesUri []string, currentPicturesUri []string) (string, error) {
 	return compare("furniture", string(initialReport.State), string(initialReport.Cleanliness), initialReport.Note, furnitureName, initialPicturesUri, currentPicturesUri)
 }
+
+func TriageDamage(roomName string, comment string, picturesUri []string) (string, error) {
+	content := []map[string]any{
+		{
+			"type": "text",
+			"text": buildTriagePromptMessage(roomName, comment),
+		},
+	}
+	for _, picture := range picturesUri {
+		content = append(content, buildImageContent(picture))
+	}
+	messages := []map[string]any{
+		{
+			"role":    "user",
+			"content": content,
+		},
+	}
+
+	resp, err := callChatGPT(messages)
+	if err != nil {
+		return "", err
+	}
+
+	var result Response
+	if err := json.Unmarshal([]byte(resp), &result); err != nil {
+		return "", err
+	}
+
+	if len(result.Choices) > 0 {
+		return result.Choices[0].Message.Content, nil
+	}
+	return "", nil
+}
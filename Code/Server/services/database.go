@@ -4,6 +4,8 @@ import (
 	"context"
 	"testing"
 
+	"github.com/steebchen/prisma-client-go/engine/mock"
+	"github.com/steebchen/prisma-client-go/runtime/builder"
 	"keyz/backend/prisma/db"
 )
 
@@ -33,3 +35,12 @@ func ConnectDBTest() (*PrismaDB, *db.Mock, func(t *testing.T)) {
 
 	return DBclient, mock, ensure
 }
+
+// ExpectCount expects a query updating or deleting many rows and returns the number of affected rows, which the
+// generated mocks always report as 0.
+func ExpectCount(m *db.Mock, query interface{ ExtractQuery() builder.Query }, count int) {
+	*m.Expectations = append(*m.Expectations, mock.Expectation{
+		Query: query.ExtractQuery(),
+		Want:  &db.BatchResult{Count: count},
+	})
+}
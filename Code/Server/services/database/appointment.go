@@ -0,0 +1,237 @@
+package database
+
+import (
+	"time"
+
+	"keyz/backend/prisma/db"
+	"keyz/backend/services"
+)
+
+func CreateDamageAppointment(appointment db.DamageAppointmentModel, damageId string) db.DamageAppointmentModel {
+	pdb := services.DBclient
+	newAppointment, err := pdb.Client.DamageAppointment.CreateOne(
+		db.DamageAppointment.StartAt.Set(appointment.StartAt),
+		db.DamageAppointment.DurationMinutes.Set(appointment.DurationMinutes),
+		db.DamageAppointment.ProposedBy.Set(appointment.ProposedBy),
+		db.DamageAppointment.Damage.Link(db.Damage.ID.Equals(damageId)),
+	).Exec(pdb.Context)
+	if err != nil {
+		panic(err)
+	}
+	return *newAppointment
+}
+
+func MockCreateDamageAppointment(c *services.PrismaDB, appointment db.DamageAppointmentModel) db.DamageAppointmentMockExpectParam {
+	return c.Client.DamageAppointment.CreateOne(
+		db.DamageAppointment.StartAt.Set(appointment.StartAt),
+		db.DamageAppointment.DurationMinutes.Set(appointment.DurationMinutes),
+		db.DamageAppointment.ProposedBy.Set(appointment.ProposedBy),
+		db.DamageAppointment.Damage.Link(db.Damage.ID.Equals("1")),
+	)
+}
+
+func GetDamageAppointments(damageId string) []db.DamageAppointmentModel {
+	pdb := services.DBclient
+	appointments, err := pdb.Client.DamageAppointment.FindMany(
+		db.DamageAppointment.DamageID.Equals(damageId),
+	).OrderBy(
+		db.DamageAppointment.StartAt.Order(db.SortOrderAsc),
+	).Exec(pdb.Context)
+	if err != nil {
+		panic(err)
+	}
+	return appointments
+}
+
+func MockGetDamageAppointments(c *services.PrismaDB) db.DamageAppointmentMockExpectParam {
+	return c.Client.DamageAppointment.FindMany(
+		db.DamageAppointment.DamageID.Equals("1"),
+	).OrderBy(
+		db.DamageAppointment.StartAt.Order(db.SortOrderAsc),
+	)
+}
+
+func GetDamageAppointmentByID(id string) *db.DamageAppointmentModel {
+	pdb := services.DBclient
+	appointment, err := pdb.Client.DamageAppointment.FindUnique(
+		db.DamageAppointment.ID.Equals(id),
+	).Exec(pdb.Context)
+	if err != nil {
+		if db.IsErrNotFound(err) {
+			return nil
+		}
+		panic(err)
+	}
+	return appointment
+}
+
+func MockGetDamageAppointmentByID(c *services.PrismaDB) db.DamageAppointmentMockExpectParam {
+	return c.Client.DamageAppointment.FindUnique(
+		db.DamageAppointment.ID.Equals("1"),
+	)
+}
+
+func ConfirmDamageAppointment(appointmentId string) db.DamageAppointmentModel {
+	pdb := services.DBclient
+	appointment, err := pdb.Client.DamageAppointment.FindUnique(
+		db.DamageAppointment.ID.Equals(appointmentId),
+	).Update(
+		db.DamageAppointment.Status.Set(db.AppointmentStatusConfirmed),
+		db.DamageAppointment.ConfirmedAt.Set(time.Now().Truncate(time.Minute)),
+	).Exec(pdb.Context)
+	if err != nil {
+		panic(err)
+	}
+	return *appointment
+}
+
+func MockConfirmDamageAppointment(c *services.PrismaDB) db.DamageAppointmentMockExpectParam {
+	return c.Client.DamageAppointment.FindUnique(
+		db.DamageAppointment.ID.Equals("1"),
+	).Update(
+		db.DamageAppointment.Status.Set(db.AppointmentStatusConfirmed),
+		db.DamageAppointment.ConfirmedAt.Set(time.Now().Truncate(time.Minute)),
+	)
+}
+
+// DeclineProposedAppointments declines the pending proposals of a damage, when new slots are
+// proposed or one of them is confirmed.
+func DeclineProposedAppointments(damageId string) {
+	pdb := services.DBclient
+	_, err := pdb.Client.DamageAppointment.FindMany(
+		db.DamageAppointment.DamageID.Equals(damageId),
+		db.DamageAppointment.Status.Equals(db.AppointmentStatusProposed),
+	).Update(
+		db.DamageAppointment.Status.Set(db.AppointmentStatusDeclined),
+	).Exec(pdb.Context)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func MockDeclineProposedAppointments(c *services.PrismaDB) db.DamageAppointmentMockExpectParam {
+	return c.Client.DamageAppointment.FindMany(
+		db.DamageAppointment.DamageID.Equals("1"),
+		db.DamageAppointment.Status.Equals(db.AppointmentStatusProposed),
+	).Update(
+		db.DamageAppointment.Status.Set(db.AppointmentStatusDeclined),
+	)
+}
+
+// CancelConfirmedAppointments cancels the confirmed appointments of a damage replaced by a new one.
+func CancelConfirmedAppointments(damageId string, exceptId string) {
+	pdb := services.DBclient
+	_, err := pdb.Client.DamageAppointment.FindMany(
+		db.DamageAppointment.DamageID.Equals(damageId),
+		db.DamageAppointment.Status.Equals(db.AppointmentStatusConfirmed),
+		db.DamageAppointment.Not(db.DamageAppointment.ID.Equals(exceptId)),
+	).Update(
+		db.DamageAppointment.Status.Set(db.AppointmentStatusCancelled),
+	).Exec(pdb.Context)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func MockCancelConfirmedAppointments(c *services.PrismaDB) db.DamageAppointmentMockExpectParam {
+	return c.Client.DamageAppointment.FindMany(
+		db.DamageAppointment.DamageID.Equals("1"),
+		db.DamageAppointment.Status.Equals(db.AppointmentStatusConfirmed),
+		db.DamageAppointment.Not(db.DamageAppointment.ID.Equals("1")),
+	).Update(
+		db.DamageAppointment.Status.Set(db.AppointmentStatusCancelled),
+	)
+}
+
+// CancelUpcomingAppointments cancels the confirmed appointments of a damage that have not started yet,
+// when the fix they were planned for is rejected.
+func CancelUpcomingAppointments(damageId string) {
+	pdb := services.DBclient
+	_, err := pdb.Client.DamageAppointment.FindMany(
+		db.DamageAppointment.DamageID.Equals(damageId),
+		db.DamageAppointment.Status.Equals(db.AppointmentStatusConfirmed),
+		db.DamageAppointment.StartAt.Gt(time.Now().Truncate(time.Minute)),
+	).Update(
+		db.DamageAppointment.Status.Set(db.AppointmentStatusCancelled),
+	).Exec(pdb.Context)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func MockCancelUpcomingAppointments(c *services.PrismaDB) db.DamageAppointmentMockExpectParam {
+	return c.Client.DamageAppointment.FindMany(
+		db.DamageAppointment.DamageID.Equals("1"),
+		db.DamageAppointment.Status.Equals(db.AppointmentStatusConfirmed),
+		db.DamageAppointment.StartAt.Gt(time.Now().Truncate(time.Minute)),
+	).Update(
+		db.DamageAppointment.Status.Set(db.AppointmentStatusCancelled),
+	)
+}
+
+// GetAppointmentsToRemind returns the confirmed appointments starting within the reminder delay
+// whose reminder wasn't sent yet, with everything needed to send it.
+func GetAppointmentsToRemind(now time.Time) []db.DamageAppointmentModel {
+	pdb := services.DBclient
+	appointments, err := pdb.Client.DamageAppointment.FindMany(
+		db.DamageAppointment.Status.Equals(db.AppointmentStatusConfirmed),
+		db.DamageAppointment.ReminderSent.Equals(false),
+		db.DamageAppointment.StartAt.Gt(now),
+		db.DamageAppointment.StartAt.Lte(now.Add(db.AppointmentReminderDelay)),
+	).With(
+		db.DamageAppointment.Damage.Fetch().With(
+			db.Damage.Lease.Fetch().With(
+				db.Lease.Tenant.Fetch(),
+				db.Lease.Property.Fetch().With(
+					db.Property.Owner.Fetch(),
+				),
+			),
+			db.Damage.Room.Fetch(),
+			db.Damage.Contractor.Fetch(),
+		),
+	).Exec(pdb.Context)
+	if err != nil {
+		panic(err)
+	}
+	return appointments
+}
+
+func MockGetAppointmentsToRemind(c *services.PrismaDB, now time.Time) db.DamageAppointmentMockExpectParam {
+	return c.Client.DamageAppointment.FindMany(
+		db.DamageAppointment.Status.Equals(db.AppointmentStatusConfirmed),
+		db.DamageAppointment.ReminderSent.Equals(false),
+		db.DamageAppointment.StartAt.Gt(now),
+		db.DamageAppointment.StartAt.Lte(now.Add(db.AppointmentReminderDelay)),
+	).With(
+		db.DamageAppointment.Damage.Fetch().With(
+			db.Damage.Lease.Fetch().With(
+				db.Lease.Tenant.Fetch(),
+				db.Lease.Property.Fetch().With(
+					db.Property.Owner.Fetch(),
+				),
+			),
+			db.Damage.Room.Fetch(),
+			db.Damage.Contractor.Fetch(),
+		),
+	)
+}
+
+func MarkAppointmentReminded(id string) {
+	pdb := services.DBclient
+	_, err := pdb.Client.DamageAppointment.FindUnique(
+		db.DamageAppointment.ID.Equals(id),
+	).Update(
+		db.DamageAppointment.ReminderSent.Set(true),
+	).Exec(pdb.Context)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func MockMarkAppointmentReminded(c *services.PrismaDB) db.DamageAppointmentMockExpectParam {
+	return c.Client.DamageAppointment.FindUnique(
+		db.DamageAppointment.ID.Equals("1"),
+	).Update(
+		db.DamageAppointment.ReminderSent.Set(true),
+	)
+}
@@ -0,0 +1,139 @@
+package database_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"keyz/backend/prisma/db"
+	"keyz/backend/services"
+	"keyz/backend/services/database"
+)
+
+func BuildTestAppointment(id string) db.DamageAppointmentModel {
+	return db.DamageAppointmentModel{
+		InnerDamageAppointment: db.InnerDamageAppointment{
+			ID:              id,
+			DamageID:        "1",
+			StartAt:         time.Now().Add(48 * time.Hour).Truncate(time.Minute),
+			DurationMinutes: 90,
+			Status:          db.AppointmentStatusProposed,
+			ProposedBy:      db.AppointmentPartyOwner,
+			CreatedAt:       time.Now(),
+		},
+	}
+}
+
+func TestCreateDamageAppointment(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	appointment := BuildTestAppointment("1")
+	m.DamageAppointment.Expect(database.MockCreateDamageAppointment(c, appointment)).Returns(appointment)
+
+	res := database.CreateDamageAppointment(appointment, "1")
+	assert.Equal(t, appointment.ID, res.ID)
+	assert.Equal(t, appointment.DurationMinutes, res.DurationMinutes)
+}
+
+func TestCreateDamageAppointment_NoConnection(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	appointment := BuildTestAppointment("1")
+	m.DamageAppointment.Expect(database.MockCreateDamageAppointment(c, appointment)).Errors(errors.New("connection failed"))
+
+	assert.Panics(t, func() {
+		database.CreateDamageAppointment(appointment, "1")
+	})
+}
+
+func TestGetDamageAppointments(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	appointments := []db.DamageAppointmentModel{BuildTestAppointment("1"), BuildTestAppointment("2")}
+	m.DamageAppointment.Expect(database.MockGetDamageAppointments(c)).ReturnsMany(appointments)
+
+	res := database.GetDamageAppointments("1")
+	assert.Len(t, res, 2)
+}
+
+func TestGetDamageAppointmentByID_NotFound(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	m.DamageAppointment.Expect(database.MockGetDamageAppointmentByID(c)).Errors(db.ErrNotFound)
+
+	assert.Nil(t, database.GetDamageAppointmentByID("1"))
+}
+
+func TestConfirmDamageAppointment(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	appointment := BuildTestAppointment("1")
+	appointment.Status = db.AppointmentStatusConfirmed
+	m.DamageAppointment.Expect(database.MockConfirmDamageAppointment(c)).Returns(appointment)
+
+	res := database.ConfirmDamageAppointment("1")
+	assert.Equal(t, db.AppointmentStatusConfirmed, res.Status)
+}
+
+func TestDeclineProposedAppointments(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	m.DamageAppointment.Expect(database.MockDeclineProposedAppointments(c)).Returns(db.DamageAppointmentModel{})
+
+	assert.NotPanics(t, func() {
+		database.DeclineProposedAppointments("1")
+	})
+}
+
+func TestCancelUpcomingAppointments(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	m.DamageAppointment.Expect(database.MockCancelUpcomingAppointments(c)).Returns(db.DamageAppointmentModel{})
+
+	assert.NotPanics(t, func() {
+		database.CancelUpcomingAppointments("1")
+	})
+}
+
+func TestCancelUpcomingAppointments_NoConnection(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	m.DamageAppointment.Expect(database.MockCancelUpcomingAppointments(c)).Errors(errors.New("connection failed"))
+
+	assert.Panics(t, func() {
+		database.CancelUpcomingAppointments("1")
+	})
+}
+
+func TestGetAppointmentsToRemind(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	now := time.Now()
+	appointment := BuildTestAppointment("1")
+	appointment.Status = db.AppointmentStatusConfirmed
+	m.DamageAppointment.Expect(database.MockGetAppointmentsToRemind(c, now)).ReturnsMany([]db.DamageAppointmentModel{appointment})
+
+	res := database.GetAppointmentsToRemind(now)
+	assert.Len(t, res, 1)
+}
+
+func TestMarkAppointmentReminded_NoConnection(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	m.DamageAppointment.Expect(database.MockMarkAppointmentReminded(c)).Errors(errors.New("connection failed"))
+
+	assert.Panics(t, func() {
+		database.MarkAppointmentReminded("1")
+	})
+}
@@ -0,0 +1,88 @@
+package database
+
+import (
+	"keyz/backend/prisma/db"
+	"keyz/backend/services"
+)
+
+// GetDatabaseImages returns images whose content is still kept in the database.
+func GetDatabaseImages(limit int) []db.ImageModel {
+	pdb := services.DBclient
+	images, err := pdb.Client.Image.FindMany(
+		db.Image.StorageKey.IsNull(),
+	).Take(limit).Exec(pdb.Context)
+	if err != nil {
+		panic(err)
+	}
+	return images
+}
+
+func MockGetDatabaseImages(c *services.PrismaDB) db.ImageMockExpectParam {
+	return c.Client.Image.FindMany(
+		db.Image.StorageKey.IsNull(),
+	).Take(100)
+}
+
+// MoveImageToStorage records the storage key of an image and removes its content from the database.
+func MoveImageToStorage(id string, key string) {
+	pdb := services.DBclient
+	_, err := pdb.Client.Image.FindUnique(
+		db.Image.ID.Equals(id),
+	).Update(
+		db.Image.StorageKey.Set(key),
+		db.Image.Data.SetOptional(nil),
+	).Exec(pdb.Context)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func MockMoveImageToStorage(c *services.PrismaDB, key string) db.ImageMockExpectParam {
+	return c.Client.Image.FindUnique(
+		db.Image.ID.Equals("1"),
+	).Update(
+		db.Image.StorageKey.Set(key),
+		db.Image.Data.SetOptional(nil),
+	)
+}
+
+// GetDatabaseDocuments returns documents whose content is still kept in the database.
+func GetDatabaseDocuments(limit int) []db.DocumentModel {
+	pdb := services.DBclient
+	documents, err := pdb.Client.Document.FindMany(
+		db.Document.StorageKey.IsNull(),
+	).Take(limit).Exec(pdb.Context)
+	if err != nil {
+		panic(err)
+	}
+	return documents
+}
+
+func MockGetDatabaseDocuments(c *services.PrismaDB) db.DocumentMockExpectParam {
+	return c.Client.Document.FindMany(
+		db.Document.StorageKey.IsNull(),
+	).Take(100)
+}
+
+// MoveDocumentToStorage records the storage key of a document and removes its content from the database.
+func MoveDocumentToStorage(id string, key string) {
+	pdb := services.DBclient
+	_, err := pdb.Client.Document.FindUnique(
+		db.Document.ID.Equals(id),
+	).Update(
+		db.Document.StorageKey.Set(key),
+		db.Document.Data.SetOptional(nil),
+	).Exec(pdb.Context)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func MockMoveDocumentToStorage(c *services.PrismaDB, key string) db.DocumentMockExpectParam {
+	return c.Client.Document.FindUnique(
+		db.Document.ID.Equals("1"),
+	).Update(
+		db.Document.StorageKey.Set(key),
+		db.Document.Data.SetOptional(nil),
+	)
+}
@@ -0,0 +1,80 @@
+package database_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"keyz/backend/prisma/db"
+	"keyz/backend/services"
+	"keyz/backend/services/database"
+)
+
+func TestGetDatabaseImages(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	images := []db.ImageModel{BuildTestImage("1", "data:image/jpeg;base64,/9j/4A==")}
+	m.Image.Expect(database.MockGetDatabaseImages(c)).ReturnsMany(images)
+
+	res := database.GetDatabaseImages(100)
+	assert.Len(t, res, 1)
+	assert.Equal(t, images[0].ID, res[0].ID)
+}
+
+func TestGetDatabaseImages_NoConnection(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	m.Image.Expect(database.MockGetDatabaseImages(c)).Errors(errors.New("connection failed"))
+
+	assert.Panics(t, func() {
+		database.GetDatabaseImages(100)
+	})
+}
+
+func TestMoveImageToStorage(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	image := BuildTestImage("1", "data:image/jpeg;base64,/9j/4A==")
+	m.Image.Expect(database.MockMoveImageToStorage(c, "images/1")).Returns(image)
+
+	assert.NotPanics(t, func() {
+		database.MoveImageToStorage("1", "images/1")
+	})
+}
+
+func TestGetDatabaseDocuments(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	documents := []db.DocumentModel{BuildTestDocument("1")}
+	m.Document.Expect(database.MockGetDatabaseDocuments(c)).ReturnsMany(documents)
+
+	res := database.GetDatabaseDocuments(100)
+	assert.Len(t, res, 1)
+	assert.Equal(t, documents[0].ID, res[0].ID)
+}
+
+func TestMoveDocumentToStorage(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	m.Document.Expect(database.MockMoveDocumentToStorage(c, "documents/1")).Returns(BuildTestDocument("1"))
+
+	assert.NotPanics(t, func() {
+		database.MoveDocumentToStorage("1", "documents/1")
+	})
+}
+
+func TestMoveDocumentToStorage_NoConnection(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	m.Document.Expect(database.MockMoveDocumentToStorage(c, "documents/1")).Errors(errors.New("connection failed"))
+
+	assert.Panics(t, func() {
+		database.MoveDocumentToStorage("1", "documents/1")
+	})
+}
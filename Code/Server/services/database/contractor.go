@@ -0,0 +1,133 @@
+package database
+
+import (
+	"keyz/backend/models"
+	"keyz/backend/prisma/db"
+	"keyz/backend/services"
+	"keyz/backend/utils"
+)
+
+func CreateContractor(contractor db.ContractorModel, ownerId string) db.ContractorModel {
+	pdb := services.DBclient
+	newContractor, err := pdb.Client.Contractor.CreateOne(
+		db.Contractor.Name.Set(contractor.Name),
+		db.Contractor.Trade.Set(contractor.Trade),
+		db.Contractor.Email.Set(utils.SanitizeEmail(contractor.Email)),
+		db.Contractor.Owner.Link(db.User.ID.Equals(ownerId)),
+		db.Contractor.Company.SetIfPresent(contractor.InnerContractor.Company),
+		db.Contractor.Phone.SetIfPresent(contractor.InnerContractor.Phone),
+		db.Contractor.Notes.SetIfPresent(contractor.InnerContractor.Notes),
+	).Exec(pdb.Context)
+	if err != nil {
+		panic(err)
+	}
+	return *newContractor
+}
+
+func MockCreateContractor(c *services.PrismaDB, contractor db.ContractorModel) db.ContractorMockExpectParam {
+	return c.Client.Contractor.CreateOne(
+		db.Contractor.Name.Set(contractor.Name),
+		db.Contractor.Trade.Set(contractor.Trade),
+		db.Contractor.Email.Set(utils.SanitizeEmail(contractor.Email)),
+		db.Contractor.Owner.Link(db.User.ID.Equals("1")),
+		db.Contractor.Company.SetIfPresent(contractor.InnerContractor.Company),
+		db.Contractor.Phone.SetIfPresent(contractor.InnerContractor.Phone),
+		db.Contractor.Notes.SetIfPresent(contractor.InnerContractor.Notes),
+	)
+}
+
+func GetContractorsByOwner(ownerId string) []db.ContractorModel {
+	pdb := services.DBclient
+	contractors, err := pdb.Client.Contractor.FindMany(
+		db.Contractor.OwnerID.Equals(ownerId),
+	).OrderBy(
+		db.Contractor.Name.Order(db.SortOrderAsc),
+	).Exec(pdb.Context)
+	if err != nil {
+		panic(err)
+	}
+	return contractors
+}
+
+func MockGetContractorsByOwner(c *services.PrismaDB) db.ContractorMockExpectParam {
+	return c.Client.Contractor.FindMany(
+		db.Contractor.OwnerID.Equals("1"),
+	).OrderBy(
+		db.Contractor.Name.Order(db.SortOrderAsc),
+	)
+}
+
+func GetContractorByID(id string) *db.ContractorModel {
+	pdb := services.DBclient
+	contractor, err := pdb.Client.Contractor.FindUnique(
+		db.Contractor.ID.Equals(id),
+	).Exec(pdb.Context)
+	if err != nil {
+		if db.IsErrNotFound(err) {
+			return nil
+		}
+		panic(err)
+	}
+	return contractor
+}
+
+func MockGetContractorByID(c *services.PrismaDB) db.ContractorMockExpectParam {
+	return c.Client.Contractor.FindUnique(
+		db.Contractor.ID.Equals("1"),
+	)
+}
+
+func UpdateContractor(contractor db.ContractorModel, req models.ContractorUpdateRequest) db.ContractorModel {
+	if req.Email != nil {
+		req.Email = utils.Ptr(utils.SanitizeEmail(*req.Email))
+	}
+
+	pdb := services.DBclient
+	newContractor, err := pdb.Client.Contractor.FindUnique(
+		db.Contractor.ID.Equals(contractor.ID),
+	).Update(
+		db.Contractor.Name.SetIfPresent(req.Name),
+		db.Contractor.Company.SetIfPresent(req.Company),
+		db.Contractor.Trade.SetIfPresent(req.Trade),
+		db.Contractor.Email.SetIfPresent(req.Email),
+		db.Contractor.Phone.SetIfPresent(req.Phone),
+		db.Contractor.Notes.SetIfPresent(req.Notes),
+	).Exec(pdb.Context)
+	if err != nil {
+		panic(err)
+	}
+	return *newContractor
+}
+
+func MockUpdateContractor(c *services.PrismaDB, req models.ContractorUpdateRequest) db.ContractorMockExpectParam {
+	if req.Email != nil {
+		req.Email = utils.Ptr(utils.SanitizeEmail(*req.Email))
+	}
+
+	return c.Client.Contractor.FindUnique(
+		db.Contractor.ID.Equals("1"),
+	).Update(
+		db.Contractor.Name.SetIfPresent(req.Name),
+		db.Contractor.Company.SetIfPresent(req.Company),
+		db.Contractor.Trade.SetIfPresent(req.Trade),
+		db.Contractor.Email.SetIfPresent(req.Email),
+		db.Contractor.Phone.SetIfPresent(req.Phone),
+		db.Contractor.Notes.SetIfPresent(req.Notes),
+	)
+}
+
+func DeleteContractor(id string) {
+	pdb := services.DBclient
+	_, err := pdb.Client.Contractor.FindUnique(
+		db.Contractor.ID.Equals(id),
+	).Delete().Exec(pdb.Context)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func MockDeleteContractor(c *services.PrismaDB) db.ContractorMockExpectParam {
+	return c.Client.Contractor.FindUnique(
+		db.Contractor.ID.Equals("1"),
+	).Delete()
+}
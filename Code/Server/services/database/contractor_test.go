@@ -0,0 +1,115 @@
+package database_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"keyz/backend/models"
+	"keyz/backend/prisma/db"
+	"keyz/backend/services"
+	"keyz/backend/services/database"
+	"keyz/backend/utils"
+)
+
+func BuildTestContractor(id string) db.ContractorModel {
+	return db.ContractorModel{
+		InnerContractor: db.InnerContractor{
+			ID:        id,
+			OwnerID:   "1",
+			Name:      "Mario",
+			Company:   utils.Ptr("Plumbing Bros"),
+			Trade:     db.TradePlumber,
+			Email:     "mario@example.com",
+			CreatedAt: time.Now(),
+		},
+	}
+}
+
+func TestCreateContractor(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	contractor := BuildTestContractor("1")
+	m.Contractor.Expect(database.MockCreateContractor(c, contractor)).Returns(contractor)
+
+	newContractor := database.CreateContractor(contractor, "1")
+	assert.Equal(t, contractor.ID, newContractor.ID)
+	assert.Equal(t, contractor.Trade, newContractor.Trade)
+}
+
+func TestCreateContractor_NoConnection(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	contractor := BuildTestContractor("1")
+	m.Contractor.Expect(database.MockCreateContractor(c, contractor)).Errors(errors.New("connection failed"))
+
+	assert.Panics(t, func() {
+		database.CreateContractor(contractor, "1")
+	})
+}
+
+func TestGetContractorsByOwner(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	contractors := []db.ContractorModel{BuildTestContractor("1"), BuildTestContractor("2")}
+	m.Contractor.Expect(database.MockGetContractorsByOwner(c)).ReturnsMany(contractors)
+
+	res := database.GetContractorsByOwner("1")
+	assert.Len(t, res, 2)
+}
+
+func TestGetContractorByID(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	contractor := BuildTestContractor("1")
+	m.Contractor.Expect(database.MockGetContractorByID(c)).Returns(contractor)
+
+	res := database.GetContractorByID("1")
+	assert.NotNil(t, res)
+	assert.Equal(t, contractor.ID, res.ID)
+}
+
+func TestGetContractorByID_NotFound(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	m.Contractor.Expect(database.MockGetContractorByID(c)).Errors(db.ErrNotFound)
+
+	assert.Nil(t, database.GetContractorByID("1"))
+}
+
+func TestUpdateContractor(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	contractor := BuildTestContractor("1")
+	req := models.ContractorUpdateRequest{
+		Phone: utils.Ptr("0600000000"),
+		Email: utils.Ptr("Mario@Example.com"),
+	}
+	updated := contractor
+	updated.Email = "mario@example.com"
+	updated.Phone = req.Phone
+	m.Contractor.Expect(database.MockUpdateContractor(c, req)).Returns(updated)
+
+	res := database.UpdateContractor(contractor, req)
+	assert.Equal(t, updated.Email, res.Email)
+	assert.Equal(t, req.Phone, res.InnerContractor.Phone)
+}
+
+func TestDeleteContractor(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	contractor := BuildTestContractor("1")
+	m.Contractor.Expect(database.MockDeleteContractor(c)).Returns(contractor)
+
+	assert.NotPanics(t, func() {
+		database.DeleteContractor("1")
+	})
+}
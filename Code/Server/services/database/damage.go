@@ -10,7 +10,10 @@ import (
 )
 
 func CreateDamage(damage db.DamageModel, leaseId string, picturesId []string) db.DamageModel {
-	params := make([]db.DamageSetParam, 0, len(picturesId))
+	params := make([]db.DamageSetParam, 0, len(picturesId)+1)
+	if furnitureId, ok := damage.FurnitureID(); ok {
+		params = append(params, db.Damage.Furniture.Link(db.Furniture.ID.Equals(furnitureId)))
+	}
 	for _, id := range picturesId {
 		params = append(params, db.Damage.Pictures.Link(db.Image.ID.Equals(id)))
 	}
@@ -30,7 +33,10 @@ func CreateDamage(damage db.DamageModel, leaseId string, picturesId []string) db
 }
 
 func MockCreateDamage(c *services.PrismaDB, damage db.DamageModel, leaseId string, picturesId []string) db.DamageMockExpectParam {
-	params := make([]db.DamageSetParam, 0, len(picturesId))
+	params := make([]db.DamageSetParam, 0, len(picturesId)+1)
+	if furnitureId, ok := damage.FurnitureID(); ok {
+		params = append(params, db.Damage.Furniture.Link(db.Furniture.ID.Equals(furnitureId)))
+	}
 	for _, id := range picturesId {
 		params = append(params, db.Damage.Pictures.Link(db.Image.ID.Equals(id)))
 	}
@@ -44,24 +50,134 @@ func MockCreateDamage(c *services.PrismaDB, damage db.DamageModel, leaseId strin
 	)
 }
 
-func GetDamagesByPropertyID(propertyID string, fixed bool) []db.DamageModel {
-	fixedParam := utils.Ternary(fixed, db.Damage.FixedAt.Gt(db.DateTime{}), db.Damage.FixedAt.IsNull())
+func damageFixStatusParam(status db.FixStatus) db.DamageWhereParam {
+	open := db.Damage.And(db.Damage.WithdrawnAt.IsNull(), db.Damage.DismissedAt.IsNull())
+	switch status {
+	case db.FixStatusWithdrawn:
+		return db.Damage.WithdrawnAt.Gt(db.DateTime{})
+	case db.FixStatusDismissed:
+		return db.Damage.And(db.Damage.WithdrawnAt.IsNull(), db.Damage.DismissedAt.Gt(db.DateTime{}))
+	case db.FixStatusFixed:
+		return db.Damage.And(open, db.Damage.FixedOwner.Equals(true), db.Damage.FixedTenant.Equals(true))
+	case db.FixStatusAwaitingOwnerConfirmation:
+		return db.Damage.And(open, db.Damage.FixedOwner.Equals(false), db.Damage.FixedTenant.Equals(true))
+	case db.FixStatusAwaitingTenantConfirmation:
+		return db.Damage.And(open, db.Damage.FixedOwner.Equals(true), db.Damage.FixedTenant.Equals(false))
+	case db.FixStatusPlanned:
+		return db.Damage.And(
+			open,
+			db.Damage.FixedOwner.Equals(false),
+			db.Damage.FixedTenant.Equals(false),
+			db.Damage.FixPlannedAt.Gt(db.DateTime{}),
+		)
+	case db.FixStatusFixRejected:
+		return db.Damage.And(
+			open,
+			db.Damage.FixedOwner.Equals(false),
+			db.Damage.FixedTenant.Equals(false),
+			db.Damage.FixPlannedAt.IsNull(),
+			db.Damage.FixRejectedAt.Gt(db.DateTime{}),
+		)
+	case db.FixStatusReopened:
+		return db.Damage.And(
+			open,
+			db.Damage.FixedOwner.Equals(false),
+			db.Damage.FixedTenant.Equals(false),
+			db.Damage.FixPlannedAt.IsNull(),
+			db.Damage.FixRejectedAt.IsNull(),
+			db.Damage.ReopenedAt.Gt(db.DateTime{}),
+		)
+	default:
+		return db.Damage.And(
+			open,
+			db.Damage.FixedOwner.Equals(false),
+			db.Damage.FixedTenant.Equals(false),
+			db.Damage.FixPlannedAt.IsNull(),
+			db.Damage.FixRejectedAt.IsNull(),
+			db.Damage.ReopenedAt.IsNull(),
+		)
+	}
+}
 
-	pdb := services.DBclient
-	damages, err := pdb.Client.Damage.FindMany(
-		db.Damage.Lease.Where(db.Lease.PropertyID.Equals(propertyID)),
-		fixedParam,
-	).OrderBy(
-		db.Damage.FixedAt.Order(db.SortOrderDesc),
-		db.Damage.CreatedAt.Order(db.SortOrderDesc),
-	).With(
+// damageListParams translates the list filters to where params, appended to the base param
+// selecting the damages of a property or lease.
+func damageListParams(base db.DamageWhereParam, query models.DamageListQuery) []db.DamageWhereParam {
+	params := []db.DamageWhereParam{
+		base,
+		db.Damage.RoomID.EqualsIfPresent(query.RoomID),
+		db.Damage.Read.EqualsIfPresent(query.Read),
+		db.Damage.Priority.InIfPresent(query.Priority),
+		db.Damage.CreatedAt.GteIfPresent(query.CreatedFrom),
+		db.Damage.CreatedAt.LteIfPresent(query.CreatedTo),
+		db.Damage.FixedAt.GteIfPresent(query.FixedFrom),
+		db.Damage.FixedAt.LteIfPresent(query.FixedTo),
+	}
+	if query.Fixed != nil {
+		params = append(params, utils.Ternary(*query.Fixed, db.Damage.FixedAt.Gt(db.DateTime{}), db.Damage.FixedAt.IsNull()))
+	}
+	if query.Closed != nil {
+		params = append(params, utils.Ternary(*query.Closed,
+			db.Damage.Or(db.Damage.WithdrawnAt.Gt(db.DateTime{}), db.Damage.DismissedAt.Gt(db.DateTime{})),
+			db.Damage.And(db.Damage.WithdrawnAt.IsNull(), db.Damage.DismissedAt.IsNull()),
+		))
+	}
+	if len(query.FixStatus) > 0 {
+		params = append(params, db.Damage.Or(utils.Map(query.FixStatus, damageFixStatusParam)...))
+	}
+	return params
+}
+
+// damageListOrder always ends with the damage ID so that cursor pagination is stable.
+func damageListOrder(query models.DamageListQuery) []db.DamageOrderByParam {
+	order := utils.Ternary(query.Order == "", db.SortOrderDesc, query.Order)
+	switch query.Sort {
+	case models.DamageSortCreatedAt:
+		return []db.DamageOrderByParam{db.Damage.CreatedAt.Order(order), db.Damage.ID.Order(order)}
+	case models.DamageSortUpdatedAt:
+		return []db.DamageOrderByParam{db.Damage.UpdatedAt.Order(order), db.Damage.ID.Order(order)}
+	case models.DamageSortPriority:
+		return []db.DamageOrderByParam{db.Damage.Priority.Order(order), db.Damage.CreatedAt.Order(order), db.Damage.ID.Order(order)}
+	case models.DamageSortFixedAt:
+		return []db.DamageOrderByParam{db.Damage.FixedAt.Order(order), db.Damage.ID.Order(order)}
+	default:
+		return []db.DamageOrderByParam{db.Damage.FixedAt.Order(order), db.Damage.CreatedAt.Order(order), db.Damage.ID.Order(order)}
+	}
+}
+
+func damageListWith(query models.DamageListQuery) []db.DamageRelationWith {
+	with := []db.DamageRelationWith{
 		db.Damage.Lease.Fetch().With(
 			db.Lease.Tenant.Fetch(),
 			db.Lease.Property.Fetch(),
 		),
 		db.Damage.Room.Fetch(),
-		db.Damage.Pictures.Fetch(),
-	).Exec(pdb.Context)
+		db.Damage.Furniture.Fetch(),
+	}
+	if !query.OmitPictures {
+		with = append(with, db.Damage.Pictures.Fetch())
+	}
+	return with
+}
+
+// GetDamagesByPropertyID returns the damages of a property matching the query.
+// When a limit is set, one more damage than the limit is returned to tell if there is a next page.
+func GetDamagesByPropertyID(propertyID string, query models.DamageListQuery) []db.DamageModel {
+	pdb := services.DBclient
+	find := pdb.Client.Damage.FindMany(
+		damageListParams(db.Damage.Lease.Where(db.Lease.PropertyID.Equals(propertyID)), query)...,
+	).OrderBy(
+		damageListOrder(query)...,
+	).With(
+		damageListWith(query)...,
+	)
+	if query.Limit > 0 {
+		find = find.Take(query.Limit + 1)
+	}
+	if query.Cursor != nil {
+		find = find.Cursor(db.Damage.ID.Cursor(*query.Cursor)).Skip(1)
+	}
+
+	damages, err := find.Exec(pdb.Context)
 	if err != nil {
 		if db.IsErrNotFound(err) {
 			return nil
@@ -71,43 +187,42 @@ func GetDamagesByPropertyID(propertyID string, fixed bool) []db.DamageModel {
 	return damages
 }
 
-func MockGetDamagesByPropertyID(c *services.PrismaDB, fixed bool) db.DamageMockExpectParam {
-	fixedParam := utils.Ternary(fixed, db.Damage.FixedAt.Gt(db.DateTime{}), db.Damage.FixedAt.IsNull())
-
-	return c.Client.Damage.FindMany(
-		db.Damage.Lease.Where(db.Lease.PropertyID.Equals("1")),
-		fixedParam,
+func MockGetDamagesByPropertyID(c *services.PrismaDB, query models.DamageListQuery) db.DamageMockExpectParam {
+	find := c.Client.Damage.FindMany(
+		damageListParams(db.Damage.Lease.Where(db.Lease.PropertyID.Equals("1")), query)...,
 	).OrderBy(
-		db.Damage.FixedAt.Order(db.SortOrderDesc),
-		db.Damage.CreatedAt.Order(db.SortOrderDesc),
+		damageListOrder(query)...,
 	).With(
-		db.Damage.Lease.Fetch().With(
-			db.Lease.Tenant.Fetch(),
-			db.Lease.Property.Fetch(),
-		),
-		db.Damage.Room.Fetch(),
-		db.Damage.Pictures.Fetch(),
+		damageListWith(query)...,
 	)
+	if query.Limit > 0 {
+		find = find.Take(query.Limit + 1)
+	}
+	if query.Cursor != nil {
+		find = find.Cursor(db.Damage.ID.Cursor(*query.Cursor)).Skip(1)
+	}
+	return find
 }
 
-func GetDamagesByLeaseID(leaseID string, fixed bool) []db.DamageModel {
-	fixedParam := utils.Ternary(fixed, db.Damage.FixedAt.Gt(db.DateTime{}), db.Damage.FixedAt.IsNull())
-
+// GetDamagesByLeaseID returns the damages of a lease matching the query.
+// When a limit is set, one more damage than the limit is returned to tell if there is a next page.
+func GetDamagesByLeaseID(leaseID string, query models.DamageListQuery) []db.DamageModel {
 	pdb := services.DBclient
-	damages, err := pdb.Client.Damage.FindMany(
-		db.Damage.LeaseID.Equals(leaseID),
-		fixedParam,
+	find := pdb.Client.Damage.FindMany(
+		damageListParams(db.Damage.LeaseID.Equals(leaseID), query)...,
 	).OrderBy(
-		db.Damage.FixedAt.Order(db.SortOrderDesc),
-		db.Damage.CreatedAt.Order(db.SortOrderDesc),
+		damageListOrder(query)...,
 	).With(
-		db.Damage.Lease.Fetch().With(
-			db.Lease.Tenant.Fetch(),
-			db.Lease.Property.Fetch(),
-		),
-		db.Damage.Room.Fetch(),
-		db.Damage.Pictures.Fetch(),
-	).Exec(pdb.Context)
+		damageListWith(query)...,
+	)
+	if query.Limit > 0 {
+		find = find.Take(query.Limit + 1)
+	}
+	if query.Cursor != nil {
+		find = find.Cursor(db.Damage.ID.Cursor(*query.Cursor)).Skip(1)
+	}
+
+	damages, err := find.Exec(pdb.Context)
 	if err != nil {
 		if db.IsErrNotFound(err) {
 			return nil
@@ -117,23 +232,21 @@ func GetDamagesByLeaseID(leaseID string, fixed bool) []db.DamageModel {
 	return damages
 }
 
-func MockGetDamagesByLeaseID(c *services.PrismaDB, fixed bool) db.DamageMockExpectParam {
-	fixedParam := utils.Ternary(fixed, db.Damage.FixedAt.Gt(db.DateTime{}), db.Damage.FixedAt.IsNull())
-
-	return c.Client.Damage.FindMany(
-		db.Damage.LeaseID.Equals("1"),
-		fixedParam,
+func MockGetDamagesByLeaseID(c *services.PrismaDB, query models.DamageListQuery) db.DamageMockExpectParam {
+	find := c.Client.Damage.FindMany(
+		damageListParams(db.Damage.LeaseID.Equals("1"), query)...,
 	).OrderBy(
-		db.Damage.FixedAt.Order(db.SortOrderDesc),
-		db.Damage.CreatedAt.Order(db.SortOrderDesc),
+		damageListOrder(query)...,
 	).With(
-		db.Damage.Lease.Fetch().With(
-			db.Lease.Tenant.Fetch(),
-			db.Lease.Property.Fetch(),
-		),
-		db.Damage.Room.Fetch(),
-		db.Damage.Pictures.Fetch(),
+		damageListWith(query)...,
 	)
+	if query.Limit > 0 {
+		find = find.Take(query.Limit + 1)
+	}
+	if query.Cursor != nil {
+		find = find.Cursor(db.Damage.ID.Cursor(*query.Cursor)).Skip(1)
+	}
+	return find
 }
 
 func GetDamageByID(damageID string) *db.DamageModel {
@@ -143,10 +256,25 @@ func GetDamageByID(damageID string) *db.DamageModel {
 	).With(
 		db.Damage.Lease.Fetch().With(
 			db.Lease.Tenant.Fetch(),
-			db.Lease.Property.Fetch(),
+			db.Lease.Property.Fetch().With(
+				db.Property.Owner.Fetch(),
+			),
 		),
 		db.Damage.Room.Fetch(),
+		db.Damage.Furniture.Fetch(),
+		db.Damage.Contractor.Fetch(),
 		db.Damage.Pictures.Fetch(),
+		db.Damage.Messages.Fetch().OrderBy(
+			db.DamageMessage.CreatedAt.Order(db.SortOrderAsc),
+		).With(
+			db.DamageMessage.Author.Fetch(),
+			db.DamageMessage.Pictures.Fetch(),
+		),
+		db.Damage.Events.Fetch().OrderBy(
+			db.DamageEvent.CreatedAt.Order(db.SortOrderAsc),
+		).With(
+			db.DamageEvent.Actor.Fetch(),
+		),
 	).Exec(pdb.Context)
 	if err != nil {
 		if db.IsErrNotFound(err) {
@@ -163,10 +291,25 @@ func MockGetDamageByID(c *services.PrismaDB) db.DamageMockExpectParam {
 	).With(
 		db.Damage.Lease.Fetch().With(
 			db.Lease.Tenant.Fetch(),
-			db.Lease.Property.Fetch(),
+			db.Lease.Property.Fetch().With(
+				db.Property.Owner.Fetch(),
+			),
 		),
 		db.Damage.Room.Fetch(),
+		db.Damage.Furniture.Fetch(),
+		db.Damage.Contractor.Fetch(),
 		db.Damage.Pictures.Fetch(),
+		db.Damage.Messages.Fetch().OrderBy(
+			db.DamageMessage.CreatedAt.Order(db.SortOrderAsc),
+		).With(
+			db.DamageMessage.Author.Fetch(),
+			db.DamageMessage.Pictures.Fetch(),
+		),
+		db.Damage.Events.Fetch().OrderBy(
+			db.DamageEvent.CreatedAt.Order(db.SortOrderAsc),
+		).With(
+			db.DamageEvent.Actor.Fetch(),
+		),
 	)
 }
 
@@ -284,3 +427,331 @@ func MockMarkDamageAsFixed(c *services.PrismaDB, damage db.DamageModel, role db.
 		params...,
 	)
 }
+
+// RejectDamageFix resets the fix confirmation of the other party. The planned fix date is cleared on purpose, as it was
+// for the rejected fix and the damage would otherwise stay planned: its upcoming appointments must be cancelled too.
+func RejectDamageFix(damage db.DamageModel, role db.Role, reason string) db.DamageModel {
+	params := []db.DamageSetParam{
+		db.Damage.FixPlannedAt.SetOptional(nil),
+		db.Damage.FixRejectedAt.Set(time.Now().Truncate(time.Minute)),
+		db.Damage.FixRejectedReason.Set(reason),
+	}
+	if role == db.RoleOwner {
+		params = append(params, db.Damage.FixedTenant.Set(false), db.Damage.ContractorDoneAt.SetOptional(nil))
+	}
+	if role == db.RoleTenant {
+		params = append(params, db.Damage.FixedOwner.Set(false))
+	}
+
+	pdb := services.DBclient
+	newDamage, err := pdb.Client.Damage.FindUnique(
+		db.Damage.ID.Equals(damage.ID),
+	).Update(
+		params...,
+	).Exec(pdb.Context)
+	if err != nil {
+		panic(err)
+	}
+	return *newDamage
+}
+
+func MockRejectDamageFix(c *services.PrismaDB, role db.Role, reason string) db.DamageMockExpectParam {
+	params := []db.DamageSetParam{
+		db.Damage.FixPlannedAt.SetOptional(nil),
+		db.Damage.FixRejectedAt.Set(time.Now().Truncate(time.Minute)),
+		db.Damage.FixRejectedReason.Set(reason),
+	}
+	if role == db.RoleOwner {
+		params = append(params, db.Damage.FixedTenant.Set(false), db.Damage.ContractorDoneAt.SetOptional(nil))
+	}
+	if role == db.RoleTenant {
+		params = append(params, db.Damage.FixedOwner.Set(false))
+	}
+
+	return c.Client.Damage.FindUnique(
+		db.Damage.ID.Equals("1"),
+	).Update(
+		params...,
+	)
+}
+
+func ReopenDamage(damage db.DamageModel, reason string) db.DamageModel {
+	pdb := services.DBclient
+	newDamage, err := pdb.Client.Damage.FindUnique(
+		db.Damage.ID.Equals(damage.ID),
+	).Update(
+		db.Damage.FixedOwner.Set(false),
+		db.Damage.FixedTenant.Set(false),
+		db.Damage.FixedAt.SetOptional(nil),
+		db.Damage.FixPlannedAt.SetOptional(nil),
+		db.Damage.ContractorDoneAt.SetOptional(nil),
+		db.Damage.FixRejectedAt.SetOptional(nil),
+		db.Damage.FixRejectedReason.SetOptional(nil),
+		db.Damage.ReopenedAt.Set(time.Now().Truncate(time.Minute)),
+		db.Damage.ReopenedReason.Set(reason),
+	).Exec(pdb.Context)
+	if err != nil {
+		panic(err)
+	}
+	return *newDamage
+}
+
+func MockReopenDamage(c *services.PrismaDB, reason string) db.DamageMockExpectParam {
+	return c.Client.Damage.FindUnique(
+		db.Damage.ID.Equals("1"),
+	).Update(
+		db.Damage.FixedOwner.Set(false),
+		db.Damage.FixedTenant.Set(false),
+		db.Damage.FixedAt.SetOptional(nil),
+		db.Damage.FixPlannedAt.SetOptional(nil),
+		db.Damage.ContractorDoneAt.SetOptional(nil),
+		db.Damage.FixRejectedAt.SetOptional(nil),
+		db.Damage.FixRejectedReason.SetOptional(nil),
+		db.Damage.ReopenedAt.Set(time.Now().Truncate(time.Minute)),
+		db.Damage.ReopenedReason.Set(reason),
+	)
+}
+
+func WithdrawDamage(damage db.DamageModel) db.DamageModel {
+	pdb := services.DBclient
+	newDamage, err := pdb.Client.Damage.FindUnique(
+		db.Damage.ID.Equals(damage.ID),
+	).Update(
+		db.Damage.WithdrawnAt.Set(time.Now().Truncate(time.Minute)),
+	).Exec(pdb.Context)
+	if err != nil {
+		panic(err)
+	}
+	return *newDamage
+}
+
+func MockWithdrawDamage(c *services.PrismaDB) db.DamageMockExpectParam {
+	return c.Client.Damage.FindUnique(
+		db.Damage.ID.Equals("1"),
+	).Update(
+		db.Damage.WithdrawnAt.Set(time.Now().Truncate(time.Minute)),
+	)
+}
+
+func DismissDamage(damage db.DamageModel, req models.DamageDismissRequest) db.DamageModel {
+	pdb := services.DBclient
+	newDamage, err := pdb.Client.Damage.FindUnique(
+		db.Damage.ID.Equals(damage.ID),
+	).Update(
+		db.Damage.Read.Set(true),
+		db.Damage.DismissedAt.Set(time.Now().Truncate(time.Minute)),
+		db.Damage.Dismissal.Set(req.Dismissal),
+		db.Damage.DismissedReason.Set(req.Reason),
+	).Exec(pdb.Context)
+	if err != nil {
+		panic(err)
+	}
+	return *newDamage
+}
+
+func MockDismissDamage(c *services.PrismaDB, req models.DamageDismissRequest) db.DamageMockExpectParam {
+	return c.Client.Damage.FindUnique(
+		db.Damage.ID.Equals("1"),
+	).Update(
+		db.Damage.Read.Set(true),
+		db.Damage.DismissedAt.Set(time.Now().Truncate(time.Minute)),
+		db.Damage.Dismissal.Set(req.Dismissal),
+		db.Damage.DismissedReason.Set(req.Reason),
+	)
+}
+
+func AssignDamageContractor(damage db.DamageModel, req models.DamageAssignRequest, tokenHash *string) db.DamageModel {
+	pdb := services.DBclient
+	newDamage, err := pdb.Client.Damage.FindUnique(
+		db.Damage.ID.Equals(damage.ID),
+	).Update(
+		db.Damage.Contractor.Link(db.Contractor.ID.Equals(req.ContractorID)),
+		db.Damage.InterventionDate.Set(req.InterventionDate),
+		db.Damage.FixPlannedAt.Set(req.InterventionDate),
+		db.Damage.ContractorTokenHash.SetOptional(tokenHash),
+		db.Damage.ContractorDoneAt.SetOptional(nil),
+	).Exec(pdb.Context)
+	if err != nil {
+		panic(err)
+	}
+	return *newDamage
+}
+
+func MockAssignDamageContractor(c *services.PrismaDB, req models.DamageAssignRequest, tokenHash *string) db.DamageMockExpectParam {
+	return c.Client.Damage.FindUnique(
+		db.Damage.ID.Equals("1"),
+	).Update(
+		db.Damage.Contractor.Link(db.Contractor.ID.Equals(req.ContractorID)),
+		db.Damage.InterventionDate.Set(req.InterventionDate),
+		db.Damage.FixPlannedAt.Set(req.InterventionDate),
+		db.Damage.ContractorTokenHash.SetOptional(tokenHash),
+		db.Damage.ContractorDoneAt.SetOptional(nil),
+	)
+}
+
+func GetDamageByContractorToken(token string) *db.DamageModel {
+	pdb := services.DBclient
+	damage, err := pdb.Client.Damage.FindUnique(
+		db.Damage.ContractorTokenHash.Equals(utils.HashToken(token)),
+	).With(
+		db.Damage.Lease.Fetch().With(
+			db.Lease.Property.Fetch().With(
+				db.Property.Owner.Fetch(),
+			),
+		),
+		db.Damage.Room.Fetch(),
+		db.Damage.Pictures.Fetch(),
+		db.Damage.Contractor.Fetch(),
+		db.Damage.Appointments.Fetch().OrderBy(
+			db.DamageAppointment.StartAt.Order(db.SortOrderAsc),
+		),
+	).Exec(pdb.Context)
+	if err != nil {
+		if db.IsErrNotFound(err) {
+			return nil
+		}
+		panic(err)
+	}
+	return damage
+}
+
+func MockGetDamageByContractorToken(c *services.PrismaDB, token string) db.DamageMockExpectParam {
+	return c.Client.Damage.FindUnique(
+		db.Damage.ContractorTokenHash.Equals(utils.HashToken(token)),
+	).With(
+		db.Damage.Lease.Fetch().With(
+			db.Lease.Property.Fetch().With(
+				db.Property.Owner.Fetch(),
+			),
+		),
+		db.Damage.Room.Fetch(),
+		db.Damage.Pictures.Fetch(),
+		db.Damage.Contractor.Fetch(),
+		db.Damage.Appointments.Fetch().OrderBy(
+			db.DamageAppointment.StartAt.Order(db.SortOrderAsc),
+		),
+	)
+}
+
+func MarkDamageContractorDone(damage db.DamageModel) db.DamageModel {
+	pdb := services.DBclient
+	newDamage, err := pdb.Client.Damage.FindUnique(
+		db.Damage.ID.Equals(damage.ID),
+	).Update(
+		db.Damage.ContractorDoneAt.Set(time.Now().Truncate(time.Minute)),
+	).Exec(pdb.Context)
+	if err != nil {
+		panic(err)
+	}
+	return *newDamage
+}
+
+func MockMarkDamageContractorDone(c *services.PrismaDB) db.DamageMockExpectParam {
+	return c.Client.Damage.FindUnique(
+		db.Damage.ID.Equals("1"),
+	).Update(
+		db.Damage.ContractorDoneAt.Set(time.Now().Truncate(time.Minute)),
+	)
+}
+
+func UpdateDamageCosts(damage db.DamageModel, req models.DamageCostRequest) db.DamageModel {
+	pdb := services.DBclient
+	newDamage, err := pdb.Client.Damage.FindUnique(
+		db.Damage.ID.Equals(damage.ID),
+	).Update(
+		db.Damage.EstimatedCost.SetIfPresent(req.EstimatedCost),
+		db.Damage.ActualCost.SetIfPresent(req.ActualCost),
+		db.Damage.Payer.SetIfPresent(req.Payer),
+	).Exec(pdb.Context)
+	if err != nil {
+		panic(err)
+	}
+	return *newDamage
+}
+
+func MockUpdateDamageCosts(c *services.PrismaDB, req models.DamageCostRequest) db.DamageMockExpectParam {
+	return c.Client.Damage.FindUnique(
+		db.Damage.ID.Equals("1"),
+	).Update(
+		db.Damage.EstimatedCost.SetIfPresent(req.EstimatedCost),
+		db.Damage.ActualCost.SetIfPresent(req.ActualCost),
+		db.Damage.Payer.SetIfPresent(req.Payer),
+	)
+}
+
+func UpdateDamageTriage(damageId string, triage models.DamageTriage) db.DamageModel {
+	pdb := services.DBclient
+	newDamage, err := pdb.Client.Damage.FindUnique(
+		db.Damage.ID.Equals(damageId),
+	).Update(
+		db.Damage.TriagePriority.Set(triage.Priority),
+		db.Damage.TriageCategory.Set(triage.Category),
+		db.Damage.TriageResponsible.Set(triage.Responsible),
+		db.Damage.TriageAdvice.Set(triage.Advice),
+		db.Damage.TriagedAt.Set(time.Now().Truncate(time.Minute)),
+	).Exec(pdb.Context)
+	if err != nil {
+		panic(err)
+	}
+	return *newDamage
+}
+
+func MockUpdateDamageTriage(c *services.PrismaDB, triage models.DamageTriage) db.DamageMockExpectParam {
+	return c.Client.Damage.FindUnique(
+		db.Damage.ID.Equals("1"),
+	).Update(
+		db.Damage.TriagePriority.Set(triage.Priority),
+		db.Damage.TriageCategory.Set(triage.Category),
+		db.Damage.TriageResponsible.Set(triage.Responsible),
+		db.Damage.TriageAdvice.Set(triage.Advice),
+		db.Damage.TriagedAt.Set(time.Now().Truncate(time.Minute)),
+	)
+}
+
+// GetDamageExpensesByProperty returns the damages of a property created during the given year that have a cost.
+func GetDamageExpensesByProperty(propertyId string, year int) []db.DamageModel {
+	start := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	pdb := services.DBclient
+	damages, err := pdb.Client.Damage.FindMany(
+		db.Damage.Lease.Where(db.Lease.PropertyID.Equals(propertyId)),
+		db.Damage.CreatedAt.Gte(start),
+		db.Damage.CreatedAt.Lt(start.AddDate(1, 0, 0)),
+		db.Damage.Or(
+			db.Damage.EstimatedCost.Gte(0),
+			db.Damage.ActualCost.Gte(0),
+		),
+	).OrderBy(
+		db.Damage.CreatedAt.Order(db.SortOrderAsc),
+	).With(
+		db.Damage.Lease.Fetch().With(
+			db.Lease.Tenant.Fetch(),
+		),
+		db.Damage.Room.Fetch(),
+	).Exec(pdb.Context)
+	if err != nil {
+		panic(err)
+	}
+	return damages
+}
+
+func MockGetDamageExpensesByProperty(c *services.PrismaDB, year int) db.DamageMockExpectParam {
+	start := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	return c.Client.Damage.FindMany(
+		db.Damage.Lease.Where(db.Lease.PropertyID.Equals("1")),
+		db.Damage.CreatedAt.Gte(start),
+		db.Damage.CreatedAt.Lt(start.AddDate(1, 0, 0)),
+		db.Damage.Or(
+			db.Damage.EstimatedCost.Gte(0),
+			db.Damage.ActualCost.Gte(0),
+		),
+	).OrderBy(
+		db.Damage.CreatedAt.Order(db.SortOrderAsc),
+	).With(
+		db.Damage.Lease.Fetch().With(
+			db.Lease.Tenant.Fetch(),
+		),
+		db.Damage.Room.Fetch(),
+	)
+}
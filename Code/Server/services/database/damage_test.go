@@ -43,6 +43,21 @@ func TestCreateDamage(t *testing.T) {
 	assert.Equal(t, damage.ID, newDamage.ID)
 }
 
+func TestCreateDamage_WithFurniture(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	damage := BuildTestDamage("1")
+	furnitureId := "1"
+	damage.InnerDamage.FurnitureID = &furnitureId
+	pictures := []string{"1"}
+	m.Damage.Expect(database.MockCreateDamage(c, damage, "1", pictures)).Returns(damage)
+
+	newDamage := database.CreateDamage(damage, "1", pictures)
+	assert.Equal(t, damage.ID, newDamage.ID)
+	assert.Equal(t, &furnitureId, newDamage.InnerDamage.FurnitureID)
+}
+
 func TestCreateDamage_NoConnection(t *testing.T) {
 	c, m, ensure := services.ConnectDBTest()
 	defer ensure(t)
@@ -62,21 +77,43 @@ func TestGetDamagesByPropertyID(t *testing.T) {
 
 	damage1 := BuildTestDamage("1")
 	damage2 := BuildTestDamage("2")
-	m.Damage.Expect(database.MockGetDamagesByPropertyID(c, false)).ReturnsMany([]db.DamageModel{damage1, damage2})
+	m.Damage.Expect(database.MockGetDamagesByPropertyID(c, models.DamageListQuery{})).ReturnsMany([]db.DamageModel{damage1, damage2})
 
-	damages := database.GetDamagesByPropertyID("1", false)
+	damages := database.GetDamagesByPropertyID("1", models.DamageListQuery{})
 	assert.Len(t, damages, 2)
 	assert.Equal(t, damage1.ID, damages[0].ID)
 	assert.Equal(t, damage2.ID, damages[1].ID)
 }
 
+func TestGetDamagesByPropertyID_FilteredPage(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	damage := BuildTestDamage("2")
+	query := models.DamageListQuery{
+		FixStatus:   []db.FixStatus{db.FixStatusPending, db.FixStatusReopened},
+		Priority:    []db.Priority{db.PriorityUrgent},
+		Read:        utils.Ptr(false),
+		CreatedFrom: utils.Ptr(time.Now().Truncate(time.Minute).AddDate(0, -1, 0)),
+		Sort:        models.DamageSortCreatedAt,
+		Order:       db.SortOrderAsc,
+		Cursor:      utils.Ptr("1"),
+		Limit:       10,
+	}
+	m.Damage.Expect(database.MockGetDamagesByPropertyID(c, query)).ReturnsMany([]db.DamageModel{damage})
+
+	damages := database.GetDamagesByPropertyID("1", query)
+	assert.Len(t, damages, 1)
+	assert.Equal(t, damage.ID, damages[0].ID)
+}
+
 func TestGetDamagesByPropertyID_NoDamages(t *testing.T) {
 	c, m, ensure := services.ConnectDBTest()
 	defer ensure(t)
 
-	m.Damage.Expect(database.MockGetDamagesByPropertyID(c, false)).ReturnsMany([]db.DamageModel{})
+	m.Damage.Expect(database.MockGetDamagesByPropertyID(c, models.DamageListQuery{})).ReturnsMany([]db.DamageModel{})
 
-	damages := database.GetDamagesByPropertyID("1", false)
+	damages := database.GetDamagesByPropertyID("1", models.DamageListQuery{})
 	assert.Empty(t, damages)
 }
 
@@ -84,9 +121,9 @@ func TestGetDamagesByPropertyID_NotFound(t *testing.T) {
 	c, m, ensure := services.ConnectDBTest()
 	defer ensure(t)
 
-	m.Damage.Expect(database.MockGetDamagesByPropertyID(c, false)).Errors(db.ErrNotFound)
+	m.Damage.Expect(database.MockGetDamagesByPropertyID(c, models.DamageListQuery{})).Errors(db.ErrNotFound)
 
-	damages := database.GetDamagesByPropertyID("1", false)
+	damages := database.GetDamagesByPropertyID("1", models.DamageListQuery{})
 	assert.Nil(t, damages)
 }
 
@@ -94,10 +131,10 @@ func TestGetDamagesByPropertyID_NoConnection(t *testing.T) {
 	c, m, ensure := services.ConnectDBTest()
 	defer ensure(t)
 
-	m.Damage.Expect(database.MockGetDamagesByPropertyID(c, false)).Errors(errors.New("connection failed"))
+	m.Damage.Expect(database.MockGetDamagesByPropertyID(c, models.DamageListQuery{})).Errors(errors.New("connection failed"))
 
 	assert.Panics(t, func() {
-		database.GetDamagesByPropertyID("1", false)
+		database.GetDamagesByPropertyID("1", models.DamageListQuery{})
 	})
 }
 
@@ -107,9 +144,9 @@ func TestGetDamagesByLeaseID(t *testing.T) {
 
 	damage1 := BuildTestDamage("1")
 	damage2 := BuildTestDamage("2")
-	m.Damage.Expect(database.MockGetDamagesByLeaseID(c, false)).ReturnsMany([]db.DamageModel{damage1, damage2})
+	m.Damage.Expect(database.MockGetDamagesByLeaseID(c, models.DamageListQuery{})).ReturnsMany([]db.DamageModel{damage1, damage2})
 
-	damages := database.GetDamagesByLeaseID("1", false)
+	damages := database.GetDamagesByLeaseID("1", models.DamageListQuery{})
 	assert.Len(t, damages, 2)
 	assert.Equal(t, damage1.ID, damages[0].ID)
 	assert.Equal(t, damage2.ID, damages[1].ID)
@@ -119,9 +156,9 @@ func TestGetDamagesByLeaseID_NoDamages(t *testing.T) {
 	c, m, ensure := services.ConnectDBTest()
 	defer ensure(t)
 
-	m.Damage.Expect(database.MockGetDamagesByLeaseID(c, false)).ReturnsMany([]db.DamageModel{})
+	m.Damage.Expect(database.MockGetDamagesByLeaseID(c, models.DamageListQuery{})).ReturnsMany([]db.DamageModel{})
 
-	damages := database.GetDamagesByLeaseID("1", false)
+	damages := database.GetDamagesByLeaseID("1", models.DamageListQuery{})
 	assert.Empty(t, damages)
 }
 
@@ -129,9 +166,9 @@ func TestGetDamagesByLeaseID_NotFound(t *testing.T) {
 	c, m, ensure := services.ConnectDBTest()
 	defer ensure(t)
 
-	m.Damage.Expect(database.MockGetDamagesByLeaseID(c, false)).Errors(db.ErrNotFound)
+	m.Damage.Expect(database.MockGetDamagesByLeaseID(c, models.DamageListQuery{})).Errors(db.ErrNotFound)
 
-	damages := database.GetDamagesByLeaseID("1", false)
+	damages := database.GetDamagesByLeaseID("1", models.DamageListQuery{})
 	assert.Nil(t, damages)
 }
 
@@ -139,10 +176,10 @@ func TestGetDamagesByLeaseID_NoConnection(t *testing.T) {
 	c, m, ensure := services.ConnectDBTest()
 	defer ensure(t)
 
-	m.Damage.Expect(database.MockGetDamagesByLeaseID(c, false)).Errors(errors.New("connection failed"))
+	m.Damage.Expect(database.MockGetDamagesByLeaseID(c, models.DamageListQuery{})).Errors(errors.New("connection failed"))
 
 	assert.Panics(t, func() {
-		database.GetDamagesByLeaseID("1", false)
+		database.GetDamagesByLeaseID("1", models.DamageListQuery{})
 	})
 }
 
@@ -430,3 +467,231 @@ func TestMarkDamageAsFixed_NoConnection(t *testing.T) {
 		database.MarkDamageAsFixed(damage, db.RoleTenant)
 	})
 }
+
+func TestAssignDamageContractor(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	damage := BuildTestDamage("1")
+	req := models.DamageAssignRequest{
+		ContractorID:     "1",
+		InterventionDate: time.Now().Add(24 * time.Hour),
+		SendLink:         true,
+	}
+	tokenHash := utils.Ptr(utils.HashToken("1"))
+
+	updatedDamage := damage
+	updatedDamage.ContractorID = utils.Ptr("1")
+	updatedDamage.InterventionDate = &req.InterventionDate
+	updatedDamage.ContractorTokenHash = tokenHash
+	m.Damage.Expect(database.MockAssignDamageContractor(c, req, tokenHash)).Returns(updatedDamage)
+
+	result := database.AssignDamageContractor(damage, req, tokenHash)
+	assert.Equal(t, updatedDamage.ContractorID, result.InnerDamage.ContractorID)
+	assert.Equal(t, updatedDamage.InterventionDate, result.InnerDamage.InterventionDate)
+}
+
+func TestAssignDamageContractor_NoConnection(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	damage := BuildTestDamage("1")
+	req := models.DamageAssignRequest{
+		ContractorID:     "1",
+		InterventionDate: time.Now().Add(24 * time.Hour),
+	}
+	m.Damage.Expect(database.MockAssignDamageContractor(c, req, nil)).Errors(errors.New("connection failed"))
+
+	assert.Panics(t, func() {
+		database.AssignDamageContractor(damage, req, nil)
+	})
+}
+
+func TestGetDamageByContractorToken(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	damage := BuildTestDamage("1")
+	m.Damage.Expect(database.MockGetDamageByContractorToken(c, "1")).Returns(damage)
+
+	result := database.GetDamageByContractorToken("1")
+	assert.NotNil(t, result)
+	assert.Equal(t, damage.ID, result.ID)
+}
+
+func TestGetDamageByContractorToken_NotFound(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	m.Damage.Expect(database.MockGetDamageByContractorToken(c, "1")).Errors(db.ErrNotFound)
+
+	assert.Nil(t, database.GetDamageByContractorToken("1"))
+}
+
+func TestMarkDamageContractorDone(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	damage := BuildTestDamage("1")
+	updatedDamage := damage
+	updatedDamage.ContractorDoneAt = utils.Ptr(time.Now().Truncate(time.Minute))
+	m.Damage.Expect(database.MockMarkDamageContractorDone(c)).Returns(updatedDamage)
+
+	result := database.MarkDamageContractorDone(damage)
+	assert.NotNil(t, result.InnerDamage.ContractorDoneAt)
+}
+
+func TestUpdateDamageCosts(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	damage := BuildTestDamage("1")
+	req := models.DamageCostRequest{
+		EstimatedCost: utils.Ptr(200.0),
+		Payer:         utils.Ptr(db.PayerInsurance),
+	}
+	updatedDamage := damage
+	updatedDamage.EstimatedCost = req.EstimatedCost
+	updatedDamage.Payer = req.Payer
+	m.Damage.Expect(database.MockUpdateDamageCosts(c, req)).Returns(updatedDamage)
+
+	result := database.UpdateDamageCosts(damage, req)
+	assert.Equal(t, req.EstimatedCost, result.InnerDamage.EstimatedCost)
+	assert.Equal(t, req.Payer, result.InnerDamage.Payer)
+}
+
+func TestUpdateDamageTriage(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	damage := BuildTestDamage("1")
+	triage := models.DamageTriage{
+		Priority:    db.PriorityUrgent,
+		Category:    db.DamageCategoryPlumbing,
+		Responsible: db.PayerOwner,
+		Advice:      "Close the water supply valve.",
+	}
+	updatedDamage := damage
+	updatedDamage.TriagePriority = &triage.Priority
+	updatedDamage.TriageCategory = &triage.Category
+	updatedDamage.TriageResponsible = &triage.Responsible
+	updatedDamage.TriageAdvice = &triage.Advice
+	updatedDamage.TriagedAt = utils.Ptr(time.Now().Truncate(time.Minute))
+	m.Damage.Expect(database.MockUpdateDamageTriage(c, triage)).Returns(updatedDamage)
+
+	result := database.UpdateDamageTriage(damage.ID, triage)
+	assert.Equal(t, &triage.Priority, result.InnerDamage.TriagePriority)
+	assert.Equal(t, &triage.Category, result.InnerDamage.TriageCategory)
+}
+
+func TestGetDamageExpensesByProperty(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	damage := BuildTestDamage("1")
+	damage.ActualCost = utils.Ptr(90.0)
+	m.Damage.Expect(database.MockGetDamageExpensesByProperty(c, 2025)).ReturnsMany([]db.DamageModel{damage})
+
+	result := database.GetDamageExpensesByProperty("1", 2025)
+	assert.Len(t, result, 1)
+}
+
+func TestGetDamageExpensesByProperty_NoConnection(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	m.Damage.Expect(database.MockGetDamageExpensesByProperty(c, 2025)).Errors(errors.New("connection failed"))
+
+	assert.Panics(t, func() {
+		database.GetDamageExpensesByProperty("1", 2025)
+	})
+}
+
+func TestRejectDamageFix(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	damage := BuildTestDamage("1")
+	damage.FixedTenant = true
+	rejected := damage
+	rejected.FixedTenant = false
+	rejected.FixRejectedAt = utils.Ptr(time.Now().Truncate(time.Minute))
+	rejected.FixRejectedReason = utils.Ptr("Still broken")
+	m.Damage.Expect(database.MockRejectDamageFix(c, db.RoleOwner, "Still broken")).Returns(rejected)
+
+	result := database.RejectDamageFix(damage, db.RoleOwner, "Still broken")
+	assert.False(t, result.FixedTenant)
+	assert.Equal(t, db.FixStatusFixRejected, result.FixStatus())
+}
+
+func TestReopenDamage(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	damage := BuildTestDamage("1")
+	damage.FixedTenant = true
+	damage.FixedOwner = true
+	reopened := BuildTestDamage("1")
+	reopened.ReopenedAt = utils.Ptr(time.Now().Truncate(time.Minute))
+	m.Damage.Expect(database.MockReopenDamage(c, "Leaking again")).Returns(reopened)
+
+	result := database.ReopenDamage(damage, "Leaking again")
+	assert.Equal(t, db.FixStatusReopened, result.FixStatus())
+}
+
+func TestReopenDamage_NoConnection(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	m.Damage.Expect(database.MockReopenDamage(c, "Leaking again")).Errors(errors.New("connection failed"))
+
+	assert.Panics(t, func() {
+		database.ReopenDamage(BuildTestDamage("1"), "Leaking again")
+	})
+}
+
+func TestWithdrawDamage(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	withdrawn := BuildTestDamage("1")
+	withdrawn.WithdrawnAt = utils.Ptr(time.Now().Truncate(time.Minute))
+	m.Damage.Expect(database.MockWithdrawDamage(c)).Returns(withdrawn)
+
+	result := database.WithdrawDamage(BuildTestDamage("1"))
+	assert.Equal(t, db.FixStatusWithdrawn, result.FixStatus())
+}
+
+func TestDismissDamage(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	req := models.DamageDismissRequest{
+		Dismissal: db.DismissalNotADamage,
+		Reason:    "Normal wear",
+	}
+	dismissed := BuildTestDamage("1")
+	dismissed.DismissedAt = utils.Ptr(time.Now().Truncate(time.Minute))
+	dismissed.Dismissal = &req.Dismissal
+	dismissed.DismissedReason = &req.Reason
+	m.Damage.Expect(database.MockDismissDamage(c, req)).Returns(dismissed)
+
+	result := database.DismissDamage(BuildTestDamage("1"), req)
+	assert.Equal(t, db.FixStatusDismissed, result.FixStatus())
+	assert.Equal(t, req.Reason, *result.InnerDamage.DismissedReason)
+}
+
+func TestDismissDamage_NoConnection(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	req := models.DamageDismissRequest{
+		Dismissal: db.DismissalNotADamage,
+		Reason:    "Normal wear",
+	}
+	m.Damage.Expect(database.MockDismissDamage(c, req)).Errors(errors.New("connection failed"))
+
+	assert.Panics(t, func() {
+		database.DismissDamage(BuildTestDamage("1"), req)
+	})
+}
@@ -0,0 +1,33 @@
+package database
+
+import (
+	"keyz/backend/prisma/db"
+	"keyz/backend/services"
+)
+
+func CreateDamageEvent(event db.DamageEventModel) db.DamageEventModel {
+	pdb := services.DBclient
+	newEvent, err := pdb.Client.DamageEvent.CreateOne(
+		db.DamageEvent.Field.Set(event.Field),
+		db.DamageEvent.ActorRole.Set(event.ActorRole),
+		db.DamageEvent.Damage.Link(db.Damage.ID.Equals(event.DamageID)),
+		db.DamageEvent.Actor.Link(db.User.ID.Equals(event.ActorID)),
+		db.DamageEvent.OldValue.SetIfPresent(event.InnerDamageEvent.OldValue),
+		db.DamageEvent.NewValue.SetIfPresent(event.InnerDamageEvent.NewValue),
+	).Exec(pdb.Context)
+	if err != nil {
+		panic(err)
+	}
+	return *newEvent
+}
+
+func MockCreateDamageEvent(c *services.PrismaDB, event db.DamageEventModel) db.DamageEventMockExpectParam {
+	return c.Client.DamageEvent.CreateOne(
+		db.DamageEvent.Field.Set(event.Field),
+		db.DamageEvent.ActorRole.Set(event.ActorRole),
+		db.DamageEvent.Damage.Link(db.Damage.ID.Equals("1")),
+		db.DamageEvent.Actor.Link(db.User.ID.Equals("1")),
+		db.DamageEvent.OldValue.SetIfPresent(event.InnerDamageEvent.OldValue),
+		db.DamageEvent.NewValue.SetIfPresent(event.InnerDamageEvent.NewValue),
+	)
+}
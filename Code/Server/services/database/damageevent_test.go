@@ -0,0 +1,49 @@
+package database_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"keyz/backend/prisma/db"
+	"keyz/backend/services"
+	"keyz/backend/services/database"
+	"keyz/backend/utils"
+)
+
+func BuildTestDamageEvent(id string) db.DamageEventModel {
+	return db.DamageEventModel{
+		InnerDamageEvent: db.InnerDamageEvent{
+			ID:        id,
+			DamageID:  "1",
+			ActorID:   "1",
+			ActorRole: db.RoleOwner,
+			Field:     db.DamageFieldRead,
+			OldValue:  utils.Ptr("false"),
+			NewValue:  utils.Ptr("true"),
+		},
+	}
+}
+
+func TestCreateDamageEvent(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	event := BuildTestDamageEvent("1")
+	m.DamageEvent.Expect(database.MockCreateDamageEvent(c, event)).Returns(event)
+
+	newEvent := database.CreateDamageEvent(event)
+	assert.Equal(t, event.ID, newEvent.ID)
+}
+
+func TestCreateDamageEvent_NoConnection(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	event := BuildTestDamageEvent("1")
+	m.DamageEvent.Expect(database.MockCreateDamageEvent(c, event)).Errors(errors.New("connection failed"))
+
+	assert.Panics(t, func() {
+		database.CreateDamageEvent(event)
+	})
+}
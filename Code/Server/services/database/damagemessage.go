@@ -0,0 +1,39 @@
+package database
+
+import (
+	"keyz/backend/prisma/db"
+	"keyz/backend/services"
+)
+
+func CreateDamageMessage(message db.DamageMessageModel, damageId string, authorId string, picturesId []string) db.DamageMessageModel {
+	params := make([]db.DamageMessageSetParam, 0, len(picturesId))
+	for _, id := range picturesId {
+		params = append(params, db.DamageMessage.Pictures.Link(db.Image.ID.Equals(id)))
+	}
+
+	pdb := services.DBclient
+	newMessage, err := pdb.Client.DamageMessage.CreateOne(
+		db.DamageMessage.Content.Set(message.Content),
+		db.DamageMessage.Damage.Link(db.Damage.ID.Equals(damageId)),
+		db.DamageMessage.Author.Link(db.User.ID.Equals(authorId)),
+		params...,
+	).Exec(pdb.Context)
+	if err != nil {
+		panic(err)
+	}
+	return *newMessage
+}
+
+func MockCreateDamageMessage(c *services.PrismaDB, message db.DamageMessageModel, picturesId []string) db.DamageMessageMockExpectParam {
+	params := make([]db.DamageMessageSetParam, 0, len(picturesId))
+	for _, id := range picturesId {
+		params = append(params, db.DamageMessage.Pictures.Link(db.Image.ID.Equals(id)))
+	}
+
+	return c.Client.DamageMessage.CreateOne(
+		db.DamageMessage.Content.Set(message.Content),
+		db.DamageMessage.Damage.Link(db.Damage.ID.Equals("1")),
+		db.DamageMessage.Author.Link(db.User.ID.Equals("1")),
+		params...,
+	)
+}
@@ -0,0 +1,46 @@
+package database_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"keyz/backend/prisma/db"
+	"keyz/backend/services"
+	"keyz/backend/services/database"
+)
+
+func BuildTestDamageMessage(id string) db.DamageMessageModel {
+	return db.DamageMessageModel{
+		InnerDamageMessage: db.InnerDamageMessage{
+			ID:       id,
+			DamageID: "1",
+			AuthorID: "1",
+			Content:  "Test message",
+		},
+	}
+}
+
+func TestCreateDamageMessage(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	message := BuildTestDamageMessage("1")
+	pictures := []string{"1", "2"}
+	m.DamageMessage.Expect(database.MockCreateDamageMessage(c, message, pictures)).Returns(message)
+
+	newMessage := database.CreateDamageMessage(message, "1", "1", pictures)
+	assert.Equal(t, message.ID, newMessage.ID)
+}
+
+func TestCreateDamageMessage_NoConnection(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	message := BuildTestDamageMessage("1")
+	m.DamageMessage.Expect(database.MockCreateDamageMessage(c, message, []string{})).Errors(errors.New("connection failed"))
+
+	assert.Panics(t, func() {
+		database.CreateDamageMessage(message, "1", "1", []string{})
+	})
+}
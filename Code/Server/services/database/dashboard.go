@@ -15,6 +15,7 @@ func GetAllDatasFromProperties(ownerId string) []db.PropertyModel {
 		db.Property.Leases.Fetch().With(
 			db.Lease.Tenant.Fetch(),
 			db.Lease.Damages.Fetch().With(db.Damage.Room.Fetch()),
+			db.Lease.Documents.Fetch(currentDocumentParams(db.Document.Category.Equals(db.DocCategoryInsurance))...),
 			db.Lease.Reports.Fetch().With(
 				db.InventoryReport.RoomStates.Fetch().With(db.RoomState.Room.Fetch()),
 				db.InventoryReport.FurnitureStates.Fetch().With(db.FurnitureState.Furniture.Fetch()),
@@ -38,6 +39,7 @@ func MockGetAllDatasFromProperties(c *services.PrismaDB) db.PropertyMockExpectPa
 		db.Property.Leases.Fetch().With(
 			db.Lease.Tenant.Fetch(),
 			db.Lease.Damages.Fetch().With(db.Damage.Room.Fetch()),
+			db.Lease.Documents.Fetch(currentDocumentParams(db.Document.Category.Equals(db.DocCategoryInsurance))...),
 			db.Lease.Reports.Fetch().With(
 				db.InventoryReport.RoomStates.Fetch().With(db.RoomState.Room.Fetch()),
 				db.InventoryReport.FurnitureStates.Fetch().With(db.FurnitureState.Furniture.Fetch()),
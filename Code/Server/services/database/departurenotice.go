@@ -0,0 +1,97 @@
+package database
+
+import (
+	"keyz/backend/prisma/db"
+	"keyz/backend/services"
+)
+
+// UpsertDepartureNotice creates the departure notice of a lease, or replaces it with a new one to be reviewed again
+// by the owner.
+func UpsertDepartureNotice(notice db.DepartureNoticeModel, leaseId string) db.DepartureNoticeModel {
+	pdb := services.DBclient
+	newNotice, err := pdb.Client.DepartureNotice.UpsertOne(
+		db.DepartureNotice.LeaseID.Equals(leaseId),
+	).Create(
+		db.DepartureNotice.RequestedDate.Set(notice.RequestedDate),
+		db.DepartureNotice.EndDate.Set(notice.EndDate),
+		db.DepartureNotice.Reason.Set(notice.Reason),
+		db.DepartureNotice.Lease.Link(db.Lease.ID.Equals(leaseId)),
+		db.DepartureNotice.Comment.SetIfPresent(notice.InnerDepartureNotice.Comment),
+	).Update(
+		db.DepartureNotice.RequestedDate.Set(notice.RequestedDate),
+		db.DepartureNotice.EndDate.Set(notice.EndDate),
+		db.DepartureNotice.Reason.Set(notice.Reason),
+		db.DepartureNotice.Status.Set(db.NoticeStatusPending),
+		db.DepartureNotice.Comment.SetOptional(notice.InnerDepartureNotice.Comment),
+		db.DepartureNotice.OwnerComment.SetOptional(nil),
+	).Exec(pdb.Context)
+	if err != nil {
+		panic(err)
+	}
+	return *newNotice
+}
+
+func MockUpsertDepartureNotice(c *services.PrismaDB, notice db.DepartureNoticeModel) db.DepartureNoticeMockExpectParam {
+	return c.Client.DepartureNotice.UpsertOne(
+		db.DepartureNotice.LeaseID.Equals("1"),
+	).Create(
+		db.DepartureNotice.RequestedDate.Set(notice.RequestedDate),
+		db.DepartureNotice.EndDate.Set(notice.EndDate),
+		db.DepartureNotice.Reason.Set(notice.Reason),
+		db.DepartureNotice.Lease.Link(db.Lease.ID.Equals("1")),
+		db.DepartureNotice.Comment.SetIfPresent(notice.InnerDepartureNotice.Comment),
+	).Update(
+		db.DepartureNotice.RequestedDate.Set(notice.RequestedDate),
+		db.DepartureNotice.EndDate.Set(notice.EndDate),
+		db.DepartureNotice.Reason.Set(notice.Reason),
+		db.DepartureNotice.Status.Set(db.NoticeStatusPending),
+		db.DepartureNotice.Comment.SetOptional(notice.InnerDepartureNotice.Comment),
+		db.DepartureNotice.OwnerComment.SetOptional(nil),
+	)
+}
+
+func GetDepartureNoticeByLease(leaseId string) *db.DepartureNoticeModel {
+	pdb := services.DBclient
+	notice, err := pdb.Client.DepartureNotice.FindUnique(
+		db.DepartureNotice.LeaseID.Equals(leaseId),
+	).Exec(pdb.Context)
+	if err != nil {
+		if db.IsErrNotFound(err) {
+			return nil
+		}
+		panic(err)
+	}
+	return notice
+}
+
+func MockGetDepartureNoticeByLease(c *services.PrismaDB) db.DepartureNoticeMockExpectParam {
+	return c.Client.DepartureNotice.FindUnique(
+		db.DepartureNotice.LeaseID.Equals("1"),
+	)
+}
+
+func UpdateDepartureNoticeStatus(id string, status db.NoticeStatus, ownerComment *string) *db.DepartureNoticeModel {
+	pdb := services.DBclient
+	notice, err := pdb.Client.DepartureNotice.FindUnique(
+		db.DepartureNotice.ID.Equals(id),
+	).Update(
+		db.DepartureNotice.Status.Set(status),
+		db.DepartureNotice.OwnerComment.SetIfPresent(ownerComment),
+	).Exec(pdb.Context)
+	if err != nil {
+		if db.IsErrNotFound(err) {
+			return nil
+		}
+		panic(err)
+	}
+	return notice
+}
+
+func MockUpdateDepartureNoticeStatus(c *services.PrismaDB, status db.NoticeStatus, ownerComment *string) db.DepartureNoticeMockExpectParam {
+	return c.Client.DepartureNotice.FindUnique(
+		db.DepartureNotice.ID.Equals("1"),
+	).Update(
+		db.DepartureNotice.Status.Set(status),
+		db.DepartureNotice.OwnerComment.SetIfPresent(ownerComment),
+	)
+}
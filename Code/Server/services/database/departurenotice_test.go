@@ -0,0 +1,107 @@
+package database_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"keyz/backend/prisma/db"
+	"keyz/backend/services"
+	"keyz/backend/services/database"
+	"keyz/backend/utils"
+)
+
+func BuildTestDepartureNotice(id string) db.DepartureNoticeModel {
+	return db.DepartureNoticeModel{
+		InnerDepartureNotice: db.InnerDepartureNotice{
+			ID:            id,
+			LeaseID:       "1",
+			RequestedDate: time.Now(),
+			EndDate:       time.Now().AddDate(0, 3, 0),
+			Reason:        db.DepartureReasonStandard,
+			Status:        db.NoticeStatusPending,
+			CreatedAt:     time.Now(),
+			UpdatedAt:     time.Now(),
+		},
+	}
+}
+
+func TestUpsertDepartureNotice(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	notice := BuildTestDepartureNotice("1")
+	m.DepartureNotice.Expect(database.MockUpsertDepartureNotice(c, notice)).Returns(notice)
+
+	newNotice := database.UpsertDepartureNotice(notice, "1")
+	assert.Equal(t, notice.ID, newNotice.ID)
+	assert.Equal(t, notice.EndDate, newNotice.EndDate)
+}
+
+func TestUpsertDepartureNotice_NoConnection(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	notice := BuildTestDepartureNotice("1")
+	m.DepartureNotice.Expect(database.MockUpsertDepartureNotice(c, notice)).Errors(errors.New("connection failed"))
+
+	assert.Panics(t, func() {
+		database.UpsertDepartureNotice(notice, "1")
+	})
+}
+
+func TestGetDepartureNoticeByLease(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	notice := BuildTestDepartureNotice("1")
+	m.DepartureNotice.Expect(database.MockGetDepartureNoticeByLease(c)).Returns(notice)
+
+	found := database.GetDepartureNoticeByLease("1")
+	assert.NotNil(t, found)
+	assert.Equal(t, notice.ID, found.ID)
+}
+
+func TestGetDepartureNoticeByLease_NotFound(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	m.DepartureNotice.Expect(database.MockGetDepartureNoticeByLease(c)).Errors(db.ErrNotFound)
+
+	assert.Nil(t, database.GetDepartureNoticeByLease("1"))
+}
+
+func TestGetDepartureNoticeByLease_NoConnection(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	m.DepartureNotice.Expect(database.MockGetDepartureNoticeByLease(c)).Errors(errors.New("connection failed"))
+
+	assert.Panics(t, func() {
+		database.GetDepartureNoticeByLease("1")
+	})
+}
+
+func TestUpdateDepartureNoticeStatus(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	notice := BuildTestDepartureNotice("1")
+	notice.Status = db.NoticeStatusDiscussing
+	notice.OwnerComment = utils.Ptr("Let's talk")
+	m.DepartureNotice.Expect(database.MockUpdateDepartureNoticeStatus(c, db.NoticeStatusDiscussing, notice.OwnerComment)).Returns(notice)
+
+	updated := database.UpdateDepartureNoticeStatus("1", db.NoticeStatusDiscussing, notice.OwnerComment)
+	assert.NotNil(t, updated)
+	assert.Equal(t, db.NoticeStatusDiscussing, updated.Status)
+}
+
+func TestUpdateDepartureNoticeStatus_NotFound(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	m.DepartureNotice.Expect(database.MockUpdateDepartureNoticeStatus(c, db.NoticeStatusAccepted, nil)).Errors(db.ErrNotFound)
+
+	assert.Nil(t, database.UpdateDepartureNoticeStatus("1", db.NoticeStatusAccepted, nil))
+}
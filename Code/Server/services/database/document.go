@@ -1,14 +1,43 @@
 package database
 
 import (
+	"time"
+
+	"keyz/backend/models"
 	"keyz/backend/prisma/db"
 	"keyz/backend/services"
+	"keyz/backend/services/storage"
 )
 
-func GetDocumentsByLease(leaseID string) []db.DocumentModel {
+// currentDocumentParams selects the latest version of the documents that are not deleted.
+func currentDocumentParams(params ...db.DocumentWhereParam) []db.DocumentWhereParam {
+	return append([]db.DocumentWhereParam{
+		db.Document.Latest.Equals(true),
+		db.Document.DeletedAt.IsNull(),
+	}, params...)
+}
+
+// documentVersionsParam selects all the versions of a document from the ID of its first version.
+func documentVersionsParam(originalID string) db.DocumentWhereParam {
+	return db.Document.Or(
+		db.Document.ID.Equals(originalID),
+		db.Document.OriginalID.Equals(originalID),
+	)
+}
+
+// documentListParams translates the list filters to where params on the documents of a lease.
+func documentListParams(leaseID string, query models.DocumentListQuery) []db.DocumentWhereParam {
+	return currentDocumentParams(
+		db.Document.LeaseID.Equals(leaseID),
+		db.Document.Category.InIfPresent(query.Category),
+		db.Document.Tags.HasIfPresent(query.Tag),
+	)
+}
+
+func GetDocumentsByLease(leaseID string, query models.DocumentListQuery) []db.DocumentModel {
 	pdb := services.DBclient
 	documents, err := pdb.Client.Document.FindMany(
-		db.Document.LeaseID.Equals(leaseID),
+		documentListParams(leaseID, query)...,
 	).Exec(pdb.Context)
 	if err != nil {
 		panic(err)
@@ -16,9 +45,9 @@ func GetDocumentsByLease(leaseID string) []db.DocumentModel {
 	return documents
 }
 
-func MockGetDocumentsByLease(c *services.PrismaDB) db.DocumentMockExpectParam {
+func MockGetDocumentsByLease(c *services.PrismaDB, query models.DocumentListQuery) db.DocumentMockExpectParam {
 	return c.Client.Document.FindMany(
-		db.Document.LeaseID.Equals("1"),
+		documentListParams("1", query)...,
 	)
 }
 
@@ -40,15 +69,40 @@ func MockGetDocumentByID(c *services.PrismaDB) db.DocumentMockExpectParam {
 	)
 }
 
+// documentParams returns the classification of a document, its size and its content,
+// or the key of its content when it is kept out of the database.
+func documentParams(doc db.DocumentModel, key *string) []db.DocumentSetParam {
+	data, _ := doc.Data()
+	params := []db.DocumentSetParam{
+		db.Document.Size.Set(len(data)),
+		db.Document.ExpiresAt.SetIfPresent(doc.InnerDocument.ExpiresAt),
+	}
+	if doc.Category != "" {
+		params = append(params, db.Document.Category.Set(doc.Category))
+	}
+	if len(doc.Tags) > 0 {
+		params = append(params, db.Document.Tags.Set(doc.Tags))
+	}
+	if key != nil {
+		params = append(params, db.Document.StorageKey.Set(*key))
+	} else {
+		params = append(params, db.Document.Data.Set(data))
+	}
+	return params
+}
+
 func CreateDocument(doc db.DocumentModel, leaseId string) db.DocumentModel {
 	pdb := services.DBclient
+	data, _ := doc.Data()
+	key := storage.Offload("documents", data, models.DocumentMimeTypes[doc.Type])
 	newDocument, err := pdb.Client.Document.CreateOne(
 		db.Document.Name.Set(doc.Name),
-		db.Document.Data.Set(doc.Data),
 		db.Document.Type.Set(doc.Type),
 		db.Document.Lease.Link(db.Lease.ID.Equals(leaseId)),
+		documentParams(doc, key)...,
 	).Exec(pdb.Context)
 	if err != nil || newDocument == nil {
+		storage.Remove(key)
 		panic(err)
 	}
 	return *newDocument
@@ -57,24 +111,307 @@ func CreateDocument(doc db.DocumentModel, leaseId string) db.DocumentModel {
 func MockCreateDocument(c *services.PrismaDB, document db.DocumentModel) db.DocumentMockExpectParam {
 	return c.Client.Document.CreateOne(
 		db.Document.Name.Set(document.Name),
-		db.Document.Data.Set(document.Data),
 		db.Document.Type.Set(document.Type),
 		db.Document.Lease.Link(db.Lease.ID.Equals(document.LeaseID)),
+		documentParams(document, nil)...,
 	)
 }
 
-func DeleteDocument(id string) {
+func UpdateDocumentInfo(id string, info db.DocumentModel) db.DocumentModel {
 	pdb := services.DBclient
-	_, err := pdb.Client.Document.FindUnique(
+	doc, err := pdb.Client.Document.FindUnique(
 		db.Document.ID.Equals(id),
+	).Update(
+		db.Document.Category.Set(info.Category),
+		db.Document.Tags.Set(info.Tags),
+		db.Document.ExpiresAt.SetOptional(info.InnerDocument.ExpiresAt),
+	).Exec(pdb.Context)
+	if err != nil || doc == nil {
+		panic(err)
+	}
+	return *doc
+}
+
+func MockUpdateDocumentInfo(c *services.PrismaDB, info db.DocumentModel) db.DocumentMockExpectParam {
+	return c.Client.Document.FindUnique(
+		db.Document.ID.Equals("1"),
+	).Update(
+		db.Document.Category.Set(info.Category),
+		db.Document.Tags.Set(info.Tags),
+		db.Document.ExpiresAt.SetOptional(info.InnerDocument.ExpiresAt),
+	)
+}
+
+// DeleteDocument moves all the versions of a document to the trash, from where they can be restored
+// until they are purged.
+func DeleteDocument(originalID string, now time.Time) {
+	pdb := services.DBclient
+	_, err := pdb.Client.Document.FindMany(
+		documentVersionsParam(originalID),
+	).Update(
+		db.Document.DeletedAt.Set(now.Truncate(time.Minute)),
+	).Exec(pdb.Context)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func MockDeleteDocument(c *services.PrismaDB, now time.Time) db.DocumentMockExpectParam {
+	return c.Client.Document.FindMany(
+		documentVersionsParam("1"),
+	).Update(
+		db.Document.DeletedAt.Set(now.Truncate(time.Minute)),
+	)
+}
+
+func RestoreDocument(originalID string) {
+	pdb := services.DBclient
+	_, err := pdb.Client.Document.FindMany(
+		documentVersionsParam(originalID),
+	).Update(
+		db.Document.DeletedAt.SetOptional(nil),
+	).Exec(pdb.Context)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func MockRestoreDocument(c *services.PrismaDB) db.DocumentMockExpectParam {
+	return c.Client.Document.FindMany(
+		documentVersionsParam("1"),
+	).Update(
+		db.Document.DeletedAt.SetOptional(nil),
+	)
+}
+
+// GetDeletedDocumentsByLease returns the latest version of the documents of a lease in the trash.
+func GetDeletedDocumentsByLease(leaseID string) []db.DocumentModel {
+	pdb := services.DBclient
+	documents, err := pdb.Client.Document.FindMany(
+		db.Document.LeaseID.Equals(leaseID),
+		db.Document.Latest.Equals(true),
+		db.Document.DeletedAt.Gt(db.DateTime{}),
+	).OrderBy(
+		db.Document.DeletedAt.Order(db.SortOrderDesc),
+	).Exec(pdb.Context)
+	if err != nil {
+		panic(err)
+	}
+	return documents
+}
+
+func MockGetDeletedDocumentsByLease(c *services.PrismaDB) db.DocumentMockExpectParam {
+	return c.Client.Document.FindMany(
+		db.Document.LeaseID.Equals("1"),
+		db.Document.Latest.Equals(true),
+		db.Document.DeletedAt.Gt(db.DateTime{}),
+	).OrderBy(
+		db.Document.DeletedAt.Order(db.SortOrderDesc),
+	)
+}
+
+// GetDocumentsDeletedBefore returns all the versions of the documents in the trash since before the given date.
+func GetDocumentsDeletedBefore(date time.Time) []db.DocumentModel {
+	pdb := services.DBclient
+	documents, err := pdb.Client.Document.FindMany(
+		db.Document.DeletedAt.Lt(date),
+	).Exec(pdb.Context)
+	if err != nil {
+		panic(err)
+	}
+	return documents
+}
+
+func MockGetDocumentsDeletedBefore(c *services.PrismaDB, date time.Time) db.DocumentMockExpectParam {
+	return c.Client.Document.FindMany(
+		db.Document.DeletedAt.Lt(date),
+	)
+}
+
+// PurgeDocuments permanently deletes the documents in the trash since before the given date.
+func PurgeDocuments(date time.Time) {
+	pdb := services.DBclient
+	_, err := pdb.Client.Document.FindMany(
+		db.Document.DeletedAt.Lt(date),
 	).Delete().Exec(pdb.Context)
 	if err != nil {
 		panic(err)
 	}
 }
 
-func MockDeleteDocument(c *services.PrismaDB) db.DocumentMockExpectParam {
+func MockPurgeDocuments(c *services.PrismaDB, date time.Time) db.DocumentMockExpectParam {
+	return c.Client.Document.FindMany(
+		db.Document.DeletedAt.Lt(date),
+	).Delete()
+}
+
+// GetDocumentVersions returns all the versions of a document, the latest first.
+func GetDocumentVersions(originalID string) []db.DocumentModel {
+	pdb := services.DBclient
+	documents, err := pdb.Client.Document.FindMany(
+		documentVersionsParam(originalID),
+	).OrderBy(
+		db.Document.Version.Order(db.SortOrderDesc),
+	).Exec(pdb.Context)
+	if err != nil {
+		panic(err)
+	}
+	return documents
+}
+
+func MockGetDocumentVersions(c *services.PrismaDB) db.DocumentMockExpectParam {
+	return c.Client.Document.FindMany(
+		documentVersionsParam("1"),
+	).OrderBy(
+		db.Document.Version.Order(db.SortOrderDesc),
+	)
+}
+
+// documentVersionParams links a new version to the first version of the document and to the same guarantor or damage.
+func documentVersionParams(doc db.DocumentModel, key *string, previous db.DocumentModel) []db.DocumentSetParam {
+	params := append(documentParams(doc, key),
+		db.Document.Version.Set(previous.Version+1),
+		db.Document.Original.Link(db.Document.ID.Equals(previous.FirstVersionID())),
+	)
+	if guarantorID, ok := previous.GuarantorID(); ok {
+		params = append(params, db.Document.Guarantor.Link(db.Guarantor.ID.Equals(guarantorID)))
+	}
+	if damageID, ok := previous.DamageID(); ok {
+		params = append(params, db.Document.Damage.Link(db.Damage.ID.Equals(damageID)))
+	}
+	return params
+}
+
+// CreateDocumentVersion marks the previous document as outdated, then creates its new version. It returns nil when the
+// previous document is no longer the latest version, another version having been uploaded meanwhile.
+func CreateDocumentVersion(doc db.DocumentModel, previous db.DocumentModel) *db.DocumentModel {
+	pdb := services.DBclient
+	// Only one upload can outdate the previous version, the others find no latest version to update
+	outdated, err := pdb.Client.Document.FindMany(
+		db.Document.ID.Equals(previous.ID),
+		db.Document.Latest.Equals(true),
+	).Update(
+		db.Document.Latest.Set(false),
+	).Exec(pdb.Context)
+	if err != nil {
+		panic(err)
+	}
+	if outdated.Count == 0 {
+		return nil
+	}
+
+	data, _ := doc.Data()
+	key := storage.Offload("documents", data, models.DocumentMimeTypes[doc.Type])
+	newDocument, err := pdb.Client.Document.CreateOne(
+		db.Document.Name.Set(doc.Name),
+		db.Document.Type.Set(doc.Type),
+		db.Document.Lease.Link(db.Lease.ID.Equals(previous.LeaseID)),
+		documentVersionParams(doc, key, previous)...,
+	).Exec(pdb.Context)
+	if err != nil || newDocument == nil {
+		storage.Remove(key)
+		// The previous version is the latest one again, so that the document isn't left without any
+		_, _ = pdb.Client.Document.FindUnique(
+			db.Document.ID.Equals(previous.ID),
+		).Update(
+			db.Document.Latest.Set(true),
+		).Exec(pdb.Context)
+		panic(err)
+	}
+	return newDocument
+}
+
+func MockOutdateDocument(c *services.PrismaDB) db.DocumentMockExpectParam {
+	return c.Client.Document.FindMany(
+		db.Document.ID.Equals("1"),
+		db.Document.Latest.Equals(true),
+	).Update(
+		db.Document.Latest.Set(false),
+	)
+}
+
+func MockCreateDocumentVersion(c *services.PrismaDB, document db.DocumentModel, previous db.DocumentModel) db.DocumentMockExpectParam {
+	return c.Client.Document.CreateOne(
+		db.Document.Name.Set(document.Name),
+		db.Document.Type.Set(document.Type),
+		db.Document.Lease.Link(db.Lease.ID.Equals(previous.LeaseID)),
+		documentVersionParams(document, nil, previous)...,
+	)
+}
+
+func MockRestoreLatestDocument(c *services.PrismaDB) db.DocumentMockExpectParam {
 	return c.Client.Document.FindUnique(
 		db.Document.ID.Equals("1"),
-	).Delete()
+	).Update(
+		db.Document.Latest.Set(true),
+	)
+}
+
+func CreateGuarantorDocument(doc db.DocumentModel, leaseId string, guarantorId string) db.DocumentModel {
+	pdb := services.DBclient
+	data, _ := doc.Data()
+	key := storage.Offload("documents", data, models.DocumentMimeTypes[doc.Type])
+	newDocument, err := pdb.Client.Document.CreateOne(
+		db.Document.Name.Set(doc.Name),
+		db.Document.Type.Set(doc.Type),
+		db.Document.Lease.Link(db.Lease.ID.Equals(leaseId)),
+		append(documentParams(doc, key), db.Document.Guarantor.Link(db.Guarantor.ID.Equals(guarantorId)))...,
+	).Exec(pdb.Context)
+	if err != nil || newDocument == nil {
+		storage.Remove(key)
+		panic(err)
+	}
+	return *newDocument
+}
+
+func MockCreateGuarantorDocument(c *services.PrismaDB, document db.DocumentModel) db.DocumentMockExpectParam {
+	return c.Client.Document.CreateOne(
+		db.Document.Name.Set(document.Name),
+		db.Document.Type.Set(document.Type),
+		db.Document.Lease.Link(db.Lease.ID.Equals(document.LeaseID)),
+		append(documentParams(document, nil), db.Document.Guarantor.Link(db.Guarantor.ID.Equals("1")))...,
+	)
+}
+
+func CreateDamageInvoice(doc db.DocumentModel, leaseId string, damageId string) db.DocumentModel {
+	pdb := services.DBclient
+	data, _ := doc.Data()
+	key := storage.Offload("documents", data, models.DocumentMimeTypes[doc.Type])
+	newDocument, err := pdb.Client.Document.CreateOne(
+		db.Document.Name.Set(doc.Name),
+		db.Document.Type.Set(doc.Type),
+		db.Document.Lease.Link(db.Lease.ID.Equals(leaseId)),
+		append(documentParams(doc, key), db.Document.Damage.Link(db.Damage.ID.Equals(damageId)))...,
+	).Exec(pdb.Context)
+	if err != nil || newDocument == nil {
+		storage.Remove(key)
+		panic(err)
+	}
+	return *newDocument
+}
+
+func MockCreateDamageInvoice(c *services.PrismaDB, document db.DocumentModel) db.DocumentMockExpectParam {
+	return c.Client.Document.CreateOne(
+		db.Document.Name.Set(document.Name),
+		db.Document.Type.Set(document.Type),
+		db.Document.Lease.Link(db.Lease.ID.Equals(document.LeaseID)),
+		append(documentParams(document, nil), db.Document.Damage.Link(db.Damage.ID.Equals("1")))...,
+	)
+}
+
+func GetDocumentsByDamage(damageId string) []db.DocumentModel {
+	pdb := services.DBclient
+	documents, err := pdb.Client.Document.FindMany(
+		currentDocumentParams(db.Document.DamageID.Equals(damageId))...,
+	).Exec(pdb.Context)
+	if err != nil {
+		panic(err)
+	}
+	return documents
+}
+
+func MockGetDocumentsByDamage(c *services.PrismaDB) db.DocumentMockExpectParam {
+	return c.Client.Document.FindMany(
+		currentDocumentParams(db.Document.DamageID.Equals("1"))...,
+	)
 }
@@ -6,9 +6,11 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"keyz/backend/models"
 	"keyz/backend/prisma/db"
 	"keyz/backend/services"
 	"keyz/backend/services/database"
+	"keyz/backend/utils"
 )
 
 func BuildTestDocument(id string) db.DocumentModel {
@@ -16,8 +18,11 @@ func BuildTestDocument(id string) db.DocumentModel {
 		InnerDocument: db.InnerDocument{
 			ID:        id,
 			Name:      "Document",
-			Data:      []byte("data"),
+			Data:      utils.Ptr([]byte("data")),
 			Type:      db.DocTypePdf,
+			Category:  db.DocCategoryOther,
+			Version:   1,
+			Latest:    true,
 			LeaseID:   "1",
 			CreatedAt: time.Now(),
 		},
@@ -34,9 +39,9 @@ func TestGetLeaseDocuments(t *testing.T) {
 		BuildTestDocument("1"),
 		BuildTestDocument("2"),
 	}
-	m.Document.Expect(database.MockGetDocumentsByLease(c)).ReturnsMany(documents)
+	m.Document.Expect(database.MockGetDocumentsByLease(c, models.DocumentListQuery{})).ReturnsMany(documents)
 
-	foundDocuments := database.GetDocumentsByLease("1")
+	foundDocuments := database.GetDocumentsByLease("1", models.DocumentListQuery{})
 	assert.NotNil(t, foundDocuments)
 	assert.Len(t, foundDocuments, len(documents))
 	assert.Equal(t, documents[0].ID, foundDocuments[0].ID)
@@ -47,9 +52,9 @@ func TestGetLeaseDocuments_NoDocuments(t *testing.T) {
 	c, m, ensure := services.ConnectDBTest()
 	defer ensure(t)
 
-	m.Document.Expect(database.MockGetDocumentsByLease(c)).ReturnsMany([]db.DocumentModel{})
+	m.Document.Expect(database.MockGetDocumentsByLease(c, models.DocumentListQuery{})).ReturnsMany([]db.DocumentModel{})
 
-	foundDocuments := database.GetDocumentsByLease("1")
+	foundDocuments := database.GetDocumentsByLease("1", models.DocumentListQuery{})
 	assert.NotNil(t, foundDocuments)
 	assert.Empty(t, foundDocuments)
 }
@@ -58,13 +63,30 @@ func TestGetLeaseDocuments_NoConnection(t *testing.T) {
 	c, m, ensure := services.ConnectDBTest()
 	defer ensure(t)
 
-	m.Document.Expect(database.MockGetDocumentsByLease(c)).Errors(errors.New("connection error"))
+	m.Document.Expect(database.MockGetDocumentsByLease(c, models.DocumentListQuery{})).Errors(errors.New("connection error"))
 
 	assert.Panics(t, func() {
-		database.GetDocumentsByLease("1")
+		database.GetDocumentsByLease("1", models.DocumentListQuery{})
 	})
 }
 
+func TestGetLeaseDocuments_Filtered(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	query := models.DocumentListQuery{
+		Category: []db.DocCategory{db.DocCategoryInsurance},
+		Tag:      utils.Ptr("2025"),
+	}
+	document := BuildTestDocument("1")
+	document.Category = db.DocCategoryInsurance
+	m.Document.Expect(database.MockGetDocumentsByLease(c, query)).ReturnsMany([]db.DocumentModel{document})
+
+	foundDocuments := database.GetDocumentsByLease("1", query)
+	assert.Len(t, foundDocuments, 1)
+	assert.Equal(t, db.DocCategoryInsurance, foundDocuments[0].Category)
+}
+
 // #############################################################################
 
 func TestGetDocumentByID(t *testing.T) {
@@ -129,22 +151,216 @@ func TestCreateDocument_NoConnection(t *testing.T) {
 
 // #############################################################################
 
+func TestUpdateDocumentInfo(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	info := db.DocumentModel{
+		InnerDocument: db.InnerDocument{
+			Category:  db.DocCategoryInsurance,
+			Tags:      []string{"home"},
+			ExpiresAt: utils.Ptr(time.Now().AddDate(1, 0, 0)),
+		},
+	}
+	document := BuildTestDocument("1")
+	document.Category = info.Category
+	document.Tags = info.Tags
+	m.Document.Expect(database.MockUpdateDocumentInfo(c, info)).Returns(document)
+
+	updatedDocument := database.UpdateDocumentInfo("1", info)
+	assert.Equal(t, db.DocCategoryInsurance, updatedDocument.Category)
+	assert.Equal(t, []string{"home"}, updatedDocument.Tags)
+}
+
+func TestUpdateDocumentInfo_NoConnection(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	info := db.DocumentModel{InnerDocument: db.InnerDocument{Category: db.DocCategoryOther, Tags: []string{}}}
+	m.Document.Expect(database.MockUpdateDocumentInfo(c, info)).Errors(errors.New("connection error"))
+
+	assert.Panics(t, func() {
+		database.UpdateDocumentInfo("1", info)
+	})
+}
+
+// #############################################################################
+
 func TestDeleteDocument(t *testing.T) {
 	c, m, ensure := services.ConnectDBTest()
 	defer ensure(t)
 
-	m.Document.Expect(database.MockDeleteDocument(c)).Returns(BuildTestDocument("1"))
+	now := time.Now()
+	m.Document.Expect(database.MockDeleteDocument(c, now)).Returns(BuildTestDocument("1"))
 
-	database.DeleteDocument("1")
+	database.DeleteDocument("1", now)
 }
 
 func TestDeleteDocument_NotFound(t *testing.T) {
 	c, m, ensure := services.ConnectDBTest()
 	defer ensure(t)
 
-	m.Document.Expect(database.MockDeleteDocument(c)).Errors(db.ErrNotFound)
+	now := time.Now()
+	m.Document.Expect(database.MockDeleteDocument(c, now)).Errors(db.ErrNotFound)
+
+	assert.Panics(t, func() {
+		database.DeleteDocument("1", now)
+	})
+}
+
+func TestRestoreDocument(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	m.Document.Expect(database.MockRestoreDocument(c)).Returns(BuildTestDocument("1"))
+
+	database.RestoreDocument("1")
+}
+
+func TestRestoreDocument_NoConnection(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	m.Document.Expect(database.MockRestoreDocument(c)).Errors(errors.New("connection error"))
+
+	assert.Panics(t, func() {
+		database.RestoreDocument("1")
+	})
+}
+
+func TestGetDeletedDocumentsByLease(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	document := BuildTestDocument("1")
+	document.InnerDocument.DeletedAt = utils.Ptr(time.Now())
+	m.Document.Expect(database.MockGetDeletedDocumentsByLease(c)).ReturnsMany([]db.DocumentModel{document})
+
+	foundDocuments := database.GetDeletedDocumentsByLease("1")
+	assert.Len(t, foundDocuments, 1)
+	assert.Equal(t, document.ID, foundDocuments[0].ID)
+}
+
+func TestPurgeDocuments(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	before := time.Now().Add(-db.DocumentRetentionPeriod)
+	document := BuildTestDocument("1")
+	document.InnerDocument.DeletedAt = utils.Ptr(before.Add(-time.Hour))
+	m.Document.Expect(database.MockGetDocumentsDeletedBefore(c, before)).ReturnsMany([]db.DocumentModel{document})
+	m.Document.Expect(database.MockPurgeDocuments(c, before)).Returns(document)
+
+	foundDocuments := database.GetDocumentsDeletedBefore(before)
+	assert.Len(t, foundDocuments, 1)
+	database.PurgeDocuments(before)
+}
+
+func TestPurgeDocuments_NoConnection(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	before := time.Now().Add(-db.DocumentRetentionPeriod)
+	m.Document.Expect(database.MockPurgeDocuments(c, before)).Errors(errors.New("connection error"))
 
 	assert.Panics(t, func() {
-		database.DeleteDocument("1")
+		database.PurgeDocuments(before)
 	})
 }
+
+// #############################################################################
+
+func TestGetDocumentVersions(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	version := BuildTestDocument("2")
+	version.Version = 2
+	version.InnerDocument.OriginalID = utils.Ptr("1")
+	versions := []db.DocumentModel{version, BuildTestDocument("1")}
+	m.Document.Expect(database.MockGetDocumentVersions(c)).ReturnsMany(versions)
+
+	foundVersions := database.GetDocumentVersions("1")
+	assert.Len(t, foundVersions, 2)
+	assert.Equal(t, 2, foundVersions[0].Version)
+}
+
+func TestCreateDocumentVersion(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	previous := BuildTestDocument("1")
+	document := BuildTestDocument("2")
+	document.Version = 2
+	document.InnerDocument.OriginalID = utils.Ptr("1")
+	services.ExpectCount(m, database.MockOutdateDocument(c), 1)
+	m.Document.Expect(database.MockCreateDocumentVersion(c, document, previous)).Returns(document)
+
+	newDocument := database.CreateDocumentVersion(document, previous)
+	assert.NotNil(t, newDocument)
+	assert.Equal(t, document.ID, newDocument.ID)
+	assert.Equal(t, 2, newDocument.Version)
+}
+
+func TestCreateDocumentVersion_NotLatest(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	previous := BuildTestDocument("1")
+	document := BuildTestDocument("2")
+	services.ExpectCount(m, database.MockOutdateDocument(c), 0)
+
+	assert.Nil(t, database.CreateDocumentVersion(document, previous))
+}
+
+func TestCreateDocumentVersion_NoConnection1(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	previous := BuildTestDocument("1")
+	document := BuildTestDocument("2")
+	m.Document.Expect(database.MockOutdateDocument(c)).Errors(errors.New("connection error"))
+
+	assert.Panics(t, func() {
+		database.CreateDocumentVersion(document, previous)
+	})
+}
+
+func TestCreateDocumentVersion_NoConnection2(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	previous := BuildTestDocument("1")
+	document := BuildTestDocument("2")
+	services.ExpectCount(m, database.MockOutdateDocument(c), 1)
+	m.Document.Expect(database.MockCreateDocumentVersion(c, document, previous)).Errors(errors.New("connection error"))
+	m.Document.Expect(database.MockRestoreLatestDocument(c)).Returns(previous)
+
+	assert.Panics(t, func() {
+		database.CreateDocumentVersion(document, previous)
+	})
+}
+
+// #############################################################################
+
+func TestCreateDamageInvoice(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	document := BuildTestDocument("1")
+	m.Document.Expect(database.MockCreateDamageInvoice(c, document)).Returns(document)
+
+	newDocument := database.CreateDamageInvoice(document, document.LeaseID, "1")
+	assert.Equal(t, document.ID, newDocument.ID)
+}
+
+func TestGetDocumentsByDamage(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	documents := []db.DocumentModel{BuildTestDocument("1")}
+	m.Document.Expect(database.MockGetDocumentsByDamage(c)).ReturnsMany(documents)
+
+	foundDocuments := database.GetDocumentsByDamage("1")
+	assert.Len(t, foundDocuments, 1)
+}
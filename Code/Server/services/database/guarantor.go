@@ -0,0 +1,97 @@
+package database
+
+import (
+	"keyz/backend/prisma/db"
+	"keyz/backend/services"
+	"keyz/backend/utils"
+)
+
+func CreateGuarantor(guarantor db.GuarantorModel, leaseId string) db.GuarantorModel {
+	pdb := services.DBclient
+	newGuarantor, err := pdb.Client.Guarantor.CreateOne(
+		db.Guarantor.Firstname.Set(guarantor.Firstname),
+		db.Guarantor.Lastname.Set(guarantor.Lastname),
+		db.Guarantor.Email.Set(utils.SanitizeEmail(guarantor.Email)),
+		db.Guarantor.Type.Set(guarantor.Type),
+		db.Guarantor.Lease.Link(db.Lease.ID.Equals(leaseId)),
+		db.Guarantor.Phone.SetIfPresent(guarantor.InnerGuarantor.Phone),
+		db.Guarantor.Address.SetIfPresent(guarantor.InnerGuarantor.Address),
+		db.Guarantor.AmountCap.SetIfPresent(guarantor.InnerGuarantor.AmountCap),
+		db.Guarantor.DurationMonths.SetIfPresent(guarantor.InnerGuarantor.DurationMonths),
+	).Exec(pdb.Context)
+	if err != nil {
+		panic(err)
+	}
+	return *newGuarantor
+}
+
+func MockCreateGuarantor(c *services.PrismaDB, guarantor db.GuarantorModel) db.GuarantorMockExpectParam {
+	return c.Client.Guarantor.CreateOne(
+		db.Guarantor.Firstname.Set(guarantor.Firstname),
+		db.Guarantor.Lastname.Set(guarantor.Lastname),
+		db.Guarantor.Email.Set(utils.SanitizeEmail(guarantor.Email)),
+		db.Guarantor.Type.Set(guarantor.Type),
+		db.Guarantor.Lease.Link(db.Lease.ID.Equals("1")),
+		db.Guarantor.Phone.SetIfPresent(guarantor.InnerGuarantor.Phone),
+		db.Guarantor.Address.SetIfPresent(guarantor.InnerGuarantor.Address),
+		db.Guarantor.AmountCap.SetIfPresent(guarantor.InnerGuarantor.AmountCap),
+		db.Guarantor.DurationMonths.SetIfPresent(guarantor.InnerGuarantor.DurationMonths),
+	)
+}
+
+func GetGuarantorsByLease(leaseId string) []db.GuarantorModel {
+	pdb := services.DBclient
+	guarantors, err := pdb.Client.Guarantor.FindMany(
+		db.Guarantor.LeaseID.Equals(leaseId),
+	).Exec(pdb.Context)
+	if err != nil {
+		panic(err)
+	}
+	return guarantors
+}
+
+func MockGetGuarantorsByLease(c *services.PrismaDB) db.GuarantorMockExpectParam {
+	return c.Client.Guarantor.FindMany(
+		db.Guarantor.LeaseID.Equals("1"),
+	)
+}
+
+func GetGuarantorByID(id string) *db.GuarantorModel {
+	pdb := services.DBclient
+	guarantor, err := pdb.Client.Guarantor.FindUnique(
+		db.Guarantor.ID.Equals(id),
+	).With(
+		db.Guarantor.Documents.Fetch(currentDocumentParams()...),
+	).Exec(pdb.Context)
+	if err != nil {
+		if db.IsErrNotFound(err) {
+			return nil
+		}
+		panic(err)
+	}
+	return guarantor
+}
+
+func MockGetGuarantorByID(c *services.PrismaDB) db.GuarantorMockExpectParam {
+	return c.Client.Guarantor.FindUnique(
+		db.Guarantor.ID.Equals("1"),
+	).With(
+		db.Guarantor.Documents.Fetch(currentDocumentParams()...),
+	)
+}
+
+func DeleteGuarantor(id string) {
+	pdb := services.DBclient
+	_, err := pdb.Client.Guarantor.FindUnique(
+		db.Guarantor.ID.Equals(id),
+	).Delete().Exec(pdb.Context)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func MockDeleteGuarantor(c *services.PrismaDB) db.GuarantorMockExpectParam {
+	return c.Client.Guarantor.FindUnique(
+		db.Guarantor.ID.Equals("1"),
+	).Delete()
+}
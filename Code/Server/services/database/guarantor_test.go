@@ -0,0 +1,107 @@
+package database_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"keyz/backend/prisma/db"
+	"keyz/backend/services"
+	"keyz/backend/services/database"
+	"keyz/backend/utils"
+)
+
+func BuildTestGuarantor(id string) db.GuarantorModel {
+	return db.GuarantorModel{
+		InnerGuarantor: db.InnerGuarantor{
+			ID:        id,
+			LeaseID:   "1",
+			Firstname: "Jane",
+			Lastname:  "Doe",
+			Email:     "janedoe@example.com",
+			Type:      db.GuaranteeTypeJoint,
+			AmountCap: utils.Ptr(10000.0),
+			CreatedAt: time.Now(),
+		},
+	}
+}
+
+func TestCreateGuarantor(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	guarantor := BuildTestGuarantor("1")
+	m.Guarantor.Expect(database.MockCreateGuarantor(c, guarantor)).Returns(guarantor)
+
+	newGuarantor := database.CreateGuarantor(guarantor, "1")
+	assert.Equal(t, guarantor.ID, newGuarantor.ID)
+	assert.Equal(t, guarantor.Email, newGuarantor.Email)
+}
+
+func TestCreateGuarantor_NoConnection(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	guarantor := BuildTestGuarantor("1")
+	m.Guarantor.Expect(database.MockCreateGuarantor(c, guarantor)).Errors(errors.New("connection failed"))
+
+	assert.Panics(t, func() {
+		database.CreateGuarantor(guarantor, "1")
+	})
+}
+
+func TestGetGuarantorsByLease(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	guarantors := []db.GuarantorModel{BuildTestGuarantor("1"), BuildTestGuarantor("2")}
+	m.Guarantor.Expect(database.MockGetGuarantorsByLease(c)).ReturnsMany(guarantors)
+
+	res := database.GetGuarantorsByLease("1")
+	assert.Len(t, res, 2)
+}
+
+func TestGetGuarantorByID(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	guarantor := BuildTestGuarantor("1")
+	m.Guarantor.Expect(database.MockGetGuarantorByID(c)).Returns(guarantor)
+
+	res := database.GetGuarantorByID("1")
+	assert.NotNil(t, res)
+	assert.Equal(t, guarantor.ID, res.ID)
+}
+
+func TestGetGuarantorByID_NotFound(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	m.Guarantor.Expect(database.MockGetGuarantorByID(c)).Errors(db.ErrNotFound)
+
+	assert.Nil(t, database.GetGuarantorByID("1"))
+}
+
+func TestDeleteGuarantor(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	guarantor := BuildTestGuarantor("1")
+	m.Guarantor.Expect(database.MockDeleteGuarantor(c)).Returns(guarantor)
+
+	assert.NotPanics(t, func() {
+		database.DeleteGuarantor("1")
+	})
+}
+
+func TestDeleteGuarantor_NoConnection(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	m.Guarantor.Expect(database.MockDeleteGuarantor(c)).Errors(errors.New("connection failed"))
+
+	assert.Panics(t, func() {
+		database.DeleteGuarantor("1")
+	})
+}
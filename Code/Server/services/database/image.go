@@ -3,6 +3,7 @@ package database
 import (
 	"keyz/backend/prisma/db"
 	"keyz/backend/services"
+	"keyz/backend/services/storage"
 )
 
 func GetImageByID(id string) *db.ImageModel {
@@ -23,21 +24,36 @@ func MockGetImageByID(c *services.PrismaDB) db.ImageMockExpectParam {
 	)
 }
 
+// imageBlobParams returns the size of an image and its content, or the key of its content when it is kept out of the database.
+func imageBlobParams(data []byte, key *string) []db.ImageSetParam {
+	params := []db.ImageSetParam{db.Image.Size.Set(len(data))}
+	if key != nil {
+		params = append(params, db.Image.StorageKey.Set(*key))
+	} else {
+		params = append(params, db.Image.Data.Set(data))
+	}
+	return params
+}
+
 func CreateImage(image db.ImageModel) db.ImageModel {
 	pdb := services.DBclient
+	data, _ := image.Data()
+	key := storage.Offload("images", data, "image/"+string(image.Type))
 	newImage, err := pdb.Client.Image.CreateOne(
-		db.Image.Data.Set(image.Data),
 		db.Image.Type.Set(image.Type),
+		imageBlobParams(data, key)...,
 	).Exec(pdb.Context)
 	if err != nil || newImage == nil {
+		storage.Remove(key)
 		panic(err)
 	}
 	return *newImage
 }
 
 func MockCreateImage(c *services.PrismaDB, image db.ImageModel) db.ImageMockExpectParam {
+	data, _ := image.Data()
 	return c.Client.Image.CreateOne(
-		db.Image.Data.Set(image.Data),
 		db.Image.Type.Set(image.Type),
+		imageBlobParams(data, nil)...,
 	)
 }
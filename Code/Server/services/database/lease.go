@@ -18,6 +18,7 @@ func GetCurrentActiveLeaseByProperty(propertyId string) *db.LeaseModel {
 	).With(
 		db.Lease.Tenant.Fetch(),
 		db.Lease.Property.Fetch().With(db.Property.Owner.Fetch()),
+		db.Lease.Guarantors.Fetch(),
 	).Exec(pdb.Context)
 	if err != nil {
 		if db.IsErrNotFound(err) {
@@ -42,6 +43,7 @@ func MockGetCurrentActiveLeaseByProperty(c *services.PrismaDB) db.LeaseMockExpec
 	).With(
 		db.Lease.Tenant.Fetch(),
 		db.Lease.Property.Fetch().With(db.Property.Owner.Fetch()),
+		db.Lease.Guarantors.Fetch(),
 	)
 }
 
@@ -53,6 +55,7 @@ func GetCurrentActiveLeaseByTenant(tenantId string) *db.LeaseModel {
 	).With(
 		db.Lease.Tenant.Fetch(),
 		db.Lease.Property.Fetch().With(db.Property.Owner.Fetch()),
+		db.Lease.Guarantors.Fetch(),
 	).Exec(pdb.Context)
 	if err != nil {
 		if db.IsErrNotFound(err) {
@@ -77,6 +80,7 @@ func MockGetCurrentActiveLeaseByTenant(c *services.PrismaDB) db.LeaseMockExpectP
 	).With(
 		db.Lease.Tenant.Fetch(),
 		db.Lease.Property.Fetch().With(db.Property.Owner.Fetch()),
+		db.Lease.Guarantors.Fetch(),
 	)
 }
 
@@ -87,6 +91,7 @@ func GetLeaseByID(id string) *db.LeaseModel {
 	).With(
 		db.Lease.Tenant.Fetch(),
 		db.Lease.Property.Fetch().With(db.Property.Owner.Fetch()),
+		db.Lease.Guarantors.Fetch(),
 	).Exec(pdb.Context)
 	if err != nil {
 		if db.IsErrNotFound(err) {
@@ -103,6 +108,7 @@ func MockGetLeaseByID(c *services.PrismaDB) db.LeaseMockExpectParam {
 	).With(
 		db.Lease.Tenant.Fetch(),
 		db.Lease.Property.Fetch().With(db.Property.Owner.Fetch()),
+		db.Lease.Guarantors.Fetch(),
 	)
 }
 
@@ -113,6 +119,7 @@ func GetLeasesByProperty(propertyId string) []db.LeaseModel {
 	).With(
 		db.Lease.Tenant.Fetch(),
 		db.Lease.Property.Fetch().With(db.Property.Owner.Fetch()),
+		db.Lease.Guarantors.Fetch(),
 	).Exec(pdb.Context)
 	if err != nil {
 		if db.IsErrNotFound(err) {
@@ -129,6 +136,7 @@ func MockGetLeasesByProperty(c *services.PrismaDB) db.LeaseMockExpectParam {
 	).With(
 		db.Lease.Tenant.Fetch(),
 		db.Lease.Property.Fetch().With(db.Property.Owner.Fetch()),
+		db.Lease.Guarantors.Fetch(),
 	)
 }
 
@@ -139,6 +147,7 @@ func GetLeasesByTenant(tenantId string) []db.LeaseModel {
 	).With(
 		db.Lease.Tenant.Fetch(),
 		db.Lease.Property.Fetch().With(db.Property.Owner.Fetch()),
+		db.Lease.Guarantors.Fetch(),
 	).Exec(pdb.Context)
 	if err != nil {
 		if db.IsErrNotFound(err) {
@@ -155,6 +164,7 @@ func MockGetLeasesByTenant(c *services.PrismaDB) db.LeaseMockExpectParam {
 	).With(
 		db.Lease.Tenant.Fetch(),
 		db.Lease.Property.Fetch().With(db.Property.Owner.Fetch()),
+		db.Lease.Guarantors.Fetch(),
 	)
 }
 
@@ -175,13 +185,25 @@ func MockGetLeasesByTenant(c *services.PrismaDB) db.LeaseMockExpectParam {
 // 	return pc
 // }
 
-func CreateLease(leaseInvite db.LeaseInviteModel, tenant db.UserModel) db.LeaseModel {
+func getInitialLeaseStatus(startDate db.DateTime, now time.Time) db.LeaseStatus {
+	return utils.Ternary(startDate.After(now), db.LeaseStatusUpcoming, db.LeaseStatusActive)
+}
+
+func CreateLease(leaseInvite db.LeaseInviteModel, tenant db.UserModel, now time.Time) db.LeaseModel {
 	pdb := services.DBclient
 	newLease, err := pdb.Client.Lease.CreateOne(
 		db.Lease.StartDate.Set(leaseInvite.StartDate),
+		db.Lease.RentalPricePerMonth.Set(leaseInvite.RentalPricePerMonth),
+		db.Lease.DepositPrice.Set(leaseInvite.DepositPrice),
 		db.Lease.Tenant.Link(db.User.ID.Equals(tenant.ID)),
 		db.Lease.Property.Link(db.Property.ID.Equals(leaseInvite.PropertyID)),
 		db.Lease.EndDate.SetIfPresent(leaseInvite.InnerLeaseInvite.EndDate),
+		db.Lease.Status.Set(getInitialLeaseStatus(leaseInvite.StartDate, now)),
+		db.Lease.Furnished.Set(leaseInvite.Furnished),
+		db.Lease.Charges.Set(leaseInvite.Charges),
+		db.Lease.PaymentDay.Set(leaseInvite.PaymentDay),
+		db.Lease.SpecialClauses.SetIfPresent(leaseInvite.InnerLeaseInvite.SpecialClauses),
+		db.Lease.TermsAcceptedAt.Set(now.Truncate(time.Minute)),
 	).Exec(pdb.Context)
 	if err != nil {
 		panic(err)
@@ -195,27 +217,30 @@ func CreateLease(leaseInvite db.LeaseInviteModel, tenant db.UserModel) db.LeaseM
 	return *newLease
 }
 
-func MockCreateLease(c *services.PrismaDB, leaseInvite db.LeaseInviteModel) db.LeaseMockExpectParam {
+func MockCreateLease(c *services.PrismaDB, leaseInvite db.LeaseInviteModel, now time.Time) db.LeaseMockExpectParam {
 	return c.Client.Lease.CreateOne(
 		db.Lease.StartDate.Set(leaseInvite.StartDate),
+		db.Lease.RentalPricePerMonth.Set(leaseInvite.RentalPricePerMonth),
+		db.Lease.DepositPrice.Set(leaseInvite.DepositPrice),
 		db.Lease.Tenant.Link(db.User.ID.Equals("1")),
 		db.Lease.Property.Link(db.Property.ID.Equals(leaseInvite.PropertyID)),
 		db.Lease.EndDate.SetIfPresent(leaseInvite.InnerLeaseInvite.EndDate),
+		db.Lease.Status.Set(getInitialLeaseStatus(leaseInvite.StartDate, now)),
+		db.Lease.Furnished.Set(leaseInvite.Furnished),
+		db.Lease.Charges.Set(leaseInvite.Charges),
+		db.Lease.PaymentDay.Set(leaseInvite.PaymentDay),
+		db.Lease.SpecialClauses.SetIfPresent(leaseInvite.InnerLeaseInvite.SpecialClauses),
+		db.Lease.TermsAcceptedAt.Set(now.Truncate(time.Minute)),
 	)
 }
 
-func MockDeleteLeaseInviteById(c *services.PrismaDB) db.LeaseInviteMockExpectParam {
-	return c.Client.LeaseInvite.FindUnique(
-		db.LeaseInvite.ID.Equals("1"),
-	).Delete()
-}
-
 func EndLease(id string, endDate *db.DateTime) *db.LeaseModel {
 	pdb := services.DBclient
 	newLease, err := pdb.Client.Lease.FindUnique(
 		db.Lease.ID.Equals(id),
 	).Update(
 		db.Lease.Active.Set(false),
+		db.Lease.Status.Set(db.LeaseStatusEnded),
 		db.Lease.EndDate.SetIfPresent(endDate),
 	).Exec(pdb.Context)
 	if err != nil {
@@ -232,13 +257,91 @@ func MockEndLease(c *services.PrismaDB, endDate *time.Time) db.LeaseMockExpectPa
 		db.Lease.ID.Equals("1"),
 	).Update(
 		db.Lease.Active.Set(false),
+		db.Lease.Status.Set(db.LeaseStatusEnded),
 		db.Lease.EndDate.SetIfPresent(endDate),
 	)
 }
 
-func GetLeaseInviteById(id string) *db.LeaseInviteModel {
+func UpdateLeaseEndDate(id string, endDate db.DateTime) *db.LeaseModel {
 	pdb := services.DBclient
-	pc, err := pdb.Client.LeaseInvite.FindUnique(db.LeaseInvite.ID.Equals(id)).Exec(pdb.Context)
+	newLease, err := pdb.Client.Lease.FindUnique(
+		db.Lease.ID.Equals(id),
+	).Update(
+		db.Lease.EndDate.Set(endDate),
+	).Exec(pdb.Context)
+	if err != nil {
+		if db.IsErrNotFound(err) {
+			return nil
+		}
+		panic(err)
+	}
+	return newLease
+}
+
+func MockUpdateLeaseEndDate(c *services.PrismaDB, endDate db.DateTime) db.LeaseMockExpectParam {
+	return c.Client.Lease.FindUnique(
+		db.Lease.ID.Equals("1"),
+	).Update(
+		db.Lease.EndDate.Set(endDate),
+	)
+}
+
+func GetLeasesNotEnded() []db.LeaseModel {
+	pdb := services.DBclient
+	leases, err := pdb.Client.Lease.FindMany(
+		db.Lease.Status.In([]db.LeaseStatus{db.LeaseStatusUpcoming, db.LeaseStatusActive, db.LeaseStatusEnding}),
+	).With(
+		db.Lease.Tenant.Fetch(),
+		db.Lease.Property.Fetch().With(db.Property.Owner.Fetch()),
+	).Exec(pdb.Context)
+	if err != nil {
+		panic(err)
+	}
+	return leases
+}
+
+func MockGetLeasesNotEnded(c *services.PrismaDB) db.LeaseMockExpectParam {
+	return c.Client.Lease.FindMany(
+		db.Lease.Status.In([]db.LeaseStatus{db.LeaseStatusUpcoming, db.LeaseStatusActive, db.LeaseStatusEnding}),
+	).With(
+		db.Lease.Tenant.Fetch(),
+		db.Lease.Property.Fetch().With(db.Property.Owner.Fetch()),
+	)
+}
+
+func UpdateLeaseStatus(id string, status db.LeaseStatus) *db.LeaseModel {
+	pdb := services.DBclient
+	newLease, err := pdb.Client.Lease.FindUnique(
+		db.Lease.ID.Equals(id),
+	).Update(
+		db.Lease.Status.Set(status),
+		db.Lease.Active.Set(status != db.LeaseStatusEnded),
+	).Exec(pdb.Context)
+	if err != nil {
+		if db.IsErrNotFound(err) {
+			return nil
+		}
+		panic(err)
+	}
+	return newLease
+}
+
+func MockUpdateLeaseStatus(c *services.PrismaDB, status db.LeaseStatus) db.LeaseMockExpectParam {
+	return c.Client.Lease.FindUnique(
+		db.Lease.ID.Equals("1"),
+	).Update(
+		db.Lease.Status.Set(status),
+		db.Lease.Active.Set(status != db.LeaseStatusEnded),
+	)
+}
+
+func GetLeaseInviteByToken(token string) *db.LeaseInviteModel {
+	pdb := services.DBclient
+	pc, err := pdb.Client.LeaseInvite.FindUnique(
+		db.LeaseInvite.TokenHash.Equals(utils.HashToken(token)),
+	).With(
+		db.LeaseInvite.Property.Fetch().With(db.Property.Owner.Fetch()),
+	).Exec(pdb.Context)
 	if err != nil {
 		if db.IsErrNotFound(err) {
 			return nil
@@ -248,9 +351,11 @@ func GetLeaseInviteById(id string) *db.LeaseInviteModel {
 	return pc
 }
 
-func MockGetLeaseInviteByID(c *services.PrismaDB) db.LeaseInviteMockExpectParam {
+func MockGetLeaseInviteByToken(c *services.PrismaDB) db.LeaseInviteMockExpectParam {
 	return c.Client.LeaseInvite.FindUnique(
-		db.LeaseInvite.ID.Equals("1"),
+		db.LeaseInvite.TokenHash.Equals(utils.HashToken("1")),
+	).With(
+		db.LeaseInvite.Property.Fetch().With(db.Property.Owner.Fetch()),
 	)
 }
 
@@ -272,13 +377,35 @@ func MockGetCurrentLeaseInvite(c *services.PrismaDB) db.LeaseInviteMockExpectPar
 	)
 }
 
-func CreateLeaseInvite(leaseInvite db.LeaseInviteModel, propertyId string) *db.LeaseInviteModel {
+func getLeaseInviteExpiry(now time.Time) db.DateTime {
+	return now.Add(db.LeaseInviteValidity).Truncate(time.Minute)
+}
+
+// CreateLeaseInvite creates the invite of a property, replacing its previous invite if it has expired. It returns nil if
+// the property still has a pending invite.
+func CreateLeaseInvite(leaseInvite db.LeaseInviteModel, propertyId string, now time.Time) *db.LeaseInviteModel {
 	pdb := services.DBclient
+	_, err := pdb.Client.LeaseInvite.FindMany(
+		db.LeaseInvite.PropertyID.Equals(propertyId),
+		db.LeaseInvite.ExpiresAt.Lte(now),
+	).Delete().Exec(pdb.Context)
+	if err != nil {
+		panic(err)
+	}
+
 	newLease, err := pdb.Client.LeaseInvite.CreateOne(
 		db.LeaseInvite.TenantEmail.Set(utils.SanitizeEmail(leaseInvite.TenantEmail)),
+		db.LeaseInvite.TokenHash.Set(leaseInvite.TokenHash),
 		db.LeaseInvite.StartDate.Set(leaseInvite.StartDate),
+		db.LeaseInvite.ExpiresAt.Set(getLeaseInviteExpiry(now)),
+		db.LeaseInvite.RentalPricePerMonth.Set(leaseInvite.RentalPricePerMonth),
+		db.LeaseInvite.DepositPrice.Set(leaseInvite.DepositPrice),
 		db.LeaseInvite.Property.Link(db.Property.ID.Equals(propertyId)),
 		db.LeaseInvite.EndDate.SetIfPresent(leaseInvite.InnerLeaseInvite.EndDate),
+		db.LeaseInvite.Furnished.Set(leaseInvite.Furnished),
+		db.LeaseInvite.Charges.Set(leaseInvite.Charges),
+		db.LeaseInvite.PaymentDay.Set(leaseInvite.PaymentDay),
+		db.LeaseInvite.SpecialClauses.SetIfPresent(leaseInvite.InnerLeaseInvite.SpecialClauses),
 	).With(
 		db.LeaseInvite.Property.Fetch().With(db.Property.Owner.Fetch()),
 	).Exec(pdb.Context)
@@ -296,12 +423,27 @@ func CreateLeaseInvite(leaseInvite db.LeaseInviteModel, propertyId string) *db.L
 	return newLease
 }
 
-func MockCreateLeaseInvite(c *services.PrismaDB, leaseInvite db.LeaseInviteModel) db.LeaseInviteMockExpectParam {
+func MockDeleteExpiredLeaseInvite(c *services.PrismaDB, now time.Time) db.LeaseInviteMockExpectParam {
+	return c.Client.LeaseInvite.FindMany(
+		db.LeaseInvite.PropertyID.Equals("1"),
+		db.LeaseInvite.ExpiresAt.Lte(now),
+	).Delete()
+}
+
+func MockCreateLeaseInvite(c *services.PrismaDB, leaseInvite db.LeaseInviteModel, now time.Time) db.LeaseInviteMockExpectParam {
 	return c.Client.LeaseInvite.CreateOne(
 		db.LeaseInvite.TenantEmail.Set(utils.SanitizeEmail(leaseInvite.TenantEmail)),
+		db.LeaseInvite.TokenHash.Set(leaseInvite.TokenHash),
 		db.LeaseInvite.StartDate.Set(leaseInvite.StartDate),
+		db.LeaseInvite.ExpiresAt.Set(getLeaseInviteExpiry(now)),
+		db.LeaseInvite.RentalPricePerMonth.Set(leaseInvite.RentalPricePerMonth),
+		db.LeaseInvite.DepositPrice.Set(leaseInvite.DepositPrice),
 		db.LeaseInvite.Property.Link(db.Property.ID.Equals("1")),
 		db.LeaseInvite.EndDate.SetIfPresent(leaseInvite.InnerLeaseInvite.EndDate),
+		db.LeaseInvite.Furnished.Set(leaseInvite.Furnished),
+		db.LeaseInvite.Charges.Set(leaseInvite.Charges),
+		db.LeaseInvite.PaymentDay.Set(leaseInvite.PaymentDay),
+		db.LeaseInvite.SpecialClauses.SetIfPresent(leaseInvite.InnerLeaseInvite.SpecialClauses),
 	).With(
 		db.LeaseInvite.Property.Fetch().With(db.Property.Owner.Fetch()),
 	)
@@ -322,3 +464,143 @@ func MockDeleteCurrentLeaseInvite(c *services.PrismaDB) db.LeaseInviteMockExpect
 		db.LeaseInvite.PropertyID.Equals("1"),
 	).Delete()
 }
+
+func RenewLeaseInvite(id string, tokenHash string, now time.Time) *db.LeaseInviteModel {
+	pdb := services.DBclient
+	invite, err := pdb.Client.LeaseInvite.FindUnique(
+		db.LeaseInvite.ID.Equals(id),
+	).With(
+		db.LeaseInvite.Property.Fetch().With(db.Property.Owner.Fetch()),
+	).Update(
+		db.LeaseInvite.ExpiresAt.Set(getLeaseInviteExpiry(now)),
+		db.LeaseInvite.RemindersSent.Set(0),
+		db.LeaseInvite.TokenHash.Set(tokenHash),
+	).Exec(pdb.Context)
+	if err != nil {
+		if db.IsErrNotFound(err) {
+			return nil
+		}
+		panic(err)
+	}
+	return invite
+}
+
+func MockRenewLeaseInvite(c *services.PrismaDB, tokenHash string, now time.Time) db.LeaseInviteMockExpectParam {
+	return c.Client.LeaseInvite.FindUnique(
+		db.LeaseInvite.ID.Equals("1"),
+	).With(
+		db.LeaseInvite.Property.Fetch().With(db.Property.Owner.Fetch()),
+	).Update(
+		db.LeaseInvite.ExpiresAt.Set(getLeaseInviteExpiry(now)),
+		db.LeaseInvite.RemindersSent.Set(0),
+		db.LeaseInvite.TokenHash.Set(tokenHash),
+	)
+}
+
+func GetPendingLeaseInvites(now time.Time) []db.LeaseInviteModel {
+	pdb := services.DBclient
+	invites, err := pdb.Client.LeaseInvite.FindMany(
+		db.LeaseInvite.ExpiresAt.Gt(now),
+	).With(
+		db.LeaseInvite.Property.Fetch().With(db.Property.Owner.Fetch()),
+	).Exec(pdb.Context)
+	if err != nil {
+		panic(err)
+	}
+	return invites
+}
+
+func MockGetPendingLeaseInvites(c *services.PrismaDB, now time.Time) db.LeaseInviteMockExpectParam {
+	return c.Client.LeaseInvite.FindMany(
+		db.LeaseInvite.ExpiresAt.Gt(now),
+	).With(
+		db.LeaseInvite.Property.Fetch().With(db.Property.Owner.Fetch()),
+	)
+}
+
+// IncrementLeaseInviteReminders counts a sent reminder and replaces the token of the invite with the one of its link.
+func IncrementLeaseInviteReminders(id string, tokenHash string) {
+	pdb := services.DBclient
+	_, err := pdb.Client.LeaseInvite.FindUnique(
+		db.LeaseInvite.ID.Equals(id),
+	).Update(
+		db.LeaseInvite.RemindersSent.Increment(1),
+		db.LeaseInvite.TokenHash.Set(tokenHash),
+	).Exec(pdb.Context)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func MockIncrementLeaseInviteReminders(c *services.PrismaDB, tokenHash string) db.LeaseInviteMockExpectParam {
+	return c.Client.LeaseInvite.FindUnique(
+		db.LeaseInvite.ID.Equals("1"),
+	).Update(
+		db.LeaseInvite.RemindersSent.Increment(1),
+		db.LeaseInvite.TokenHash.Set(tokenHash),
+	)
+}
+
+// GetLeaseInvitesByEmail returns the invites sent to an email that have not expired yet.
+func GetLeaseInvitesByEmail(email string, now time.Time) []db.LeaseInviteModel {
+	pdb := services.DBclient
+	invites, err := pdb.Client.LeaseInvite.FindMany(
+		db.LeaseInvite.TenantEmail.Equals(utils.SanitizeEmail(email)),
+		db.LeaseInvite.ExpiresAt.Gt(now),
+	).With(
+		db.LeaseInvite.Property.Fetch().With(db.Property.Owner.Fetch()),
+	).Exec(pdb.Context)
+	if err != nil {
+		panic(err)
+	}
+	return invites
+}
+
+func MockGetLeaseInvitesByEmail(c *services.PrismaDB, email string, now time.Time) db.LeaseInviteMockExpectParam {
+	return c.Client.LeaseInvite.FindMany(
+		db.LeaseInvite.TenantEmail.Equals(utils.SanitizeEmail(email)),
+		db.LeaseInvite.ExpiresAt.Gt(now),
+	).With(
+		db.LeaseInvite.Property.Fetch().With(db.Property.Owner.Fetch()),
+	)
+}
+
+func GetLeaseInviteByID(id string) *db.LeaseInviteModel {
+	pdb := services.DBclient
+	invite, err := pdb.Client.LeaseInvite.FindUnique(
+		db.LeaseInvite.ID.Equals(id),
+	).With(
+		db.LeaseInvite.Property.Fetch().With(db.Property.Owner.Fetch()),
+	).Exec(pdb.Context)
+	if err != nil {
+		if db.IsErrNotFound(err) {
+			return nil
+		}
+		panic(err)
+	}
+	return invite
+}
+
+func MockGetLeaseInviteByID(c *services.PrismaDB) db.LeaseInviteMockExpectParam {
+	return c.Client.LeaseInvite.FindUnique(
+		db.LeaseInvite.ID.Equals("1"),
+	).With(
+		db.LeaseInvite.Property.Fetch().With(db.Property.Owner.Fetch()),
+	)
+}
+
+func DeleteLeaseInviteById(id string) {
+	pdb := services.DBclient
+	_, err := pdb.Client.LeaseInvite.FindUnique(
+		db.LeaseInvite.ID.Equals(id),
+	).Delete().Exec(pdb.Context)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func MockDeleteLeaseInviteById(c *services.PrismaDB) db.LeaseInviteMockExpectParam {
+	return c.Client.LeaseInvite.FindUnique(
+		db.LeaseInvite.ID.Equals("1"),
+	).Delete()
+}
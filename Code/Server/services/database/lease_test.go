@@ -10,6 +10,7 @@ import (
 	"keyz/backend/prisma/db"
 	"keyz/backend/services"
 	"keyz/backend/services/database"
+	"keyz/backend/utils"
 )
 
 func BuildTestLease() db.LeaseModel {
@@ -18,6 +19,7 @@ func BuildTestLease() db.LeaseModel {
 		InnerLease: db.InnerLease{
 			ID:         "1",
 			Active:     true,
+			Status:     db.LeaseStatusActive,
 			StartDate:  time.Now(),
 			EndDate:    &end,
 			TenantID:   "1",
@@ -31,12 +33,17 @@ func BuildTestLeaseInvite() db.LeaseInviteModel {
 	end := time.Now().Add(time.Hour)
 	return db.LeaseInviteModel{
 		InnerLeaseInvite: db.InnerLeaseInvite{
-			ID:          "1",
-			TenantEmail: "test@example.com",
-			StartDate:   time.Now(),
-			EndDate:     &end,
-			PropertyID:  "1",
-			CreatedAt:   time.Now(),
+			ID:                  "1",
+			TenantEmail:         "test@example.com",
+			StartDate:           time.Now(),
+			EndDate:             &end,
+			TokenHash:           utils.HashToken("1"),
+			PropertyID:          "1",
+			CreatedAt:           time.Now(),
+			ExpiresAt:           time.Now().Add(time.Hour),
+			RentalPricePerMonth: 500,
+			DepositPrice:        1000,
+			PaymentDay:          1,
 		},
 	}
 }
@@ -60,13 +67,14 @@ func TestCreateLease(t *testing.T) {
 	c, m, ensure := services.ConnectDBTest()
 	defer ensure(t)
 
+	now := time.Now()
 	tenant := BuildTestTenant("1")
 	leaseInvite := BuildTestLeaseInvite()
 	lease := BuildTestLease()
-	m.Lease.Expect(database.MockCreateLease(c, leaseInvite)).Returns(lease)
+	m.Lease.Expect(database.MockCreateLease(c, leaseInvite, now)).Returns(lease)
 	m.LeaseInvite.Expect(database.MockDeleteLeaseInviteById(c)).Returns(leaseInvite)
 
-	newLease := database.CreateLease(leaseInvite, tenant)
+	newLease := database.CreateLease(leaseInvite, tenant, now)
 	assert.NotNil(t, newLease)
 	assert.Equal(t, lease.TenantID, newLease.TenantID)
 	assert.Equal(t, lease.PropertyID, newLease.PropertyID)
@@ -76,12 +84,13 @@ func TestCreateLease_NoConnection1(t *testing.T) {
 	c, m, ensure := services.ConnectDBTest()
 	defer ensure(t)
 
+	now := time.Now()
 	tenant := BuildTestTenant("1")
 	leaseInvite := BuildTestLeaseInvite()
-	m.Lease.Expect(database.MockCreateLease(c, leaseInvite)).Errors(errors.New("connection failed"))
+	m.Lease.Expect(database.MockCreateLease(c, leaseInvite, now)).Errors(errors.New("connection failed"))
 
 	assert.Panics(t, func() {
-		database.CreateLease(leaseInvite, tenant)
+		database.CreateLease(leaseInvite, tenant, now)
 	})
 }
 
@@ -89,49 +98,50 @@ func TestCreateLease_NoConnection2(t *testing.T) {
 	c, m, ensure := services.ConnectDBTest()
 	defer ensure(t)
 
+	now := time.Now()
 	tenant := BuildTestTenant("1")
 	leaseInvite := BuildTestLeaseInvite()
 	lease := BuildTestLease()
-	m.Lease.Expect(database.MockCreateLease(c, leaseInvite)).Returns(lease)
+	m.Lease.Expect(database.MockCreateLease(c, leaseInvite, now)).Returns(lease)
 	m.LeaseInvite.Expect(database.MockDeleteLeaseInviteById(c)).Errors(errors.New("connection failed"))
 
 	assert.Panics(t, func() {
-		database.CreateLease(leaseInvite, tenant)
+		database.CreateLease(leaseInvite, tenant, now)
 	})
 }
 
 // #############################################################################
 
-func TestGetLeaseInviteByID(t *testing.T) {
+func TestGetLeaseInviteByToken(t *testing.T) {
 	c, m, ensure := services.ConnectDBTest()
 	defer ensure(t)
 
 	user := BuildTestLeaseInvite()
-	m.LeaseInvite.Expect(database.MockGetLeaseInviteByID(c)).Returns(user)
+	m.LeaseInvite.Expect(database.MockGetLeaseInviteByToken(c)).Returns(user)
 
-	foundPending := database.GetLeaseInviteById("1")
+	foundPending := database.GetLeaseInviteByToken("1")
 	assert.NotNil(t, foundPending)
 	assert.Equal(t, user.ID, foundPending.ID)
 }
 
-func TestGetLeaseInviteByID_NotFound(t *testing.T) {
+func TestGetLeaseInviteByToken_NotFound(t *testing.T) {
 	c, m, ensure := services.ConnectDBTest()
 	defer ensure(t)
 
-	m.LeaseInvite.Expect(database.MockGetLeaseInviteByID(c)).Errors(db.ErrNotFound)
+	m.LeaseInvite.Expect(database.MockGetLeaseInviteByToken(c)).Errors(db.ErrNotFound)
 
-	foundPending := database.GetLeaseInviteById("1")
+	foundPending := database.GetLeaseInviteByToken("1")
 	assert.Nil(t, foundPending)
 }
 
-func TestGetLeaseInviteByID_NoConnection(t *testing.T) {
+func TestGetLeaseInviteByToken_NoConnection(t *testing.T) {
 	c, m, ensure := services.ConnectDBTest()
 	defer ensure(t)
 
-	m.LeaseInvite.Expect(database.MockGetLeaseInviteByID(c)).Errors(errors.New("connection failed"))
+	m.LeaseInvite.Expect(database.MockGetLeaseInviteByToken(c)).Errors(errors.New("connection failed"))
 
 	assert.Panics(t, func() {
-		database.GetLeaseInviteById("1")
+		database.GetLeaseInviteByToken("1")
 	})
 }
 
@@ -141,10 +151,29 @@ func TestCreateLeaseInvite(t *testing.T) {
 	c, m, ensure := services.ConnectDBTest()
 	defer ensure(t)
 
+	now := time.Now()
+	leaseInvite := BuildTestLeaseInvite()
+	m.LeaseInvite.Expect(database.MockDeleteExpiredLeaseInvite(c, now)).Returns(db.LeaseInviteModel{})
+	m.LeaseInvite.Expect(database.MockCreateLeaseInvite(c, leaseInvite, now)).Returns(leaseInvite)
+
+	newLease := database.CreateLeaseInvite(leaseInvite, "1", now)
+	assert.NotNil(t, newLease)
+	assert.Equal(t, leaseInvite.ID, newLease.ID)
+}
+
+func TestCreateLeaseInvite_ReplacesExpired(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	now := time.Now()
+	expired := BuildTestLeaseInvite()
+	expired.ID = "2"
+	expired.ExpiresAt = now.Add(-time.Hour)
 	leaseInvite := BuildTestLeaseInvite()
-	m.LeaseInvite.Expect(database.MockCreateLeaseInvite(c, leaseInvite)).Returns(leaseInvite)
+	m.LeaseInvite.Expect(database.MockDeleteExpiredLeaseInvite(c, now)).Returns(expired)
+	m.LeaseInvite.Expect(database.MockCreateLeaseInvite(c, leaseInvite, now)).Returns(leaseInvite)
 
-	newLease := database.CreateLeaseInvite(leaseInvite, "1")
+	newLease := database.CreateLeaseInvite(leaseInvite, "1", now)
 	assert.NotNil(t, newLease)
 	assert.Equal(t, leaseInvite.ID, newLease.ID)
 }
@@ -153,17 +182,19 @@ func TestCreateLeaseInvite_AlreadyExists1(t *testing.T) {
 	c, m, ensure := services.ConnectDBTest()
 	defer ensure(t)
 
+	now := time.Now()
 	leaseInvite := BuildTestLeaseInvite()
-	m.LeaseInvite.Expect(database.MockCreateLeaseInvite(c, leaseInvite)).Errors(&protocol.UserFacingError{
+	m.LeaseInvite.Expect(database.MockDeleteExpiredLeaseInvite(c, now)).Returns(db.LeaseInviteModel{})
+	m.LeaseInvite.Expect(database.MockCreateLeaseInvite(c, leaseInvite, now)).Errors(&protocol.UserFacingError{
 		IsPanic:   false,
 		ErrorCode: "P2002", // https://www.prisma.io/docs/orm/reference/error-reference
 		Meta: protocol.Meta{
-			Target: []any{"tenant_email"},
+			Target: []any{"property_id"},
 		},
 		Message: "Unique constraint failed",
 	})
 
-	newLease := database.CreateLeaseInvite(leaseInvite, "1")
+	newLease := database.CreateLeaseInvite(leaseInvite, "1", now)
 	assert.Nil(t, newLease)
 }
 
@@ -171,8 +202,10 @@ func TestCreateLeaseInvite_AlreadyExists2(t *testing.T) {
 	c, m, ensure := services.ConnectDBTest()
 	defer ensure(t)
 
+	now := time.Now()
 	leaseInvite := BuildTestLeaseInvite()
-	m.LeaseInvite.Expect(database.MockCreateLeaseInvite(c, leaseInvite)).Errors(&protocol.UserFacingError{
+	m.LeaseInvite.Expect(database.MockDeleteExpiredLeaseInvite(c, now)).Returns(db.LeaseInviteModel{})
+	m.LeaseInvite.Expect(database.MockCreateLeaseInvite(c, leaseInvite, now)).Errors(&protocol.UserFacingError{
 		IsPanic:   false,
 		ErrorCode: "P2014", // https://www.prisma.io/docs/orm/reference/error-reference
 		Meta: protocol.Meta{
@@ -181,19 +214,34 @@ func TestCreateLeaseInvite_AlreadyExists2(t *testing.T) {
 		Message: "Unique constraint failed",
 	})
 
-	newLease := database.CreateLeaseInvite(leaseInvite, "1")
+	newLease := database.CreateLeaseInvite(leaseInvite, "1", now)
 	assert.Nil(t, newLease)
 }
 
-func TestCreateLeaseInvite_NoConnection(t *testing.T) {
+func TestCreateLeaseInvite_NoConnection1(t *testing.T) {
 	c, m, ensure := services.ConnectDBTest()
 	defer ensure(t)
 
+	now := time.Now()
 	leaseInvite := BuildTestLeaseInvite()
-	m.LeaseInvite.Expect(database.MockCreateLeaseInvite(c, leaseInvite)).Errors(errors.New("connection failed"))
+	m.LeaseInvite.Expect(database.MockDeleteExpiredLeaseInvite(c, now)).Errors(errors.New("connection failed"))
 
 	assert.Panics(t, func() {
-		database.CreateLeaseInvite(leaseInvite, "1")
+		database.CreateLeaseInvite(leaseInvite, "1", now)
+	})
+}
+
+func TestCreateLeaseInvite_NoConnection2(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	now := time.Now()
+	leaseInvite := BuildTestLeaseInvite()
+	m.LeaseInvite.Expect(database.MockDeleteExpiredLeaseInvite(c, now)).Returns(db.LeaseInviteModel{})
+	m.LeaseInvite.Expect(database.MockCreateLeaseInvite(c, leaseInvite, now)).Errors(errors.New("connection failed"))
+
+	assert.Panics(t, func() {
+		database.CreateLeaseInvite(leaseInvite, "1", now)
 	})
 }
 
@@ -301,6 +349,67 @@ func TestEndLease_NoConnection(t *testing.T) {
 
 // #############################################################################
 
+func TestGetLeasesNotEnded(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	lease := BuildTestLease()
+	m.Lease.Expect(database.MockGetLeasesNotEnded(c)).ReturnsMany([]db.LeaseModel{lease})
+
+	leases := database.GetLeasesNotEnded()
+	assert.Len(t, leases, 1)
+	assert.Equal(t, lease.ID, leases[0].ID)
+}
+
+func TestGetLeasesNotEnded_NoConnection(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	m.Lease.Expect(database.MockGetLeasesNotEnded(c)).Errors(errors.New("connection failed"))
+
+	assert.Panics(t, func() {
+		database.GetLeasesNotEnded()
+	})
+}
+
+// #############################################################################
+
+func TestUpdateLeaseStatus(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	lease := BuildTestLease()
+	lease.Status = db.LeaseStatusEnding
+	m.Lease.Expect(database.MockUpdateLeaseStatus(c, db.LeaseStatusEnding)).Returns(lease)
+
+	updatedLease := database.UpdateLeaseStatus(lease.ID, db.LeaseStatusEnding)
+	assert.NotNil(t, updatedLease)
+	assert.Equal(t, db.LeaseStatusEnding, updatedLease.Status)
+}
+
+func TestUpdateLeaseStatus_NotFound(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	m.Lease.Expect(database.MockUpdateLeaseStatus(c, db.LeaseStatusEnded)).Errors(db.ErrNotFound)
+
+	updatedLease := database.UpdateLeaseStatus("1", db.LeaseStatusEnded)
+	assert.Nil(t, updatedLease)
+}
+
+func TestUpdateLeaseStatus_NoConnection(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	m.Lease.Expect(database.MockUpdateLeaseStatus(c, db.LeaseStatusEnded)).Errors(errors.New("connection failed"))
+
+	assert.Panics(t, func() {
+		database.UpdateLeaseStatus("1", db.LeaseStatusEnded)
+	})
+}
+
+// #############################################################################
+
 func TestGetCurrentActiveLeaseByTenant(t *testing.T) {
 	c, m, ensure := services.ConnectDBTest()
 	defer ensure(t)
@@ -554,3 +663,137 @@ func TestGetLeasesByTenant_NoConnection(t *testing.T) {
 		database.GetLeasesByTenant(tenant.ID)
 	})
 }
+
+// #############################################################################
+
+func TestRenewLeaseInvite(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	now := time.Now()
+	leaseInvite := BuildTestLeaseInvite()
+	m.LeaseInvite.Expect(database.MockRenewLeaseInvite(c, leaseInvite.TokenHash, now)).Returns(leaseInvite)
+
+	renewed := database.RenewLeaseInvite(leaseInvite.ID, leaseInvite.TokenHash, now)
+	assert.NotNil(t, renewed)
+	assert.Equal(t, leaseInvite.ID, renewed.ID)
+}
+
+func TestRenewLeaseInvite_NotFound(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	now := time.Now()
+	hash := utils.HashToken("1")
+	m.LeaseInvite.Expect(database.MockRenewLeaseInvite(c, hash, now)).Errors(db.ErrNotFound)
+
+	assert.Nil(t, database.RenewLeaseInvite("1", hash, now))
+}
+
+func TestGetPendingLeaseInvites(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	now := time.Now()
+	leaseInvite := BuildTestLeaseInvite()
+	m.LeaseInvite.Expect(database.MockGetPendingLeaseInvites(c, now)).ReturnsMany([]db.LeaseInviteModel{leaseInvite})
+
+	invites := database.GetPendingLeaseInvites(now)
+	assert.Len(t, invites, 1)
+}
+
+func TestGetPendingLeaseInvites_NoConnection(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	now := time.Now()
+	m.LeaseInvite.Expect(database.MockGetPendingLeaseInvites(c, now)).Errors(errors.New("connection failed"))
+
+	assert.Panics(t, func() {
+		database.GetPendingLeaseInvites(now)
+	})
+}
+
+func TestIncrementLeaseInviteReminders(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	leaseInvite := BuildTestLeaseInvite()
+	leaseInvite.RemindersSent = 1
+	m.LeaseInvite.Expect(database.MockIncrementLeaseInviteReminders(c, leaseInvite.TokenHash)).Returns(leaseInvite)
+
+	assert.NotPanics(t, func() {
+		database.IncrementLeaseInviteReminders(leaseInvite.ID, leaseInvite.TokenHash)
+	})
+}
+
+// #############################################################################
+
+func TestGetLeaseInvitesByEmail(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	now := time.Now()
+	leaseInvite := BuildTestLeaseInvite()
+	m.LeaseInvite.Expect(database.MockGetLeaseInvitesByEmail(c, leaseInvite.TenantEmail, now)).ReturnsMany([]db.LeaseInviteModel{leaseInvite})
+
+	invites := database.GetLeaseInvitesByEmail(leaseInvite.TenantEmail, now)
+	assert.Len(t, invites, 1)
+	assert.Equal(t, leaseInvite.ID, invites[0].ID)
+}
+
+func TestGetLeaseInvitesByEmail_NoConnection(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	now := time.Now()
+	m.LeaseInvite.Expect(database.MockGetLeaseInvitesByEmail(c, "test@example.com", now)).Errors(errors.New("connection failed"))
+
+	assert.Panics(t, func() {
+		database.GetLeaseInvitesByEmail("test@example.com", now)
+	})
+}
+
+func TestGetLeaseInviteByID(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	leaseInvite := BuildTestLeaseInvite()
+	m.LeaseInvite.Expect(database.MockGetLeaseInviteByID(c)).Returns(leaseInvite)
+
+	found := database.GetLeaseInviteByID(leaseInvite.ID)
+	assert.NotNil(t, found)
+	assert.Equal(t, leaseInvite.ID, found.ID)
+}
+
+func TestGetLeaseInviteByID_NotFound(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	m.LeaseInvite.Expect(database.MockGetLeaseInviteByID(c)).Errors(db.ErrNotFound)
+
+	assert.Nil(t, database.GetLeaseInviteByID("1"))
+}
+
+func TestDeleteLeaseInviteById(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	leaseInvite := BuildTestLeaseInvite()
+	m.LeaseInvite.Expect(database.MockDeleteLeaseInviteById(c)).Returns(leaseInvite)
+
+	assert.NotPanics(t, func() {
+		database.DeleteLeaseInviteById(leaseInvite.ID)
+	})
+}
+
+func TestDeleteLeaseInviteById_NoConnection(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	m.LeaseInvite.Expect(database.MockDeleteLeaseInviteById(c)).Errors(errors.New("connection failed"))
+
+	assert.Panics(t, func() {
+		database.DeleteLeaseInviteById("1")
+	})
+}
@@ -0,0 +1,69 @@
+package database
+
+import (
+	"keyz/backend/prisma/db"
+	"keyz/backend/services"
+)
+
+// ownerLeaseParam selects the leases of the properties of an owner.
+func ownerLeaseParam(ownerID string) db.LeaseWhereParam {
+	return db.Lease.Property.Where(db.Property.OwnerID.Equals(ownerID))
+}
+
+// ownerImagesParam selects the images of the properties of an owner: property pictures,
+// inventory report pictures, and damage and damage message pictures.
+func ownerImagesParam(ownerID string) db.ImageWhereParam {
+	return db.Image.Or(
+		db.Image.Properties.Some(db.Property.OwnerID.Equals(ownerID)),
+		db.Image.Roomstates.Some(db.RoomState.Report.Where(db.InventoryReport.Lease.Where(ownerLeaseParam(ownerID)))),
+		db.Image.Furniturestates.Some(db.FurnitureState.Report.Where(db.InventoryReport.Lease.Where(ownerLeaseParam(ownerID)))),
+		db.Image.Damages.Some(db.Damage.Lease.Where(ownerLeaseParam(ownerID))),
+		db.Image.DamageMessages.Some(db.DamageMessage.Damage.Where(db.Damage.Lease.Where(ownerLeaseParam(ownerID)))),
+	)
+}
+
+// GetOwnerImagesSize returns the size of the images of the properties of an owner, in bytes.
+// Only the sizes are fetched, not the content of the images.
+func GetOwnerImagesSize(ownerID string) int {
+	pdb := services.DBclient
+	images, err := pdb.Client.Image.FindMany(
+		ownerImagesParam(ownerID),
+	).Select(db.Image.Size.Field()).Exec(pdb.Context)
+	if err != nil {
+		panic(err)
+	}
+	size := 0
+	for _, image := range images {
+		size += image.Size
+	}
+	return size
+}
+
+func MockGetOwnerImagesSize(c *services.PrismaDB) db.ImageMockExpectParam {
+	return c.Client.Image.FindMany(
+		ownerImagesParam("1"),
+	).Select(db.Image.Size.Field())
+}
+
+// GetOwnerDocumentsSize returns the size of the documents of the leases of an owner, in bytes.
+// Previous versions and deleted documents count until they are purged.
+func GetOwnerDocumentsSize(ownerID string) int {
+	pdb := services.DBclient
+	documents, err := pdb.Client.Document.FindMany(
+		db.Document.Lease.Where(ownerLeaseParam(ownerID)),
+	).Select(db.Document.Size.Field()).Exec(pdb.Context)
+	if err != nil {
+		panic(err)
+	}
+	size := 0
+	for _, document := range documents {
+		size += document.Size
+	}
+	return size
+}
+
+func MockGetOwnerDocumentsSize(c *services.PrismaDB) db.DocumentMockExpectParam {
+	return c.Client.Document.FindMany(
+		db.Document.Lease.Where(ownerLeaseParam("1")),
+	).Select(db.Document.Size.Field())
+}
@@ -0,0 +1,56 @@
+package database_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"keyz/backend/prisma/db"
+	"keyz/backend/services"
+	"keyz/backend/services/database"
+)
+
+func TestGetOwnerImagesSize(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	m.Image.Expect(database.MockGetOwnerImagesSize(c)).ReturnsMany([]db.ImageModel{
+		{InnerImage: db.InnerImage{Size: 1000}},
+		{InnerImage: db.InnerImage{Size: 500}},
+	})
+
+	assert.Equal(t, 1500, database.GetOwnerImagesSize("1"))
+}
+
+func TestGetOwnerImagesSize_NoConnection(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	m.Image.Expect(database.MockGetOwnerImagesSize(c)).Errors(errors.New("connection failed"))
+
+	assert.Panics(t, func() {
+		database.GetOwnerImagesSize("1")
+	})
+}
+
+func TestGetOwnerDocumentsSize(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	m.Document.Expect(database.MockGetOwnerDocumentsSize(c)).ReturnsMany([]db.DocumentModel{
+		{InnerDocument: db.InnerDocument{Size: 2000}},
+	})
+
+	assert.Equal(t, 2000, database.GetOwnerDocumentsSize("1"))
+}
+
+func TestGetOwnerDocumentsSize_NoConnection(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	m.Document.Expect(database.MockGetOwnerDocumentsSize(c)).Errors(errors.New("connection failed"))
+
+	assert.Panics(t, func() {
+		database.GetOwnerDocumentsSize("1")
+	})
+}
@@ -0,0 +1,105 @@
+package pdf
+
+import (
+	"log"
+	"strconv"
+
+	"keyz/backend/models"
+	"keyz/backend/prisma/db"
+)
+
+// NewDamageClaimPDF creates the cover page of an insurance claim, with the property, the lease, the damage and its timeline.
+// The room and furniture states come from the latest inventory report, when there is one.
+func NewDamageClaimPDF(damage db.DamageModel, lease db.LeaseModel, invReport *db.InventoryReportModel) ([]byte, error) {
+	report := NewPDF()
+	row := models.DbDamageToExportRow(damage)
+	property := lease.Property()
+
+	report.AddCenteredTitle("Damage Claim", H1)
+	report.AddText("Damage ID: " + damage.ID)
+	report.AddText("Date: " + damage.CreatedAt.Format("2006-01-02"))
+
+	report.Ln(5)
+	report.AddTitle("Property", H2)
+	report.AddText("Name: " + property.Name)
+	address := property.Address
+	if apartment, ok := property.ApartmentNumber(); ok {
+		address += ", apt. " + apartment
+	}
+	report.AddText("Address: " + address)
+	report.AddText(property.PostalCode + " " + property.City + ", " + property.Country)
+
+	report.Ln(5)
+	report.AddTitle("Lease", H2)
+	report.Add2Texts("Owner: "+property.Owner().Name(), "Email: "+property.Owner().Email)
+	report.Add2Texts("Tenant: "+lease.Tenant().Name(), "Email: "+lease.Tenant().Email)
+	leaseEndDate, ok := lease.EndDate()
+	if ok {
+		report.Add2Texts("Start date: "+lease.StartDate.Format("2006-01-02"), "End date: "+leaseEndDate.Format("2006-01-02"))
+	} else {
+		report.Add2Texts("Start date: "+lease.StartDate.Format("2006-01-02"), "End date: None")
+	}
+
+	report.Ln(5)
+	report.AddTitle("Damage", H2)
+	report.Add2Texts("Room: "+row.RoomName, "Furniture: "+orDash(row.FurnitureName))
+	report.Add2Texts("Priority: "+row.Priority, "Status: "+row.FixStatus)
+	report.Add2Texts("Fix planned on: "+orDash(row.FixPlannedAt), "Fixed on: "+orDash(row.FixedAt))
+	if row.EstimatedCost != "" || row.ActualCost != "" {
+		report.Add2Texts("Estimated cost: "+orDash(row.EstimatedCost), "Actual cost: "+orDash(row.ActualCost))
+		report.AddText("Paid by: " + orDash(row.Payer))
+	}
+	if contractor, ok := damage.Contractor(); ok {
+		report.Add2Texts("Contractor: "+contractor.Name, "Trade: "+string(contractor.Trade))
+	}
+	report.AddMultiLineText("Description: " + damage.Comment)
+	report.AddText("Pictures: " + strconv.Itoa(len(damage.Pictures())))
+
+	addTimeline(&report, damage)
+	addInventoryStates(&report, damage, invReport)
+
+	bytes, err := report.Output()
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return bytes, nil
+}
+
+func addTimeline(report *PDF, damage db.DamageModel) {
+	report.Ln(5)
+	report.AddTitle("Timeline", H2)
+	report.AddText(damage.CreatedAt.Format("2006-01-02 15:04") + " - reported by " + damage.Lease().Tenant().Name())
+	for _, event := range damage.Events() {
+		if event.Field == db.DamageFieldCreated {
+			continue
+		}
+		line := event.CreatedAt.Format("2006-01-02 15:04") + " - " + event.Actor().Name() + " (" + string(event.ActorRole) + "): " + event.Field
+		if newValue, ok := event.NewValue(); ok {
+			line += " " + newValue
+		}
+		report.AddMultiLineText(line)
+	}
+}
+
+func addInventoryStates(report *PDF, damage db.DamageModel, invReport *db.InventoryReportModel) {
+	report.Ln(5)
+	report.AddTitle("Inventory", H2)
+	if invReport == nil {
+		report.AddText("No inventory report")
+		return
+	}
+	report.AddText("Report of " + invReport.Date.Format("2006-01-02") + " (" + string(invReport.Type) + ")")
+	if roomState := invReport.RoomState(damage.RoomID); roomState != nil {
+		report.Add2Texts("Room state: "+string(roomState.State), "Cleanliness: "+string(roomState.Cleanliness))
+		report.AddMultiLineText("Note: " + roomState.Note)
+	} else {
+		report.AddText("Room not inspected")
+	}
+	if furnitureID, ok := damage.FurnitureID(); ok {
+		if furnitureState := invReport.FurnitureState(furnitureID); furnitureState != nil {
+			report.Add2Texts("Furniture state: "+string(furnitureState.State), "Cleanliness: "+string(furnitureState.Cleanliness))
+			report.AddMultiLineText("Note: " + furnitureState.Note)
+		}
+	}
+}
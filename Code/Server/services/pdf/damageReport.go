@@ -0,0 +1,58 @@
+package pdf
+
+import (
+	"log"
+	"strconv"
+	"time"
+
+	"keyz/backend/models"
+	"keyz/backend/prisma/db"
+)
+
+// NewDamageReportPDF lists the given damages with their details and picture thumbnails.
+func NewDamageReportPDF(title string, damages []db.DamageModel) ([]byte, error) {
+	report := NewPDF()
+
+	report.AddCenteredTitle("Damage Report", H1)
+	report.AddText(title)
+	report.AddText("Date: " + time.Now().Format("2006-01-02"))
+	report.AddText("Damages: " + strconv.Itoa(len(damages)))
+
+	for _, damage := range damages {
+		row := models.DbDamageToExportRow(damage)
+		location := row.RoomName
+		if row.FurnitureName != "" {
+			location += " - " + row.FurnitureName
+		}
+
+		report.Ln(5)
+		report.AddLine()
+		report.AddTitle(location, H3)
+		report.Add2Texts("Tenant: "+row.TenantName, "Priority: "+row.Priority)
+		report.Add2Texts("Reported on: "+row.CreatedAt, "Status: "+row.FixStatus)
+		report.Add2Texts("Fix planned on: "+orDash(row.FixPlannedAt), "Fixed on: "+orDash(row.FixedAt))
+		if row.EstimatedCost != "" || row.ActualCost != "" {
+			report.Add2Texts("Estimated cost: "+orDash(row.EstimatedCost), "Actual cost: "+orDash(row.ActualCost))
+			report.AddText("Paid by: " + orDash(row.Payer))
+		}
+		report.AddMultiLineText("Comment: " + row.Comment)
+		report.Ln(5)
+		if len(damage.Pictures()) > 0 {
+			report.AddThumbnails(damage.Pictures())
+		}
+	}
+
+	bytes, err := report.Output()
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return bytes, nil
+}
+
+func orDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
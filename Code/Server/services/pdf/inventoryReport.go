@@ -26,7 +26,8 @@ func NewInventoryReportPDF(invReport db.InventoryReportModel, lease db.LeaseMode
 	} else {
 		report.Add2Texts("Start date: "+lease.StartDate.Format("2006-01-02"), "End date: None")
 	}
-	report.AddText("Rent: " + strconv.FormatFloat(lease.Property().RentalPricePerMonth, 'f', 2, 64) + "€")
+	report.AddText("Rent: " + strconv.FormatFloat(lease.RentalPricePerMonth, 'f', 2, 64) + "€")
+	addGuarantors(&report, lease)
 
 	addRooms(&report, invReport)
 
@@ -38,6 +39,15 @@ func NewInventoryReportPDF(invReport db.InventoryReportModel, lease db.LeaseMode
 	return bytes, nil
 }
 
+func addGuarantors(report *PDF, lease db.LeaseModel) {
+	for _, guarantor := range lease.Guarantors() {
+		report.Add2Texts("Guarantor: "+guarantor.Name()+" ("+string(guarantor.Type)+")", "Email: "+guarantor.Email)
+		if amountCap, ok := guarantor.AmountCap(); ok {
+			report.AddText("Amount cap: " + strconv.FormatFloat(amountCap, 'f', 2, 64) + "€")
+		}
+	}
+}
+
 func addRooms(report *PDF, invReport db.InventoryReportModel) {
 	report.Ln(5)
 	report.AddTitle("Rooms:", H2)
@@ -2,10 +2,13 @@ package pdf
 
 import (
 	"bytes"
+	"image/png"
 	"io"
 
 	"github.com/go-pdf/fpdf"
+	"golang.org/x/image/webp"
 	"keyz/backend/prisma/db"
+	"keyz/backend/services/storage"
 	"keyz/backend/utils"
 )
 
@@ -113,19 +116,51 @@ func (irp *PDF) AddLine() {
 }
 
 func (irp *PDF) AddImages(images []db.ImageModel) {
+	irp.addImages(images, 69.35)
+}
+
+// AddThumbnails adds the images in a smaller size, to give an overview of many pictures.
+func (irp *PDF) AddThumbnails(images []db.ImageModel) {
+	irp.addImages(images, 25)
+}
+
+// readImage returns the content of an image and its type, WebP images being converted to PNG as fpdf cannot decode them.
+func readImage(picture db.ImageModel) ([]byte, string, error) {
+	data, err := storage.Load(picture.InnerImage.Data, picture.InnerImage.StorageKey)
+	if err != nil {
+		return nil, "", err
+	}
+	if picture.Type != db.ImageTypeWebp {
+		return data, string(picture.Type), nil
+	}
+	img, err := webp.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", err
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, "", err
+	}
+	return buf.Bytes(), string(db.ImageTypePng), nil
+}
+
+func (irp *PDF) addImages(images []db.ImageModel, imageHeight float64) {
 	docW, docH := irp.pdf.GetPageSize()
 	marginL, _, marginR, marginB := irp.pdf.GetMargins()
 
 	maxWidth := docW - marginR
-	imageHeight := 69.35
 	currentX := irp.pdf.GetX()
 	currentY := irp.pdf.GetY()
 	for _, picture := range images {
+		data, imageType, err := readImage(picture)
+		if err != nil {
+			continue
+		}
 		imageOptions := fpdf.ImageOptions{
 			ReadDpi:   true,
-			ImageType: string(picture.Type),
+			ImageType: imageType,
 		}
-		info := irp.pdf.RegisterImageOptionsReader(picture.ID, imageOptions, bytes.NewReader(picture.Data))
+		info := irp.pdf.RegisterImageOptionsReader(picture.ID, imageOptions, bytes.NewReader(data))
 		if info == nil {
 			continue
 		}
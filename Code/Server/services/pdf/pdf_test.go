@@ -1,10 +1,13 @@
 package pdf_test
 
 import (
+	"bytes"
+	"encoding/base64"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"keyz/backend/prisma/db"
 	"keyz/backend/services/pdf"
 )
 
@@ -25,6 +28,7 @@ func TestPDF(t *testing.T) {
 		p.AddMultiLineText("Test")
 		p.AddLine()
 		p.AddImages(nil)
+		p.AddThumbnails(nil)
 	})
 
 	t.Run("Output", func(t *testing.T) {
@@ -33,3 +37,86 @@ func TestPDF(t *testing.T) {
 		assert.NotEmpty(t, output)
 	})
 }
+
+func TestPDF_AddImagesWebP(t *testing.T) {
+	pdf.Test = true
+	p := pdf.NewPDF()
+	data, err := base64.StdEncoding.DecodeString("UklGRhoAAABXRUJQVlA4TA0AAAAvAAAAEAcQERGIiP4HAA==") // 1x1 lossless WebP
+	require.NoError(t, err)
+
+	p.AddImages([]db.ImageModel{{InnerImage: db.InnerImage{ID: "1", Type: db.ImageTypeWebp, Data: &data}}})
+	output, err := p.Output()
+	require.NoError(t, err)
+	assert.True(t, bytes.Contains(output, []byte("/Subtype /Image")))
+}
+
+func TestNewDamageReportPDF(t *testing.T) {
+	pdf.Test = true
+	cost := 120.5
+	damages := []db.DamageModel{{
+		InnerDamage: db.InnerDamage{
+			ID:            "1",
+			Comment:       "Leaking tap",
+			Priority:      db.PriorityHigh,
+			EstimatedCost: &cost,
+		},
+		RelationsDamage: db.RelationsDamage{
+			Lease: &db.LeaseModel{
+				RelationsLease: db.RelationsLease{
+					Tenant: &db.UserModel{InnerUser: db.InnerUser{Firstname: "John", Lastname: "Doe"}},
+				},
+			},
+			Room:     &db.RoomModel{InnerRoom: db.InnerRoom{Name: "Kitchen"}},
+			Pictures: []db.ImageModel{},
+		},
+	}}
+
+	output, err := pdf.NewDamageReportPDF("Property: Test", damages)
+	require.NoError(t, err)
+	assert.NotEmpty(t, output)
+}
+
+func TestNewDamageClaimPDF(t *testing.T) {
+	pdf.Test = true
+	tenant := &db.UserModel{InnerUser: db.InnerUser{Firstname: "John", Lastname: "Doe", Email: "john@example.com"}}
+	lease := db.LeaseModel{
+		RelationsLease: db.RelationsLease{
+			Tenant: tenant,
+			Property: &db.PropertyModel{
+				InnerProperty: db.InnerProperty{Name: "Test", Address: "1 Main Street", City: "Paris", PostalCode: "75000", Country: "France"},
+				RelationsProperty: db.RelationsProperty{
+					Owner: &db.UserModel{InnerUser: db.InnerUser{Firstname: "Jane", Lastname: "Doe", Email: "jane@example.com"}},
+				},
+			},
+		},
+	}
+	newValue := "urgent"
+	damage := db.DamageModel{
+		InnerDamage: db.InnerDamage{ID: "1", RoomID: "1", Comment: "Water leak", Priority: db.PriorityHigh},
+		RelationsDamage: db.RelationsDamage{
+			Lease:    &lease,
+			Room:     &db.RoomModel{InnerRoom: db.InnerRoom{Name: "Kitchen"}},
+			Pictures: []db.ImageModel{},
+			Events: []db.DamageEventModel{{
+				InnerDamageEvent:     db.InnerDamageEvent{Field: db.DamageFieldPriority, NewValue: &newValue, ActorRole: db.RoleTenant},
+				RelationsDamageEvent: db.RelationsDamageEvent{Actor: tenant},
+			}},
+		},
+	}
+	invReport := &db.InventoryReportModel{
+		InnerInventoryReport: db.InnerInventoryReport{Type: db.ReportTypeStart},
+		RelationsInventoryReport: db.RelationsInventoryReport{
+			RoomStates: []db.RoomStateModel{
+				{InnerRoomState: db.InnerRoomState{RoomID: "1", State: db.StateGood, Cleanliness: db.CleanlinessClean}},
+			},
+		},
+	}
+
+	output, err := pdf.NewDamageClaimPDF(damage, lease, invReport)
+	require.NoError(t, err)
+	assert.NotEmpty(t, output)
+
+	output, err = pdf.NewDamageClaimPDF(damage, lease, nil)
+	require.NoError(t, err)
+	assert.NotEmpty(t, output)
+}
@@ -0,0 +1,21 @@
+package scheduler
+
+import (
+	"log"
+	"time"
+
+	"keyz/backend/services/brevo"
+	"keyz/backend/services/database"
+)
+
+func sendAppointmentReminders(now time.Time) {
+	for _, appointment := range database.GetAppointmentsToRemind(now) {
+		res, err := brevo.SendAppointmentReminder(appointment.Damage(), appointment)
+		if err != nil {
+			log.Println(res, err.Error())
+			continue
+		}
+		// Marked once sent, so that a failed reminder is sent again at the next run
+		database.MarkAppointmentReminded(appointment.ID)
+	}
+}
@@ -0,0 +1,78 @@
+package scheduler_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"keyz/backend/prisma/db"
+	"keyz/backend/services"
+	"keyz/backend/services/database"
+	"keyz/backend/services/scheduler"
+)
+
+func BuildTestAppointment(id string, startAt time.Time) db.DamageAppointmentModel {
+	lease := BuildTestLease("1", db.LeaseStatusActive, startAt.Add(365*24*time.Hour))
+	return db.DamageAppointmentModel{
+		InnerDamageAppointment: db.InnerDamageAppointment{
+			ID:              id,
+			DamageID:        "1",
+			StartAt:         startAt,
+			DurationMinutes: 90,
+			Status:          db.AppointmentStatusConfirmed,
+			ProposedBy:      db.AppointmentPartyOwner,
+			CreatedAt:       time.Now(),
+		},
+		RelationsDamageAppointment: db.RelationsDamageAppointment{
+			Damage: &db.DamageModel{
+				InnerDamage: db.InnerDamage{
+					ID:      "1",
+					LeaseID: "1",
+					RoomID:  "1",
+					Comment: "Leaking tap",
+				},
+				RelationsDamage: db.RelationsDamage{
+					Lease: &lease,
+					Room: &db.RoomModel{
+						InnerRoom: db.InnerRoom{
+							ID:   "1",
+							Name: "Kitchen",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestSendAppointmentReminders(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+	emails := RecordEmails(t, false)
+
+	now := time.Now()
+	appointment := BuildTestAppointment("1", now.Add(12*time.Hour))
+	m.DamageAppointment.Expect(database.MockGetAppointmentsToRemind(c, now)).ReturnsMany([]db.DamageAppointmentModel{appointment})
+	m.DamageAppointment.Expect(database.MockMarkAppointmentReminded(c)).Returns(appointment)
+
+	scheduler.SendAppointmentReminders(now)
+
+	require.Len(t, *emails, 1)
+	require.Len(t, (*emails)[0].To, 1)
+	assert.Equal(t, "tenant@example.com", (*emails)[0].To[0].Email)
+}
+
+func TestSendAppointmentReminders_EmailFailure(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+	emails := RecordEmails(t, true)
+
+	now := time.Now()
+	appointment := BuildTestAppointment("1", now.Add(12*time.Hour))
+	m.DamageAppointment.Expect(database.MockGetAppointmentsToRemind(c, now)).ReturnsMany([]db.DamageAppointmentModel{appointment})
+
+	// The appointment is not marked, so that the reminder is sent again at the next run
+	scheduler.SendAppointmentReminders(now)
+	assert.Len(t, *emails, 1)
+}
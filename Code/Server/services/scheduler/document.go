@@ -0,0 +1,23 @@
+package scheduler
+
+import (
+	"time"
+
+	"keyz/backend/prisma/db"
+	"keyz/backend/services/database"
+	"keyz/backend/services/storage"
+)
+
+func purgeDeletedDocuments(now time.Time) {
+	before := now.Add(-db.DocumentRetentionPeriod)
+	documents := database.GetDocumentsDeletedBefore(before)
+	if len(documents) == 0 {
+		return
+	}
+
+	// Rows are deleted first so that none is left pointing to a removed blob
+	database.PurgeDocuments(before)
+	for _, doc := range documents {
+		storage.Remove(doc.InnerDocument.StorageKey)
+	}
+}
@@ -0,0 +1,81 @@
+package scheduler_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"keyz/backend/prisma/db"
+	"keyz/backend/services"
+	"keyz/backend/services/database"
+	"keyz/backend/services/scheduler"
+	"keyz/backend/services/storage"
+	"keyz/backend/utils"
+)
+
+func BuildTestDeletedDocument(t *testing.T, now time.Time) db.DocumentModel {
+	local, err := storage.NewLocal(t.TempDir())
+	require.NoError(t, err)
+	storage.Client = local
+	t.Cleanup(func() { storage.Client = nil })
+
+	return db.DocumentModel{
+		InnerDocument: db.InnerDocument{
+			ID:         "1",
+			Name:       "Document",
+			Type:       db.DocTypePdf,
+			StorageKey: storage.Offload("documents", []byte("data"), "application/pdf"),
+			LeaseID:    "1",
+			DeletedAt:  utils.Ptr(now.Add(-db.DocumentRetentionPeriod - time.Hour)),
+			CreatedAt:  now.Add(-db.DocumentRetentionPeriod - time.Hour),
+		},
+	}
+}
+
+func TestPurgeDeletedDocuments(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	now := time.Now()
+	before := now.Add(-db.DocumentRetentionPeriod)
+	document := BuildTestDeletedDocument(t, now)
+	m.Document.Expect(database.MockGetDocumentsDeletedBefore(c, before)).ReturnsMany([]db.DocumentModel{document})
+	m.Document.Expect(database.MockPurgeDocuments(c, before)).Returns(document)
+
+	scheduler.PurgeDeletedDocuments(now)
+
+	_, err := storage.Load(nil, document.InnerDocument.StorageKey)
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+}
+
+func TestPurgeDeletedDocuments_NoDocuments(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	now := time.Now()
+	m.Document.Expect(database.MockGetDocumentsDeletedBefore(c, now.Add(-db.DocumentRetentionPeriod))).ReturnsMany([]db.DocumentModel{})
+
+	scheduler.PurgeDeletedDocuments(now)
+}
+
+func TestPurgeDeletedDocuments_NoConnection(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+
+	now := time.Now()
+	before := now.Add(-db.DocumentRetentionPeriod)
+	document := BuildTestDeletedDocument(t, now)
+	m.Document.Expect(database.MockGetDocumentsDeletedBefore(c, before)).ReturnsMany([]db.DocumentModel{document})
+	m.Document.Expect(database.MockPurgeDocuments(c, before)).Errors(errors.New("connection failed"))
+
+	assert.Panics(t, func() {
+		scheduler.PurgeDeletedDocuments(now)
+	})
+
+	// The blob is kept as long as its row exists
+	data, err := storage.Load(nil, document.InnerDocument.StorageKey)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("data"), data)
+}
@@ -0,0 +1,6 @@
+package scheduler
+
+var UpdateLeaseStatuses = updateLeaseStatuses
+var SendInviteReminders = sendInviteReminders
+var SendAppointmentReminders = sendAppointmentReminders
+var PurgeDeletedDocuments = purgeDeletedDocuments
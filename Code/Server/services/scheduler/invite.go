@@ -0,0 +1,30 @@
+package scheduler
+
+import (
+	"log"
+	"time"
+
+	"keyz/backend/services/brevo"
+	"keyz/backend/services/database"
+	"keyz/backend/utils"
+)
+
+func sendInviteReminders(now time.Time) {
+	for _, invite := range database.GetPendingLeaseInvites(now) {
+		if !invite.NeedsReminder(now) {
+			continue
+		}
+
+		// Only the hash of the token is stored, so a new link is generated for each reminder
+		token := utils.GenerateToken()
+		user := database.GetUserByEmail(invite.TenantEmail)
+		res, err := brevo.SendEmailInviteReminder(invite, token, user != nil)
+		if err != nil {
+			log.Println(res, err.Error())
+			continue
+		}
+		// The token is replaced once the email is sent, so that a failed reminder is sent again at the next run
+		// and the link of the previous email keeps working meanwhile
+		database.IncrementLeaseInviteReminders(invite.ID, utils.HashToken(token))
+	}
+}
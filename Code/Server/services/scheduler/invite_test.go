@@ -0,0 +1,109 @@
+package scheduler_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"keyz/backend/prisma/db"
+	"keyz/backend/services"
+	"keyz/backend/services/database"
+	"keyz/backend/services/scheduler"
+	"keyz/backend/utils"
+)
+
+func BuildTestLeaseInvite(id string, expiresAt time.Time, remindersSent int) db.LeaseInviteModel {
+	return db.LeaseInviteModel{
+		InnerLeaseInvite: db.InnerLeaseInvite{
+			ID:            id,
+			PropertyID:    "1",
+			TenantEmail:   "test@example.com",
+			TokenHash:     utils.HashToken(id),
+			CreatedAt:     time.Now(),
+			StartDate:     time.Now(),
+			ExpiresAt:     expiresAt,
+			RemindersSent: remindersSent,
+		},
+		RelationsLeaseInvite: db.RelationsLeaseInvite{
+			Property: &db.PropertyModel{
+				RelationsProperty: db.RelationsProperty{
+					Owner: &db.UserModel{
+						InnerUser: db.InnerUser{
+							Firstname: "Jane",
+							Lastname:  "Doe",
+							Email:     "owner@example.com",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// mockInviteToken makes the reminders send the token "1".
+func mockInviteToken(t *testing.T) {
+	generate := utils.GenerateToken
+	utils.GenerateToken = func() string { return "1" }
+	t.Cleanup(func() { utils.GenerateToken = generate })
+}
+
+func TestSendInviteReminders(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+	emails := RecordEmails(t, false)
+	mockInviteToken(t)
+	t.Setenv("WEB_PUBLIC_URL", "https://keyz-app.fr")
+
+	now := time.Now()
+	due := BuildTestLeaseInvite("1", now.Add(24*time.Hour), 1)
+	notDue := BuildTestLeaseInvite("2", now.Add(10*24*time.Hour), 0)
+	allSent := BuildTestLeaseInvite("3", now.Add(time.Hour), len(db.LeaseInviteReminders))
+	m.LeaseInvite.Expect(database.MockGetPendingLeaseInvites(c, now)).ReturnsMany([]db.LeaseInviteModel{due, notDue, allSent})
+	m.User.Expect(database.MockGetUserByEmail(c)).Errors(db.ErrNotFound)
+	m.LeaseInvite.Expect(database.MockIncrementLeaseInviteReminders(c, utils.HashToken("1"))).Returns(due)
+
+	scheduler.SendInviteReminders(now)
+
+	require.Len(t, *emails, 1)
+	email := (*emails)[0]
+	require.Len(t, email.To, 1)
+	assert.Equal(t, "test@example.com", email.To[0].Email)
+	assert.Equal(t, "Reminder: your invite to join a property on Keyz expires soon", email.Subject)
+	assert.Equal(t, "https://keyz-app.fr/register/invite/1", email.Params["inviteLink"])
+}
+
+func TestSendInviteReminders_ExistingUser(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+	emails := RecordEmails(t, false)
+	mockInviteToken(t)
+	t.Setenv("WEB_PUBLIC_URL", "https://keyz-app.fr")
+
+	now := time.Now()
+	invite := BuildTestLeaseInvite("1", now.Add(24*time.Hour), 1)
+	m.LeaseInvite.Expect(database.MockGetPendingLeaseInvites(c, now)).ReturnsMany([]db.LeaseInviteModel{invite})
+	m.User.Expect(database.MockGetUserByEmail(c)).Returns(db.UserModel{InnerUser: db.InnerUser{ID: "1", Email: invite.TenantEmail}})
+	m.LeaseInvite.Expect(database.MockIncrementLeaseInviteReminders(c, utils.HashToken("1"))).Returns(invite)
+
+	scheduler.SendInviteReminders(now)
+
+	require.Len(t, *emails, 1)
+	assert.Equal(t, "https://keyz-app.fr/login/invite/1", (*emails)[0].Params["inviteLink"])
+}
+
+func TestSendInviteReminders_EmailFailure(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+	emails := RecordEmails(t, true)
+	mockInviteToken(t)
+
+	now := time.Now()
+	invite := BuildTestLeaseInvite("1", now.Add(24*time.Hour), 1)
+	m.LeaseInvite.Expect(database.MockGetPendingLeaseInvites(c, now)).ReturnsMany([]db.LeaseInviteModel{invite})
+	m.User.Expect(database.MockGetUserByEmail(c)).Errors(db.ErrNotFound)
+
+	// Neither the reminder nor its token are stored, so that it is sent again at the next run
+	scheduler.SendInviteReminders(now)
+	assert.Len(t, *emails, 1)
+}
@@ -0,0 +1,26 @@
+package scheduler
+
+import (
+	"log"
+	"time"
+
+	"keyz/backend/services/brevo"
+	"keyz/backend/services/database"
+)
+
+func updateLeaseStatuses(now time.Time) {
+	for _, lease := range database.GetLeasesNotEnded() {
+		status := lease.ExpectedStatus(now)
+		if status == lease.Status {
+			continue
+		}
+
+		database.UpdateLeaseStatus(lease.ID, status)
+		lease.Status = status
+
+		res, err := brevo.SendLeaseStatusUpdate(lease)
+		if err != nil {
+			log.Println(res, err.Error())
+		}
+	}
+}
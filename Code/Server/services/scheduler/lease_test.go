@@ -0,0 +1,119 @@
+package scheduler_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"keyz/backend/prisma/db"
+	"keyz/backend/services"
+	"keyz/backend/services/database"
+	"keyz/backend/services/scheduler"
+	"keyz/backend/utils"
+)
+
+func BuildTestLease(id string, status db.LeaseStatus, end time.Time) db.LeaseModel {
+	return db.LeaseModel{
+		InnerLease: db.InnerLease{
+			ID:         id,
+			PropertyID: "1",
+			TenantID:   "1",
+			Status:     status,
+			Active:     true,
+			CreatedAt:  time.Now(),
+			StartDate:  end.Add(-365 * 24 * time.Hour),
+			EndDate:    utils.Ptr(end),
+		},
+		RelationsLease: db.RelationsLease{
+			Tenant: &db.UserModel{
+				InnerUser: db.InnerUser{
+					ID:        "1",
+					Firstname: "John",
+					Lastname:  "Doe",
+					Email:     "tenant@example.com",
+				},
+			},
+			Property: &db.PropertyModel{
+				InnerProperty: db.InnerProperty{
+					ID:      "1",
+					Name:    "Test Property",
+					OwnerID: "2",
+				},
+				RelationsProperty: db.RelationsProperty{
+					Owner: &db.UserModel{
+						InnerUser: db.InnerUser{
+							ID:        "2",
+							Firstname: "Jane",
+							Lastname:  "Doe",
+							Email:     "owner@example.com",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestUpdateLeaseStatuses(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+	emails := RecordEmails(t, false)
+
+	now := time.Now()
+	ending := BuildTestLease("1", db.LeaseStatusActive, now.Add(10*24*time.Hour))
+	unchanged := BuildTestLease("2", db.LeaseStatusActive, now.Add(60*24*time.Hour))
+	m.Lease.Expect(database.MockGetLeasesNotEnded(c)).ReturnsMany([]db.LeaseModel{ending, unchanged})
+	m.Lease.Expect(database.MockUpdateLeaseStatus(c, db.LeaseStatusEnding)).Returns(ending)
+
+	scheduler.UpdateLeaseStatuses(now)
+
+	require.Len(t, *emails, 1)
+	email := (*emails)[0]
+	require.Len(t, email.To, 1)
+	assert.Equal(t, "tenant@example.com", email.To[0].Email)
+	assert.Equal(t, "The lease of Test Property is now ending", email.Subject)
+}
+
+func TestUpdateLeaseStatuses_Ended(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+	emails := RecordEmails(t, false)
+
+	now := time.Now()
+	lease := BuildTestLease("1", db.LeaseStatusEnding, now)
+	m.Lease.Expect(database.MockGetLeasesNotEnded(c)).ReturnsMany([]db.LeaseModel{lease})
+	m.Lease.Expect(database.MockUpdateLeaseStatus(c, db.LeaseStatusEnded)).Returns(lease)
+
+	scheduler.UpdateLeaseStatuses(now)
+
+	require.Len(t, *emails, 1)
+	assert.Equal(t, "The lease of Test Property is now ended", (*emails)[0].Subject)
+}
+
+func TestUpdateLeaseStatuses_EmailFailure(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+	emails := RecordEmails(t, true)
+
+	now := time.Now()
+	lease := BuildTestLease("1", db.LeaseStatusActive, now.Add(10*24*time.Hour))
+	m.Lease.Expect(database.MockGetLeasesNotEnded(c)).ReturnsMany([]db.LeaseModel{lease})
+	m.Lease.Expect(database.MockUpdateLeaseStatus(c, db.LeaseStatusEnding)).Returns(lease)
+
+	assert.NotPanics(t, func() {
+		scheduler.UpdateLeaseStatuses(now)
+	})
+	assert.Len(t, *emails, 1)
+}
+
+func TestUpdateLeaseStatuses_NoLeases(t *testing.T) {
+	c, m, ensure := services.ConnectDBTest()
+	defer ensure(t)
+	emails := RecordEmails(t, false)
+
+	m.Lease.Expect(database.MockGetLeasesNotEnded(c)).ReturnsMany([]db.LeaseModel{})
+
+	scheduler.UpdateLeaseStatuses(time.Now())
+	assert.Empty(t, *emails)
+}
@@ -0,0 +1,46 @@
+package scheduler
+
+import (
+	"log"
+	"time"
+)
+
+type job struct {
+	name string
+	run  func(now time.Time)
+}
+
+var jobs = []job{
+	{name: "lease-status", run: updateLeaseStatuses},
+	{name: "invite-reminders", run: sendInviteReminders},
+	{name: "appointment-reminders", run: sendAppointmentReminders},
+	{name: "document-purge", run: purgeDeletedDocuments},
+}
+
+// Start runs every scheduled job once, then again at each interval, in a background goroutine.
+func Start(interval time.Duration) {
+	go func() {
+		runJobs()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			runJobs()
+		}
+	}()
+}
+
+func runJobs() {
+	now := time.Now()
+	for _, j := range jobs {
+		runJob(j, now)
+	}
+}
+
+func runJob(j job, now time.Time) {
+	defer func() {
+		if err := recover(); err != nil {
+			log.Println("Scheduled job", j.name, "failed:", err)
+		}
+	}()
+	j.run(now)
+}
@@ -0,0 +1,49 @@
+package scheduler_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+type sentEmail struct {
+	To []struct {
+		Email string `json:"email"`
+	} `json:"to"`
+	Subject string         `json:"subject"`
+	Params  map[string]any `json:"params"`
+}
+
+// RecordEmails replaces the HTTP transport for the duration of the test, so that the emails sent through Brevo are
+// recorded instead of sent. When fail is true, sending fails.
+func RecordEmails(t *testing.T, fail bool) *[]sentEmail {
+	transport := http.DefaultTransport
+	t.Cleanup(func() { http.DefaultTransport = transport })
+
+	emails := []sentEmail{}
+	http.DefaultTransport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		var email sentEmail
+		if err := json.NewDecoder(req.Body).Decode(&email); err != nil {
+			return nil, err
+		}
+		emails = append(emails, email)
+		if fail {
+			return nil, errors.New("brevo unavailable")
+		}
+		return &http.Response{
+			StatusCode: http.StatusCreated,
+			Header:     http.Header{},
+			Body:       io.NopCloser(strings.NewReader(`{"messageId":"1"}`)),
+		}, nil
+	})
+	return &emails
+}
@@ -0,0 +1,3 @@
+package storage
+
+var SignV4 = signV4
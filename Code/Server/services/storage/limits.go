@@ -0,0 +1,41 @@
+package storage
+
+import (
+	"errors"
+	"os"
+	"strconv"
+)
+
+const megabyte = 1 << 20
+
+// Limits on the size of the uploads and on the storage used by each owner, in bytes.
+var (
+	MaxImageSize    = 10 * megabyte
+	MaxDocumentSize = 25 * megabyte
+	MaxRequestSize  = 100 * megabyte
+	OwnerQuota      = 2048 * megabyte
+)
+
+// LoadLimits configures the limits from the environment, in megabytes:
+// MAX_IMAGE_SIZE_MB, MAX_DOCUMENT_SIZE_MB, MAX_REQUEST_SIZE_MB and OWNER_STORAGE_QUOTA_MB.
+// The limits that are not set keep their default value.
+func LoadLimits() error {
+	limits := map[string]*int{
+		"MAX_IMAGE_SIZE_MB":      &MaxImageSize,
+		"MAX_DOCUMENT_SIZE_MB":   &MaxDocumentSize,
+		"MAX_REQUEST_SIZE_MB":    &MaxRequestSize,
+		"OWNER_STORAGE_QUOTA_MB": &OwnerQuota,
+	}
+	for key, limit := range limits {
+		value := os.Getenv(key)
+		if value == "" {
+			continue
+		}
+		size, err := strconv.Atoi(value)
+		if err != nil || size <= 0 {
+			return errors.New("invalid " + key + " " + value)
+		}
+		*limit = size * megabyte
+	}
+	return nil
+}
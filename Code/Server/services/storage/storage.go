@@ -0,0 +1,106 @@
+package storage
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"log"
+	"os"
+
+	"keyz/backend/utils"
+)
+
+// Storage keeps the content of images and documents out of the database, the rows only keep a key to find it.
+type Storage interface {
+	Put(key string, data []byte, contentType string) error
+	Get(key string) ([]byte, error)
+	// Open returns a reader of a blob and its size, -1 when unknown. The caller closes the reader.
+	Open(key string) (io.ReadCloser, int64, error)
+	Delete(key string) error
+}
+
+// Client is the configured storage. When nil, the blobs are kept in the database.
+var Client Storage
+
+var ErrNotFound = errors.New("blob not found")
+
+// Connect configures the storage from the environment:
+// STORAGE_BACKEND is `database` (default), `local` with STORAGE_LOCAL_PATH,
+// or `s3` with S3_ENDPOINT, S3_REGION, S3_BUCKET, S3_ACCESS_KEY and S3_SECRET_KEY.
+func Connect() error {
+	switch os.Getenv("STORAGE_BACKEND") {
+	case "", "database":
+		Client = nil
+	case "local":
+		local, err := NewLocal(os.Getenv("STORAGE_LOCAL_PATH"))
+		if err != nil {
+			return err
+		}
+		Client = local
+	case "s3":
+		Client = NewS3(S3Config{
+			Endpoint:  os.Getenv("S3_ENDPOINT"),
+			Region:    os.Getenv("S3_REGION"),
+			Bucket:    os.Getenv("S3_BUCKET"),
+			AccessKey: os.Getenv("S3_ACCESS_KEY"),
+			SecretKey: os.Getenv("S3_SECRET_KEY"),
+		})
+	default:
+		return errors.New("unknown storage backend " + os.Getenv("STORAGE_BACKEND"))
+	}
+	return nil
+}
+
+// NewKey returns a unique key for a new blob in the given folder.
+func NewKey(folder string) string {
+	return folder + "/" + utils.GenerateToken()
+}
+
+// Offload puts the data in the storage and returns its storage key.
+// When blobs are kept in the database, no key is returned and the data must be kept in the row.
+// The blob is stored before its row is created, so the caller removes it when the row cannot be created.
+func Offload(folder string, data []byte, contentType string) *string {
+	if Client == nil {
+		return nil
+	}
+	key := NewKey(folder)
+	if err := Client.Put(key, data, contentType); err != nil {
+		panic(err)
+	}
+	return &key
+}
+
+// Load returns the content of a blob, from the storage if it has a key or else from the database.
+// The error is ErrNotFound when neither has the content.
+func Load(data *[]byte, key *string) ([]byte, error) {
+	if key == nil || Client == nil {
+		if data == nil {
+			return nil, ErrNotFound
+		}
+		return *data, nil
+	}
+	return Client.Get(*key)
+}
+
+// Open returns a reader of the content of a blob and its size, from the storage if it has a key or else from the
+// database, so that it can be streamed without reading it all first. The caller closes the reader.
+// The error is ErrNotFound when neither has the content.
+func Open(data *[]byte, key *string) (io.ReadCloser, int64, error) {
+	if key == nil || Client == nil {
+		if data == nil {
+			return nil, 0, ErrNotFound
+		}
+		return io.NopCloser(bytes.NewReader(*data)), int64(len(*data)), nil
+	}
+	return Client.Open(*key)
+}
+
+// Remove deletes a blob from the storage, if it has a key.
+func Remove(key *string) {
+	if key == nil || Client == nil {
+		return
+	}
+	if err := Client.Delete(*key); err != nil {
+		log.Println("failed to delete blob", *key, err)
+	}
+}
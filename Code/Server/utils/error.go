@@ -37,10 +37,15 @@ const (
 	InvReportMustBeCurrentLease  ErrorCode = "inventory-report-must-be-linked-to-current-lease"
 	NoLeaseInvite                ErrorCode = "no-pending-lease"
 	DocumentNotFound             ErrorCode = "document-not-found"
+	DocumentNotLatestVersion     ErrorCode = "document-not-latest-version"
 	DamageNotFound               ErrorCode = "damage-not-found"
 	DamageAlreadyExists          ErrorCode = "damage-already-exists"
 	CannotUpdateFixedDamage      ErrorCode = "cannot-update-fixed-damage"
 	DamageAlreadyFixed           ErrorCode = "damage-already-fixed"
+	DamageNotFixed               ErrorCode = "damage-not-fixed"
+	DamageClosed                 ErrorCode = "damage-closed"
+	DamageAlreadyRead            ErrorCode = "damage-already-read"
+	NoFixToReject                ErrorCode = "no-fix-to-reject"
 	FailedLinkImage              ErrorCode = "failed-to-link-image"
 	BadBase64OrUnsupportedType   ErrorCode = "bad-base64-string-or-unsupported-type"
 	PropertyPictureNotFound      ErrorCode = "property-picture-not-found"
@@ -57,6 +62,23 @@ const (
 	FurnitureStateAlreadyExists  ErrorCode = "furniture-state-already-exists"
 	ErrorRequestChatGPTAPI       ErrorCode = "error-request-chatgpt-api"
 	FailedSendEmail              ErrorCode = "failed-send-email"
+	LeaseAlreadyEnded            ErrorCode = "lease-already-ended"
+	DepartureNoticeNotFound      ErrorCode = "departure-notice-not-found"
+	DepartureNoticeAccepted      ErrorCode = "departure-notice-already-accepted"
+	GuarantorNotFound            ErrorCode = "guarantor-not-found"
+	InviteExpired                ErrorCode = "invite-expired"
+	LeaseTermsNotAccepted        ErrorCode = "lease-terms-not-accepted"
+	ContractorNotFound           ErrorCode = "contractor-not-found"
+	ContractorJobNotFound        ErrorCode = "contractor-job-not-found"
+	AppointmentNotFound          ErrorCode = "appointment-not-found"
+	AppointmentNotProposed       ErrorCode = "appointment-not-proposed"
+	AppointmentInPast            ErrorCode = "appointment-in-past"
+	CannotConfirmOwnAppointment  ErrorCode = "cannot-confirm-own-appointment"
+	FailedCreateExport           ErrorCode = "failed-to-create-export"
+	FileTooLarge                 ErrorCode = "file-too-large"
+	StorageQuotaExceeded         ErrorCode = "storage-quota-exceeded"
+	FileNotFound                 ErrorCode = "file-not-found"
+	FailedLoadFile               ErrorCode = "failed-to-load-file"
 )
 
 type Error struct {
@@ -0,0 +1,66 @@
+package utils
+
+import (
+	"strings"
+	"time"
+)
+
+const icsTimeFormat = "20060102T150405Z"
+
+// CalendarEvent is a single event of an iCalendar (.ics) file, as attached to emails.
+type CalendarEvent struct {
+	UID         string
+	Summary     string
+	Description string
+	Location    string
+	Start       time.Time
+	Duration    time.Duration
+	CreatedAt   time.Time
+}
+
+var icsEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+
+// foldICSLine splits lines longer than 75 octets, continuation lines starting with a space (RFC 5545 3.1).
+func foldICSLine(line string) string {
+	var b strings.Builder
+	limit := 75
+	for len(line) > limit {
+		cut := limit
+		// don't split a multibyte character
+		for cut > 0 && line[cut]&0xC0 == 0x80 {
+			cut--
+		}
+		b.WriteString(line[:cut] + "\r\n ")
+		line = line[cut:]
+		// the leading space of continuation lines counts in their length
+		limit = 74
+	}
+	b.WriteString(line)
+	return b.String()
+}
+
+// ICS returns the content of an .ics file holding the event.
+func (e CalendarEvent) ICS() string {
+	lines := []string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//Keyz//Keyz//EN",
+		"CALSCALE:GREGORIAN",
+		"METHOD:PUBLISH",
+		"BEGIN:VEVENT",
+		"UID:" + e.UID,
+		"DTSTAMP:" + e.CreatedAt.UTC().Format(icsTimeFormat),
+		"DTSTART:" + e.Start.UTC().Format(icsTimeFormat),
+		"DTEND:" + e.Start.Add(e.Duration).UTC().Format(icsTimeFormat),
+		"SUMMARY:" + icsEscaper.Replace(e.Summary),
+		"DESCRIPTION:" + icsEscaper.Replace(e.Description),
+		"LOCATION:" + icsEscaper.Replace(e.Location),
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}
+	var b strings.Builder
+	for _, line := range lines {
+		b.WriteString(foldICSLine(line) + "\r\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,71 @@
+package utils_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"keyz/backend/utils"
+)
+
+func TestCalendarEventICS(t *testing.T) {
+	start := time.Date(2025, time.July, 10, 9, 30, 0, 0, time.FixedZone("CEST", 2*60*60))
+	event := utils.CalendarEvent{
+		UID:         "1@keyz-app.fr",
+		Summary:     "Repair: Kitchen, sink",
+		Description: "Leaking pipe;\nbring a bucket",
+		Location:    "1 rue de la Paix, 75002 Paris",
+		Start:       start,
+		Duration:    90 * time.Minute,
+		CreatedAt:   start.Add(-24 * time.Hour),
+	}
+
+	ics := event.ICS()
+
+	assert.True(t, strings.HasPrefix(ics, "BEGIN:VCALENDAR\r\n"))
+	assert.True(t, strings.HasSuffix(ics, "END:VCALENDAR\r\n"))
+	assert.Contains(t, ics, "UID:1@keyz-app.fr\r\n")
+	assert.Contains(t, ics, "DTSTAMP:20250709T073000Z\r\n")
+	assert.Contains(t, ics, "DTSTART:20250710T073000Z\r\n")
+	assert.Contains(t, ics, "DTEND:20250710T090000Z\r\n")
+	assert.Contains(t, ics, `SUMMARY:Repair: Kitchen\, sink`+"\r\n")
+	assert.Contains(t, ics, `DESCRIPTION:Leaking pipe\;\nbring a bucket`+"\r\n")
+	assert.Contains(t, ics, `LOCATION:1 rue de la Paix\, 75002 Paris`+"\r\n")
+}
+
+func TestCalendarEventICS_FoldLongLines(t *testing.T) {
+	event := utils.CalendarEvent{
+		UID:         "1@keyz-app.fr",
+		Description: strings.Repeat("a", 200),
+		Start:       time.Now(),
+		CreatedAt:   time.Now(),
+	}
+
+	ics := event.ICS()
+
+	for _, line := range strings.Split(strings.TrimSuffix(ics, "\r\n"), "\r\n") {
+		assert.LessOrEqual(t, len(line), 75)
+	}
+	unfolded := strings.ReplaceAll(ics, "\r\n ", "")
+	assert.Contains(t, unfolded, "DESCRIPTION:"+strings.Repeat("a", 200)+"\r\n")
+}
+
+func TestCalendarEventICS_FoldMultibyte(t *testing.T) {
+	event := utils.CalendarEvent{
+		UID:         "1@keyz-app.fr",
+		Description: strings.Repeat("é", 100),
+		Start:       time.Now(),
+		CreatedAt:   time.Now(),
+	}
+
+	ics := event.ICS()
+
+	for _, line := range strings.Split(strings.TrimSuffix(ics, "\r\n"), "\r\n") {
+		assert.LessOrEqual(t, len(line), 75)
+		assert.True(t, utf8.ValidString(line))
+	}
+	unfolded := strings.ReplaceAll(ics, "\r\n ", "")
+	assert.Contains(t, unfolded, "DESCRIPTION:"+strings.Repeat("é", 100)+"\r\n")
+}
@@ -0,0 +1,77 @@
+package utils
+
+import (
+	"encoding/base64"
+	"mime"
+	"slices"
+	"strings"
+
+	"github.com/gabriel-vasile/mimetype"
+)
+
+// mimeAliases are media types sent by some clients in place of the registered ones.
+var mimeAliases = map[string]string{
+	"image/jpg":         "image/jpeg",
+	"application/x-pdf": "application/pdf",
+}
+
+// NormalizeMimeType returns the media type without its parameters and with aliases resolved, or "" if it is invalid.
+func NormalizeMimeType(mimeType string) string {
+	mediaType, _, err := mime.ParseMediaType(mimeType)
+	if err != nil {
+		return ""
+	}
+	if alias, ok := mimeAliases[mediaType]; ok {
+		return alias
+	}
+	return mediaType
+}
+
+// ParseDataURI returns the media type and the decoded content of a base64 data URI.
+func ParseDataURI(uri string) (string, []byte, bool) {
+	header, data, ok := strings.Cut(strings.TrimPrefix(uri, "data:"), ",")
+	if !ok || !strings.HasPrefix(uri, "data:") {
+		return "", nil, false
+	}
+	mediaType, ok := strings.CutSuffix(header, ";base64")
+	if !ok {
+		return "", nil, false
+	}
+	decoded, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		return "", nil, false
+	}
+	return NormalizeMimeType(mediaType), decoded, true
+}
+
+// detectMimeTypes returns the media types of a file detected from its content, from the most specific to the least,
+// e.g. text/csv then text/plain.
+func detectMimeTypes(data []byte) []string {
+	var res []string
+	for m := mimetype.Detect(data); m != nil; m = m.Parent() {
+		res = append(res, NormalizeMimeType(m.String()))
+	}
+	return res
+}
+
+// SniffMimeType returns the most specific of the accepted media types detected from the content of a file.
+// A declared media type, when not empty, must be the detected one or one of its parents, so that a file cannot be
+// sent as another type. CSV files cannot always be told apart from plain text, so plain text declared as CSV is CSV.
+func SniffMimeType(data []byte, declared string, accepted []string) (string, bool) {
+	detected := detectMimeTypes(data)
+	if declared != "" {
+		declared = NormalizeMimeType(declared)
+		if declared == "text/csv" && slices.Contains(detected, "text/plain") && !slices.Contains(detected, "text/csv") {
+			detected = append([]string{"text/csv"}, detected...)
+		}
+		if !slices.Contains(detected, declared) {
+			return "", false
+		}
+	}
+	for _, mediaType := range detected {
+		if slices.Contains(accepted, mediaType) {
+			return mediaType, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,110 @@
+package utils_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"keyz/backend/utils"
+)
+
+var (
+	pngData  = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+	jpegData = []byte("\xff\xd8\xff\xe0\x00\x10JFIF\x00")
+	webpData = []byte("RIFF\x24\x00\x00\x00WEBPVP8 ")
+	pdfData  = []byte("%PDF-1.7\n")
+	csvData  = []byte("name,amount\nrent,500\nfees,20\n")
+	txtData  = []byte("Keys are in the mailbox.\n")
+)
+
+// buildZip stores the files uncompressed, as OpenDocument files store their mimetype entry.
+func buildZip(t *testing.T, files ...string) []byte {
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for i := 0; i < len(files); i += 2 {
+		f, err := w.CreateHeader(&zip.FileHeader{Name: files[i], Method: zip.Store})
+		require.NoError(t, err)
+		_, err = f.Write([]byte(files[i+1]))
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func TestNormalizeMimeType(t *testing.T) {
+	assert.Equal(t, "image/jpeg", utils.NormalizeMimeType("image/jpg"))
+	assert.Equal(t, "text/plain", utils.NormalizeMimeType("text/plain; charset=utf-8"))
+	assert.Equal(t, "application/pdf", utils.NormalizeMimeType("application/pdf"))
+	assert.Empty(t, utils.NormalizeMimeType("not a mime type;;"))
+}
+
+func TestParseDataURI(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		mediaType, data, ok := utils.ParseDataURI("data:image/jpg;base64,dGVzdA==")
+		require.True(t, ok)
+		assert.Equal(t, "image/jpeg", mediaType)
+		assert.Equal(t, []byte("test"), data)
+	})
+
+	t.Run("NotBase64", func(t *testing.T) {
+		_, _, ok := utils.ParseDataURI("data:text/plain,test")
+		assert.False(t, ok)
+	})
+
+	t.Run("BadBase64", func(t *testing.T) {
+		_, _, ok := utils.ParseDataURI("data:text/plain;base64,???")
+		assert.False(t, ok)
+	})
+
+	t.Run("NotDataURI", func(t *testing.T) {
+		_, _, ok := utils.ParseDataURI("dGVzdA==")
+		assert.False(t, ok)
+	})
+}
+
+func TestSniffMimeType(t *testing.T) {
+	accepted := []string{
+		"application/pdf",
+		"application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+		"application/vnd.oasis.opendocument.text",
+		"text/plain",
+		"text/csv",
+		"image/png",
+		"image/jpeg",
+		"image/webp",
+	}
+	docx := buildZip(t, "[Content_Types].xml", "<Types/>", "word/document.xml", "<document/>")
+	odt := buildZip(t, "mimetype", "application/vnd.oasis.opendocument.text")
+
+	tests := []struct {
+		name     string
+		data     []byte
+		declared string
+		expected string
+		ok       bool
+	}{
+		{"PNG", pngData, "image/png", "image/png", true},
+		{"JPEGAlias", jpegData, "image/jpg", "image/jpeg", true},
+		{"WebP", webpData, "image/webp", "image/webp", true},
+		{"PDF", pdfData, "application/pdf", "application/pdf", true},
+		{"DOCX", docx, "application/vnd.openxmlformats-officedocument.wordprocessingml.document", "application/vnd.openxmlformats-officedocument.wordprocessingml.document", true},
+		{"ODT", odt, "application/vnd.oasis.opendocument.text", "application/vnd.oasis.opendocument.text", true},
+		{"CSV", csvData, "text/csv", "text/csv", true},
+		{"CSVDeclaredText", csvData, "text/plain", "text/csv", true},
+		{"TextDeclaredCSV", txtData, "text/csv", "text/csv", true},
+		{"Text", txtData, "text/plain", "text/plain", true},
+		{"NoDeclaredType", pdfData, "", "application/pdf", true},
+		{"PNGDeclaredJPEG", pngData, "image/jpeg", "", false},
+		{"TextDeclaredPDF", txtData, "application/pdf", "", false},
+		{"NotAccepted", []byte("GIF89a\x01\x00\x01\x00"), "image/gif", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mediaType, ok := utils.SniffMimeType(tt.data, tt.declared, accepted)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, mediaType)
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package utils
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Paginate trims items fetched with one extra element to the limit. When there is a next page,
+// a Link header pointing to it is set, with the cursor of the last returned item.
+func Paginate[T any](c *gin.Context, items []T, limit int, cursor func(T) string) []T {
+	if limit <= 0 || len(items) <= limit {
+		return items
+	}
+	items = items[:limit]
+
+	query := c.Request.URL.Query()
+	query.Set("cursor", cursor(items[limit-1]))
+	c.Header("Link", fmt.Sprintf(`<%s?%s>; rel="next"`, c.Request.URL.Path, query.Encode()))
+	return items
+}
@@ -0,0 +1,56 @@
+package utils_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"keyz/backend/utils"
+)
+
+func newPaginationContext(target string) (*gin.Context, *httptest.ResponseRecorder) {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, target, nil)
+	return c, w
+}
+
+func TestPaginate_NextPage(t *testing.T) {
+	c, w := newPaginationContext("/v1/items/?limit=2&sort=created_at")
+
+	items := utils.Paginate(c, []int{1, 2, 3}, 2, strconv.Itoa)
+
+	assert.Equal(t, []int{1, 2}, items)
+	assert.Equal(t, `</v1/items/?cursor=2&limit=2&sort=created_at>; rel="next"`, w.Header().Get("Link"))
+}
+
+func TestPaginate_ReplaceCursor(t *testing.T) {
+	c, w := newPaginationContext("/v1/items/?cursor=2&limit=2")
+
+	items := utils.Paginate(c, []int{3, 4, 5}, 2, strconv.Itoa)
+
+	assert.Equal(t, []int{3, 4}, items)
+	assert.Equal(t, `</v1/items/?cursor=4&limit=2>; rel="next"`, w.Header().Get("Link"))
+}
+
+func TestPaginate_LastPage(t *testing.T) {
+	c, w := newPaginationContext("/v1/items/?limit=2")
+
+	items := utils.Paginate(c, []int{1, 2}, 2, strconv.Itoa)
+
+	assert.Equal(t, []int{1, 2}, items)
+	assert.Empty(t, w.Header().Get("Link"))
+}
+
+func TestPaginate_NoLimit(t *testing.T) {
+	c, w := newPaginationContext("/v1/items/")
+
+	items := utils.Paginate(c, []int{1, 2, 3}, 0, strconv.Itoa)
+
+	assert.Equal(t, []int{1, 2, 3}, items)
+	assert.Empty(t, w.Header().Get("Link"))
+}
@@ -2,6 +2,7 @@ package utils
 
 import (
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"golang.org/x/crypto/bcrypt"
@@ -10,6 +11,10 @@ import (
 const Strue = "true"
 const Sfalse = "false"
 
+// Now returns the current time. It's a variable so tests can pin it and expect the timestamps written to the
+// database.
+var Now = time.Now
+
 func GetClaims(c *gin.Context) map[string]string {
 	return c.GetStringMapString("oauth.claims")
 }
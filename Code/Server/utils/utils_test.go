@@ -171,3 +171,17 @@ func TestSanitizeEmail(t *testing.T) {
 		assert.Equal(t, c.expected, result, "input: %q", c.input)
 	}
 }
+
+func TestGenerateToken(t *testing.T) {
+	token1 := utils.GenerateToken()
+	token2 := utils.GenerateToken()
+	assert.Len(t, token1, 43)
+	assert.NotEqual(t, token1, token2)
+}
+
+func TestHashToken(t *testing.T) {
+	hash := utils.HashToken("token")
+	assert.Len(t, hash, 64)
+	assert.Equal(t, hash, utils.HashToken("token"))
+	assert.NotEqual(t, hash, utils.HashToken("other-token"))
+}